@@ -0,0 +1,125 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// errForkChoiceSnapshotMismatch is returned when a fork choice snapshot does not match the database.
+var errForkChoiceSnapshotMismatch = errors.New("fork choice snapshot does not match the database")
+
+// restoreForkChoiceSnapshot restores the fork choice store saved by the previous run, so that it does not
+// have to be rebuilt from the finalized checkpoint. It returns false if there is no snapshot, or if the
+// snapshot does not match the finalized checkpoint and blocks in the database.
+// The caller of this function MUST hold a lock in forkchoice.
+func (s *Service) restoreForkChoiceSnapshot(ctx context.Context, finalized *ethpb.Checkpoint) bool {
+	enc, err := s.cfg.BeaconDB.ForkChoiceSnapshot(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not get fork choice snapshot, rebuilding fork choice from the finalized checkpoint")
+		return false
+	}
+	if len(enc) == 0 {
+		return false
+	}
+	start := time.Now()
+	if err := s.cfg.ForkChoiceStore.RestoreSnapshot(ctx, enc, s.verifyForkChoiceSnapshot(finalized)); err != nil {
+		log.WithError(err).Warn("Could not restore fork choice snapshot, rebuilding fork choice from the finalized checkpoint")
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"nodes":    s.cfg.ForkChoiceStore.NodeCount(),
+		"duration": time.Since(start),
+	}).Info("Restored fork choice from snapshot")
+	return true
+}
+
+// verifyForkChoiceSnapshot checks that the decoded fork choice store has the same finalized checkpoint as the
+// database, and that every node matches a block saved in the database.
+func (s *Service) verifyForkChoiceSnapshot(finalized *ethpb.Checkpoint) func(context.Context, *v1.ForkChoiceDump) error {
+	return func(ctx context.Context, dump *v1.ForkChoiceDump) error {
+		fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
+		if dump.FinalizedCheckpoint.Epoch != finalized.Epoch || s.ensureRootNotZeros(bytesutil.ToBytes32(dump.FinalizedCheckpoint.Root)) != fRoot {
+			return errors.Wrapf(errForkChoiceSnapshotMismatch, "finalized checkpoint at epoch %d, expected epoch %d",
+				dump.FinalizedCheckpoint.Epoch, finalized.Epoch)
+		}
+		hasFinalized := false
+		for i, n := range dump.ForkChoiceNodes {
+			root := bytesutil.ToBytes32(n.BlockRoot)
+			if root == fRoot {
+				hasFinalized = true
+			}
+			blk, err := s.cfg.BeaconDB.Block(ctx, root)
+			if err != nil {
+				return errors.Wrapf(err, "could not get block of root=%#x", root)
+			}
+			if err := blocks.BeaconBlockIsNil(blk); err != nil {
+				return errors.Wrapf(errForkChoiceSnapshotMismatch, "no block of root=%#x in the database", root)
+			}
+			if blk.Block().Slot() != n.Slot {
+				return errors.Wrapf(errForkChoiceSnapshotMismatch, "block of root=%#x at slot %d, expected slot %d", root, n.Slot, blk.Block().Slot())
+			}
+			// The parent of the tree root node has been pruned from fork choice.
+			if parentRoot := blk.Block().ParentRoot(); i > 0 && !bytes.Equal(parentRoot[:], n.ParentRoot) {
+				return errors.Wrapf(errForkChoiceSnapshotMismatch, "block of root=%#x with parent root=%#x, expected %#x", root, n.ParentRoot, parentRoot)
+			}
+		}
+		if !hasFinalized {
+			return errors.Wrapf(errForkChoiceSnapshotMismatch, "no node for finalized root=%#x", fRoot)
+		}
+		return nil
+	}
+}
+
+// saveForkChoiceSnapshot encodes the fork choice store and saves it to the database.
+func (s *Service) saveForkChoiceSnapshot(ctx context.Context) error {
+	s.cfg.ForkChoiceStore.RLock()
+	if s.cfg.ForkChoiceStore.NodeCount() == 0 {
+		s.cfg.ForkChoiceStore.RUnlock()
+		return nil
+	}
+	enc, err := s.cfg.ForkChoiceStore.Snapshot()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not encode fork choice store")
+	}
+	return s.cfg.BeaconDB.SaveForkChoiceSnapshot(ctx, enc)
+}
+
+// runForkChoiceSnapshots saves the fork choice store at the start of every epoch, so that a node which did not
+// shut down cleanly can restore a recent store.
+func (s *Service) runForkChoiceSnapshots() {
+	clock, err := s.clockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Fork choice snapshot routine failed to receive genesis data")
+		return
+	}
+	ticker := slots.NewSlotTicker(clock.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if !slots.IsEpochStart(slot) {
+				continue
+			}
+			start := time.Now()
+			if err := s.saveForkChoiceSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save fork choice snapshot")
+				continue
+			}
+			log.WithField("duration", time.Since(start)).Debug("Saved fork choice snapshot")
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
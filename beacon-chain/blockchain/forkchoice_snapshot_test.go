@@ -0,0 +1,134 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// setupSnapshotService saves a genesis block and a finalized block to the db of a service started from them,
+// and returns the service, the finalized state and the finalized block root.
+func setupSnapshotService(t *testing.T) (*Service, *testServiceRequirements, state.BeaconState, [32]byte) {
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	finalizedSlot := params.BeaconConfig().SlotsPerEpoch * 2
+	finalizedBlock := util.NewBeaconBlock()
+	finalizedBlock.Block.Slot = finalizedSlot
+	finalizedBlock.Block.ParentRoot = genesisRoot[:]
+	finalizedRoot, err := finalizedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	finalizedState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, finalizedState.SetSlot(finalizedSlot))
+
+	c, tr := minimalTestService(t, WithFinalizedStateAtStartUp(finalizedState))
+	ctx, beaconDB := tr.ctx, tr.db
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	util.SaveBlock(t, ctx, beaconDB, finalizedBlock)
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, finalizedState, finalizedRoot))
+	cp := &ethpb.Checkpoint{Epoch: slots.ToEpoch(finalizedSlot), Root: finalizedRoot[:]}
+	require.NoError(t, beaconDB.SaveJustifiedCheckpoint(ctx, cp))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, cp))
+	require.NoError(t, c.StartFromSavedState(finalizedState))
+	return c, tr, finalizedState, finalizedRoot
+}
+
+// insertSnapshotChild inserts a child of the finalized block in fork choice, saving its block to the db if asked.
+func insertSnapshotChild(t *testing.T, c *Service, tr *testServiceRequirements, finalizedRoot [32]byte, save bool) [32]byte {
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = params.BeaconConfig().SlotsPerEpoch*2 + 1
+	blk.Block.ParentRoot = finalizedRoot[:]
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	if save {
+		util.SaveBlock(t, tr.ctx, tr.db, blk)
+	}
+	cp := &ethpb.Checkpoint{Epoch: 2, Root: finalizedRoot[:]}
+	st, root, err := prepareForkchoiceState(tr.ctx, blk.Block.Slot, root, finalizedRoot, [32]byte{'a'}, cp, cp)
+	require.NoError(t, err)
+	c.cfg.ForkChoiceStore.Lock()
+	defer c.cfg.ForkChoiceStore.Unlock()
+	require.NoError(t, c.cfg.ForkChoiceStore.InsertNode(tr.ctx, st, root))
+	return root
+}
+
+// restartSnapshotService starts a new service with an empty fork choice store from the same db.
+func restartSnapshotService(t *testing.T, tr *testServiceRequirements, finalizedState state.BeaconState) *Service {
+	fcs := doublylinkedtree.New()
+	c, err := NewService(tr.ctx,
+		WithFinalizedStateAtStartUp(finalizedState),
+		WithDatabase(tr.db),
+		WithStateNotifier(tr.notif),
+		WithStateGen(stategen.New(tr.db, fcs)),
+		WithForkChoiceStore(fcs),
+		WithClockSynchronizer(startup.NewClockSynchronizer()),
+		WithAttestationService(tr.attSrv),
+	)
+	require.NoError(t, err)
+	require.NoError(t, c.StartFromSavedState(finalizedState))
+	return c
+}
+
+func TestService_ForkChoiceSnapshot_Restore(t *testing.T) {
+	hook := logTest.NewGlobal()
+	c, tr, finalizedState, finalizedRoot := setupSnapshotService(t)
+	childRoot := insertSnapshotChild(t, c, tr, finalizedRoot, true)
+	require.NoError(t, c.saveForkChoiceSnapshot(tr.ctx))
+
+	restarted := restartSnapshotService(t, tr, finalizedState)
+	require.LogsContain(t, hook, "Restored fork choice from snapshot")
+	assert.Equal(t, 2, restarted.cfg.ForkChoiceStore.NodeCount())
+	assert.Equal(t, true, restarted.cfg.ForkChoiceStore.HasNode(childRoot))
+	assert.Equal(t, finalizedRoot, restarted.cfg.ForkChoiceStore.FinalizedCheckpoint().Root)
+}
+
+func TestService_ForkChoiceSnapshot_Fallback(t *testing.T) {
+	hook := logTest.NewGlobal()
+	c, tr, finalizedState, finalizedRoot := setupSnapshotService(t)
+	// The block of the child node is not in the db.
+	childRoot := insertSnapshotChild(t, c, tr, finalizedRoot, false)
+	require.NoError(t, c.saveForkChoiceSnapshot(tr.ctx))
+
+	restarted := restartSnapshotService(t, tr, finalizedState)
+	require.LogsContain(t, hook, "Could not restore fork choice snapshot")
+	assert.Equal(t, 1, restarted.cfg.ForkChoiceStore.NodeCount())
+	assert.Equal(t, false, restarted.cfg.ForkChoiceStore.HasNode(childRoot))
+	assert.Equal(t, true, restarted.cfg.ForkChoiceStore.HasNode(finalizedRoot))
+}
+
+func TestService_ForkChoiceSnapshot_FinalizedMismatch(t *testing.T) {
+	hook := logTest.NewGlobal()
+	c, tr, finalizedState, finalizedRoot := setupSnapshotService(t)
+	childRoot := insertSnapshotChild(t, c, tr, finalizedRoot, true)
+	require.NoError(t, c.saveForkChoiceSnapshot(tr.ctx))
+	// Finality advanced after the snapshot was saved.
+	require.NoError(t, tr.db.SaveState(tr.ctx, finalizedState, childRoot))
+	require.NoError(t, tr.db.SaveFinalizedCheckpoint(tr.ctx, &ethpb.Checkpoint{Epoch: 3, Root: childRoot[:]}))
+
+	restarted := restartSnapshotService(t, tr, finalizedState)
+	require.LogsContain(t, hook, "fork choice snapshot does not match the database")
+	assert.Equal(t, 1, restarted.cfg.ForkChoiceStore.NodeCount())
+	assert.Equal(t, childRoot, restarted.cfg.ForkChoiceStore.FinalizedCheckpoint().Root)
+}
+
+func TestService_SaveForkChoiceSnapshot_Empty(t *testing.T) {
+	c, tr := minimalTestService(t)
+	require.NoError(t, c.saveForkChoiceSnapshot(context.Background()))
+	enc, err := tr.db.ForkChoiceSnapshot(tr.ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(enc))
+}
@@ -162,6 +162,8 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 	if err := s.setHead(newHead); err != nil {
 		return errors.Wrap(err, "could not set head")
 	}
+	// Bail outs in the pool are only meaningful against the canonical chain.
+	s.updateBailoutPool(newHeadRoot, headBlock.Block().ParentRoot(), headState)
 
 	// Save the new head root to DB.
 	if err := s.cfg.BeaconDB.SaveHeadBlockRoot(ctx, newHeadRoot); err != nil {
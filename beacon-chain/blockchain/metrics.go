@@ -2,12 +2,15 @@ package blockchain
 
 import (
 	"context"
+	"math"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -107,6 +110,50 @@ var (
 		Name: "beacon_prev_epoch_head_gwei",
 		Help: "The total amount of ether, in gwei, that has been used in voting attestation head of previous epoch",
 	})
+	rewardAdjustmentFactor = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_reward_adjustment_factor",
+		Help: "The reward adjustment factor of the head state",
+	})
+	previousEpochReserve = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_previous_epoch_reserve_gwei",
+		Help: "The reserve, in gwei, left at the end of the previous epoch",
+	})
+	currentEpochReserve = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_current_epoch_reserve_gwei",
+		Help: "The reserve, in gwei, left in the current epoch",
+	})
+	epochIssuance = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_epoch_issuance_gwei",
+		Help: "The amount of new tokens, in gwei, issued in the current epoch",
+	})
+	epochFeedbackBoost = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_epoch_feedback_boost_gwei",
+		Help: "The reward boost, in gwei, paid from the reserve in the current epoch",
+	})
+	epochReserveUsage = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_epoch_reserve_usage_gwei",
+		Help: "The amount of reserve, in gwei, used in the last epoch",
+	})
+	targetDepositPlan = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_target_deposit_plan_gwei",
+		Help: "The target deposit, in gwei, of the deposit plan for the current epoch",
+	})
+	totalBalanceWithQueue = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_total_balance_with_queue_gwei",
+		Help: "The total balance, in gwei, of active and queued validators",
+	})
+	maxBoostYield = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_max_boost_yield",
+		Help: "The maximum reward adjustment factor of the current year",
+	})
+	validatorsAboveBailOutThreshold = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_validators_above_bailout_threshold",
+		Help: "The number of validators whose bail out score reached the bail out threshold",
+	})
+	pendingBailOutsCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_pending_bailouts",
+		Help: "The number of bail outs pending in the bail out pool",
+	})
 	reorgCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "beacon_reorgs_total",
 		Help: "Count the number of times beacon chain has a reorg",
@@ -357,6 +404,40 @@ func reportEpochMetrics(ctx context.Context, postState, headState state.BeaconSt
 	return nil
 }
 
+// reportTokenomicsMetrics reports tokenomics related metrics, where preState is the state before the
+// epoch transition and postState is the state right after it.
+func reportTokenomicsMetrics(preState, postState state.ReadOnlyBeaconState) error {
+	epoch := time.CurrentEpoch(postState)
+
+	rewardAdjustmentFactor.Set(float64(postState.RewardAdjustmentFactor()))
+	previousEpochReserve.Set(float64(postState.PreviousEpochReserve()))
+	currentEpochReserve.Set(float64(postState.CurrentEpochReserve()))
+	epochIssuance.Set(float64(helpers.EpochIssuance(epoch)))
+	epochFeedbackBoost.Set(float64(helpers.EpochFeedbackBoost(postState)))
+	epochReserveUsage.Set(float64(helpers.EpochReserveUsage(preState, postState)))
+	targetDepositPlan.Set(float64(helpers.TargetDepositPlan(epoch)))
+	maxBoostYield.Set(float64(helpers.MaxBoostYield(epoch)))
+
+	totalBalance, err := helpers.TotalBalanceWithQueue(postState)
+	if err != nil {
+		return errors.Wrap(err, "could not get total balance with queue")
+	}
+	totalBalanceWithQueue.Set(float64(totalBalance))
+
+	scores, err := postState.BailOutScores()
+	if err != nil {
+		return errors.Wrap(err, "could not get bail out scores")
+	}
+	aboveThreshold := 0
+	for _, s := range scores {
+		if s >= params.BeaconConfig().BailOutScoreThreshold && s < math.MaxUint64 {
+			aboveThreshold++
+		}
+	}
+	validatorsAboveBailOutThreshold.Set(float64(aboveThreshold))
+	return nil
+}
+
 func reportAttestationInclusion(blk interfaces.ReadOnlyBeaconBlock) {
 	for _, att := range blk.Body().Attestations() {
 		attestationInclusionDelay.Observe(float64(blk.Slot() - att.Data.Slot))
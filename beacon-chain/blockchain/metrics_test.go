@@ -2,9 +2,16 @@ package blockchain
 
 import (
 	"context"
+	"math"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 )
@@ -39,3 +46,60 @@ func TestReportEpochMetrics_SlashedValidatorOutOfBound(t *testing.T) {
 	err = reportEpochMetrics(context.Background(), h, h)
 	require.ErrorContains(t, "slot 0 out of bounds", err)
 }
+
+func TestReportTokenomicsMetrics(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	pre, _ := util.DeterministicGenesisStateCapella(t, 4)
+	require.NoError(t, pre.SetPreviousEpochReserve(1000))
+	require.NoError(t, pre.SetCurrentEpochReserve(700))
+	post := pre.Copy()
+	require.NoError(t, post.SetSlot(cfg.SlotsPerEpoch))
+	require.NoError(t, post.SetRewardAdjustmentFactor(cfg.MaxBoostYield[0]))
+	require.NoError(t, post.SetPreviousEpochReserve(900))
+	require.NoError(t, post.SetCurrentEpochReserve(600))
+	require.NoError(t, post.SetBailOutScores([]uint64{0, cfg.BailOutScoreThreshold, cfg.BailOutScoreThreshold + 1, math.MaxUint64}))
+
+	require.NoError(t, reportTokenomicsMetrics(pre, post))
+
+	gaugeValue := func(g prometheus.Gauge) float64 {
+		m := &dto.Metric{}
+		require.NoError(t, g.Write(m))
+		return m.GetGauge().GetValue()
+	}
+	totalBalance, err := helpers.TotalBalanceWithQueue(post)
+	require.NoError(t, err)
+	assert.Equal(t, float64(cfg.MaxBoostYield[0]), gaugeValue(rewardAdjustmentFactor))
+	assert.Equal(t, float64(900), gaugeValue(previousEpochReserve))
+	assert.Equal(t, float64(600), gaugeValue(currentEpochReserve))
+	assert.Equal(t, float64(400), gaugeValue(epochReserveUsage))
+	assert.Equal(t, float64(helpers.EpochIssuance(1)), gaugeValue(epochIssuance))
+	assert.Equal(t, float64(900), gaugeValue(epochFeedbackBoost))
+	assert.Equal(t, float64(helpers.TargetDepositPlan(1)), gaugeValue(targetDepositPlan))
+	assert.Equal(t, float64(totalBalance), gaugeValue(totalBalanceWithQueue))
+	assert.Equal(t, float64(cfg.MaxBoostYield[0]), gaugeValue(maxBoostYield))
+	assert.Equal(t, float64(2), gaugeValue(validatorsAboveBailOutThreshold))
+}
+
+func TestHandleEpochBoundary_ReportsTokenomicsMetrics(t *testing.T) {
+	// The service has no bail out pool.
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	st, _ := util.DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	require.NoError(t, st.SetPreviousEpochReserve(10000000*1e9))
+	require.NoError(t, st.SetCurrentEpochReserve(9999000*1e9))
+	service.head = &head{state: st}
+	root := [32]byte{'a'}
+
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, root[:]))
+	postState, err := transition.ProcessSlots(ctx, st.Copy(), 2*params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	m := &dto.Metric{}
+	require.NoError(t, currentEpochReserve.Write(m))
+	assert.Equal(t, float64(postState.CurrentEpochReserve()), m.GetGauge().GetValue())
+	m = &dto.Metric{}
+	require.NoError(t, epochReserveUsage.Write(m))
+	assert.Equal(t, float64(helpers.EpochReserveUsage(st, postState)), m.GetGauge().GetValue())
+}
@@ -62,6 +62,14 @@ func WithExecutionEngineCaller(c execution.EngineCaller) Option {
 	}
 }
 
+// WithExecutionPayloadReconstructor to reconstruct the execution payloads of blinded blocks.
+func WithExecutionPayloadReconstructor(r execution.ExecutionPayloadReconstructor) Option {
+	return func(s *Service) error {
+		s.cfg.ExecutionPayloadReconstructor = r
+		return nil
+	}
+}
+
 // WithDepositCache for deposit lifecycle after chain inclusion.
 func WithDepositCache(c *depositcache.DepositCache) Option {
 	return func(s *Service) error {
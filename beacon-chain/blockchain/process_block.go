@@ -349,8 +349,8 @@ func (s *Service) updateEpochBoundaryCaches(ctx context.Context, st state.Beacon
 	return nil
 }
 
-// Epoch boundary tasks: it copies the headState and updates the epoch boundary
-// caches.
+// Epoch boundary tasks: it copies the headState, reports the tokenomics of the epoch
+// transition of the head and updates the epoch boundary caches.
 func (s *Service) handleEpochBoundary(ctx context.Context, slot primitives.Slot, headState state.BeaconState, blockRoot []byte) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.handleEpochBoundary")
 	defer span.End()
@@ -366,6 +366,23 @@ func (s *Service) handleEpochBoundary(ctx context.Context, slot primitives.Slot,
 	if err != nil {
 		return err
 	}
+	s.notifyTokenomics(copied, blockRoot)
+	if features.Get().EnableTokenomicsHistory {
+		s.recordTokenomics(ctx, headState, copied, bytesutil.ToBytes32(blockRoot))
+	}
+	if copied.Version() >= version.Altair {
+		if s.cfg.BailoutPool != nil {
+			pendingBailOuts, err := s.cfg.BailoutPool.PendingBailOuts()
+			if err != nil {
+				log.WithError(err).Error("could not get pending bail outs")
+			} else {
+				pendingBailOutsCount.Set(float64(len(pendingBailOuts)))
+			}
+		}
+		if err := reportTokenomicsMetrics(headState, copied); err != nil {
+			log.WithError(err).Error("could not report tokenomics metrics")
+		}
+	}
 	return s.updateEpochBoundaryCaches(ctx, copied)
 }
 
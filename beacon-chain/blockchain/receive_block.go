@@ -73,13 +73,6 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 	if err != nil {
 		return errors.Wrap(err, "failed to validate consensus state transition function")
 	}
-	// If received block is last block of the epoch, update the bailout pool.
-	if ctime.CanProcessEpoch(preState) {
-		err = s.updateBailoutPool(postState)
-		if err != nil {
-			return errors.Wrap(err, "failed to update bailout pool")
-		}
-	}
 	isValidPayload, err := s.validateExecutionOnBlock(ctx, preStateVersion, preStateHeader, blockCopy, blockRoot)
 	if err != nil {
 		return errors.Wrap(err, "could not notify the engine of the new payload")
@@ -117,6 +110,14 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 	if newFinalized {
 		finalized := s.cfg.ForkChoiceStore.FinalizedCheckpoint()
 		go s.sendNewFinalizedEvent(ctx, blockCopy, postState, finalized)
+		headRoot, err := s.HeadRoot(ctx)
+		if err != nil {
+			log.WithError(err).Error("Could not get head root")
+		} else if headSt, err := s.HeadState(ctx); err != nil {
+			log.WithError(err).Error("Could not get head state")
+		} else {
+			s.rebuildBailoutPool(bytesutil.ToBytes32(headRoot), headSt)
+		}
 		depCtx, cancel := context.WithTimeout(context.Background(), depositDeadline)
 		go func() {
 			s.insertFinalizedDeposits(depCtx, finalized.Root)
@@ -133,6 +134,11 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySig
 	if err := s.prunePostBlockOperationPools(ctx, blockCopy, blockRoot); err != nil {
 		log.WithError(err).Error("Could not prune canonical objects from pool ")
 	}
+	if features.Get().EnableWithdrawalsHistory {
+		if err := s.saveCanonicalWithdrawals(ctx, blockCopy, blockRoot); err != nil {
+			log.WithError(err).Error("Could not save withdrawal records")
+		}
+	}
 
 	// Have we been finalizing? Should we start saving hot states to db?
 	if err := s.checkSaveHotStateDB(ctx); err != nil {
@@ -262,6 +268,17 @@ func (s *Service) prunePostBlockOperationPools(ctx context.Context, blk interfac
 		s.cfg.SlashingPool.MarkIncludedProposerSlashing(ps)
 	}
 
+	// Mark bail outs as seen, the bailout pool is not rebuilt when the head extends the previous head.
+	if s.cfg.BailoutPool != nil && blk.Version() >= version.Altair {
+		bailOuts, err := blk.Block().Body().BailOuts()
+		if err != nil {
+			return errors.Wrap(err, "could not get bail outs")
+		}
+		for _, b := range bailOuts {
+			s.cfg.BailoutPool.MarkIncluded(b)
+		}
+	}
+
 	return nil
 }
 
@@ -408,16 +425,43 @@ func (s *Service) validateExecutionOnBlock(ctx context.Context, ver int, header
 	return isValidPayload, nil
 }
 
-// validateExecutionOnBlock notifies the engine of the incoming block execution payload and returns true if the payload is valid
-func (s *Service) updateBailoutPool(postState state.BeaconState) error {
-	if postState.Version() < version.Altair {
-		return nil
+// updateBailoutPool keeps the bailout pool in line with the new head of the given root. Bail out scores
+// only change at epoch transitions, so when the new head is a child of the previous head in the same
+// epoch, the pool is kept and the bail outs of the new head are marked by prunePostBlockOperationPools.
+// On any other head change, such as a reorg which may orphan included bail outs, the pool is rebuilt.
+func (s *Service) updateBailoutPool(headRoot, parentRoot [32]byte, headState state.ReadOnlyBeaconState) {
+	if s.cfg.BailoutPool == nil || headState.Version() < version.Altair {
+		return
 	}
+	head := epochTransition{epoch: slots.ToEpoch(headState.Slot()), root: headRoot}
 
-	if !s.cfg.BailoutPool.IsInitialized() {
-		s.cfg.BailoutPool.Initialize(postState)
-	} else {
-		s.cfg.BailoutPool.UpdateBailOuts(postState)
+	s.bailoutPoolLock.Lock()
+	defer s.bailoutPoolLock.Unlock()
+	if head.epoch == s.bailoutPoolHead.epoch && parentRoot == s.bailoutPoolHead.root && s.cfg.BailoutPool.IsInitialized() {
+		s.bailoutPoolHead = head
+		return
 	}
-	return nil
+	s.rebuildBailoutPoolLocked(head, headState)
+}
+
+// rebuildBailoutPool rebuilds the bailout pool from the state of the head of the given root so that
+// proposers only see bail outs which are valid on the canonical chain.
+func (s *Service) rebuildBailoutPool(headRoot [32]byte, headState state.ReadOnlyBeaconState) {
+	if s.cfg.BailoutPool == nil || headState.Version() < version.Altair {
+		return
+	}
+	s.bailoutPoolLock.Lock()
+	defer s.bailoutPoolLock.Unlock()
+	s.rebuildBailoutPoolLocked(epochTransition{epoch: slots.ToEpoch(headState.Slot()), root: headRoot}, headState)
+}
+
+// rebuildBailoutPoolLocked rebuilds the bailout pool from the head state. The caller must hold the
+// bailout pool lock.
+func (s *Service) rebuildBailoutPoolLocked(head epochTransition, headState state.ReadOnlyBeaconState) {
+	if err := s.cfg.BailoutPool.Rebuild(headState); err != nil {
+		log.WithError(err).Error("Could not rebuild bailout pool")
+		s.bailoutPoolHead = epochTransition{}
+		return
+	}
+	s.bailoutPoolHead = head
 }
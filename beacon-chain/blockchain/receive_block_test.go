@@ -8,6 +8,7 @@ import (
 
 	blockchainTesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/bailout"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
@@ -187,7 +188,7 @@ func TestService_ReceiveBlockWithBailOut(t *testing.T) {
 		check     func(*testing.T, *Service)
 	}{
 		{
-			name: "updates bailout pool",
+			name: "rebuilds bailout pool from head",
 			args: args{
 				block: genFullBlockAltair(t, &util.BlockGenConfig{
 					NumProposerSlashings: 0,
@@ -203,13 +204,19 @@ func TestService_ReceiveBlockWithBailOut(t *testing.T) {
 			check: func(t *testing.T, s *Service) {
 				pending, err := s.cfg.BailoutPool.PendingBailOuts()
 				require.NoError(t, err)
-				if len(pending) != 0 {
+				// Every validator is above the threshold, except for the ones bailed out in the head block.
+				if len(pending) != 61 {
 					t.Errorf(
 						"Did not mark the correct number of exits. Got %d pending but wanted %d",
 						len(pending),
-						0,
+						61,
 					)
 				}
+				headState, err := s.HeadState(context.Background())
+				require.NoError(t, err)
+				for _, b := range pending {
+					require.NoError(t, coreblocks.VerifyBailOut(headState, b.ValidatorIndex))
+				}
 			},
 		},
 	}
@@ -248,6 +255,51 @@ func TestService_ReceiveBlockWithBailOut(t *testing.T) {
 	wg.Wait()
 }
 
+func TestService_UpdateBailoutPool(t *testing.T) {
+	pool := bailout.NewPool()
+	s, _ := minimalTestService(t, WithBailoutPool(pool))
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 4)
+	scores := make([]uint64, 4)
+	for i := range scores {
+		scores[i] = params.BeaconConfig().BailOutScoreThreshold
+	}
+	require.NoError(t, st.SetBailOutScores(scores))
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+	pendingCount := func() int {
+		pending, err := pool.PendingBailOuts()
+		require.NoError(t, err)
+		return len(pending)
+	}
+	rootA, rootB, rootC, rootD := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}, [32]byte{'d'}
+	s.updateBailoutPool(rootA, [32]byte{}, st)
+	require.Equal(t, 4, pendingCount())
+
+	// The pool is kept when the head extends the previous head, the bail out of the new head being
+	// marked included.
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch+1))
+	s.updateBailoutPool(rootB, rootA, st)
+	pool.MarkIncluded(&ethpb.BailOut{ValidatorIndex: 0})
+	require.Equal(t, 3, pendingCount())
+
+	// A bail out only included in an orphaned sibling comes back.
+	s.updateBailoutPool(rootC, rootA, st)
+	require.Equal(t, 4, pendingCount())
+
+	// So does one included in the previous epoch, as the head moves to the next epoch.
+	s.updateBailoutPool(rootD, rootC, st)
+	pool.MarkIncluded(&ethpb.BailOut{ValidatorIndex: 0})
+	require.Equal(t, 3, pendingCount())
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch))
+	s.updateBailoutPool([32]byte{'e'}, rootD, st)
+	require.Equal(t, 4, pendingCount())
+
+	// A rebuild is forced, e.g. on finality.
+	pool.MarkIncluded(&ethpb.BailOut{ValidatorIndex: 0})
+	s.rebuildBailoutPool([32]byte{'e'}, st)
+	require.Equal(t, 4, pendingCount())
+}
+
 func TestService_ReceiveBlockUpdateHead(t *testing.T) {
 	s, tr := minimalTestService(t,
 		WithExitPool(voluntaryexits.NewPool()),
@@ -20,6 +20,7 @@ import (
 	coreTime "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	f "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
@@ -61,31 +62,37 @@ type Service struct {
 	clockSetter          startup.ClockSetter
 	clockWaiter          startup.ClockWaiter
 	syncComplete         chan struct{}
+	tokenomicsLock       sync.Mutex
+	tokenomicsNotified   epochTransition                               // last epoch transition a tokenomics event was sent for
+	tokenomicsRecords    map[epochTransition]*dbtypes.TokenomicsRecord // records of the epoch transitions of the head into epochs which are not finalized yet
+	bailoutPoolLock      sync.Mutex
+	bailoutPoolHead      epochTransition // epoch and root of the last head the bailout pool follows
 }
 
 // config options for the service.
 type config struct {
-	BeaconBlockBuf          int
-	ChainStartFetcher       execution.ChainStartFetcher
-	BeaconDB                db.HeadAccessDatabase
-	DepositCache            *depositcache.DepositCache
-	ProposerSlotIndexCache  *cache.ProposerPayloadIDsCache
-	AttPool                 attestations.Pool
-	ExitPool                voluntaryexits.PoolManager
-	BailoutPool             bailout.PoolManager
-	SlashingPool            slashings.PoolManager
-	BLSToExecPool           blstoexec.PoolManager
-	P2p                     p2p.Broadcaster
-	MaxRoutines             int
-	StateNotifier           statefeed.Notifier
-	ForkChoiceStore         f.ForkChoicer
-	AttService              *attestations.Service
-	StateGen                *stategen.State
-	SlasherAttestationsFeed *event.Feed
-	WeakSubjectivityCheckpt *ethpb.Checkpoint
-	BlockFetcher            execution.POWBlockFetcher
-	FinalizedStateAtStartUp state.BeaconState
-	ExecutionEngineCaller   execution.EngineCaller
+	BeaconBlockBuf                int
+	ChainStartFetcher             execution.ChainStartFetcher
+	BeaconDB                      db.HeadAccessDatabase
+	DepositCache                  *depositcache.DepositCache
+	ProposerSlotIndexCache        *cache.ProposerPayloadIDsCache
+	AttPool                       attestations.Pool
+	ExitPool                      voluntaryexits.PoolManager
+	BailoutPool                   bailout.PoolManager
+	SlashingPool                  slashings.PoolManager
+	BLSToExecPool                 blstoexec.PoolManager
+	P2p                           p2p.Broadcaster
+	MaxRoutines                   int
+	StateNotifier                 statefeed.Notifier
+	ForkChoiceStore               f.ForkChoicer
+	AttService                    *attestations.Service
+	StateGen                      *stategen.State
+	SlasherAttestationsFeed       *event.Feed
+	WeakSubjectivityCheckpt       *ethpb.Checkpoint
+	BlockFetcher                  execution.POWBlockFetcher
+	FinalizedStateAtStartUp       state.BeaconState
+	ExecutionEngineCaller         execution.EngineCaller
+	ExecutionPayloadReconstructor execution.ExecutionPayloadReconstructor
 }
 
 var ErrMissingClockSetter = errors.New("blockchain Service initialized without a startup.ClockSetter")
@@ -100,6 +107,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		boundaryRoots:        [][32]byte{},
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
+		tokenomicsRecords:    make(map[epochTransition]*dbtypes.TokenomicsRecord),
 		cfg:                  &config{ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache()},
 	}
 	for _, opt := range opts {
@@ -133,6 +141,13 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	go s.runForkChoiceSnapshots()
+	if features.Get().EnableTokenomicsHistory {
+		go s.runTokenomicsHistory()
+	}
+	if features.Get().EnableWithdrawalsHistory {
+		go s.runWithdrawalsHistoryBackfill()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
@@ -151,6 +166,12 @@ func (s *Service) Stop() error {
 	} else {
 		s.headLock.RUnlock()
 	}
+	// Save fork choice so that it does not have to be rebuilt from the finalized checkpoint in the following run.
+	if s.cfg.ForkChoiceStore != nil {
+		if err := s.saveForkChoiceSnapshot(s.ctx); err != nil {
+			log.WithError(err).Error("Could not save fork choice snapshot")
+		}
+	}
 	// Save initial sync cached blocks to the DB before stop.
 	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
 }
@@ -208,9 +229,33 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 		return errNilFinalizedCheckpoint
 	}
 
-	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
+	if s.restoreForkChoiceSnapshot(s.ctx, finalized) {
+		s.cfg.ForkChoiceStore.SetGenesisTime(uint64(s.genesisTime.Unix()))
+	} else if err := s.initializeForkChoiceFromFinalized(justified, finalized); err != nil {
+		return err
+	}
+	// not attempting to save initial sync blocks here, because there shouldn't be any until
+	// after the statefeed.Initialized event is fired (below)
+	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
+		// Exit run time if the node failed to verify weak subjectivity checkpoint.
+		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
+	}
+
+	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
+	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
+		return errors.Wrap(err, "failed to initialize blockchain service")
+	}
+
+	return nil
+}
+
+// initializeForkChoiceFromFinalized initializes fork choice with the justified and finalized checkpoints,
+// and the finalized block as the tree root node.
+// The caller of this function MUST hold a lock in forkchoice.
+func (s *Service) initializeForkChoiceFromFinalized(justified, finalized *ethpb.Checkpoint) error {
+	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	if err := s.cfg.ForkChoiceStore.UpdateJustifiedCheckpoint(s.ctx, &forkchoicetypes.Checkpoint{
 		Epoch: justified.Epoch,
 		Root:  bytesutil.ToBytes32(justified.Root),
@@ -243,18 +288,6 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 			}
 		}
 	}
-	// not attempting to save initial sync blocks here, because there shouldn't be any until
-	// after the statefeed.Initialized event is fired (below)
-	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
-		// Exit run time if the node failed to verify weak subjectivity checkpoint.
-		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
-	}
-
-	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
-	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
-		return errors.Wrap(err, "failed to initialize blockchain service")
-	}
-
 	return nil
 }
 
@@ -0,0 +1,248 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// tokenomicsRecord builds the tokenomics record of the epoch of postState, where preState is
+// the state at the last slot of the previous epoch and postState is the state right after
+// the epoch transition.
+func tokenomicsRecord(preState, postState state.ReadOnlyBeaconState) (*dbtypes.TokenomicsRecord, error) {
+	epoch := time.CurrentEpoch(postState)
+	if time.CurrentEpoch(preState)+1 != epoch {
+		return nil, errors.Errorf("states are not one epoch transition apart, pre epoch %d, post epoch %d", time.CurrentEpoch(preState), epoch)
+	}
+	totalActiveBalance, err := helpers.TotalActiveBalance(postState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	return &dbtypes.TokenomicsRecord{
+		Epoch:                  epoch,
+		RewardAdjustmentFactor: postState.RewardAdjustmentFactor(),
+		PreviousEpochReserve:   preState.PreviousEpochReserve(),
+		CurrentEpochReserve:    postState.CurrentEpochReserve(),
+		EpochIssuance:          helpers.EpochIssuance(epoch),
+		EpochFeedbackBoost:     helpers.EpochFeedbackBoost(postState),
+		ReserveUsage:           helpers.EpochReserveUsage(preState, postState),
+		TotalActiveBalance:     totalActiveBalance,
+		TargetDepositPlan:      helpers.TargetDepositPlan(epoch),
+	}, nil
+}
+
+// epochTransition identifies an epoch transition by the epoch it starts and the block root it was
+// processed on top of.
+type epochTransition struct {
+	epoch primitives.Epoch
+	root  [32]byte
+}
+
+// notifyTokenomics sends a state feed event with the tokenomics of the epoch started by postState,
+// the state right after an epoch transition processed on top of blockRoot. The epoch boundary is
+// handled both when the block is processed and by the late block tasks, so the event is only sent
+// once per epoch transition of the head.
+func (s *Service) notifyTokenomics(postState state.ReadOnlyBeaconState, blockRoot []byte) {
+	epoch := time.CurrentEpoch(postState)
+	notified := epochTransition{epoch: epoch, root: bytesutil.ToBytes32(blockRoot)}
+	s.tokenomicsLock.Lock()
+	if notified == s.tokenomicsNotified {
+		s.tokenomicsLock.Unlock()
+		return
+	}
+	s.tokenomicsNotified = notified
+	s.tokenomicsLock.Unlock()
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.Tokenomics,
+		Data: &ethpbv1.EventTokenomics{
+			Epoch:                  epoch,
+			Block:                  bytesutil.SafeCopyBytes(blockRoot),
+			RewardAdjustmentFactor: postState.RewardAdjustmentFactor(),
+			PreviousEpochReserve:   postState.PreviousEpochReserve(),
+			CurrentEpochReserve:    postState.CurrentEpochReserve(),
+			EpochIssuance:          helpers.EpochIssuance(epoch),
+			EpochFeedbackBoost:     helpers.EpochFeedbackBoost(postState),
+		},
+	})
+}
+
+// recordTokenomics saves the tokenomics record of the epoch transition of the head from preState to
+// postState, processed on top of blockRoot, so that the history covers the epochs which are not
+// finalized yet. The record is kept in memory until the epoch finalizes, as the record saved for an
+// epoch may be the one of a transition which is later orphaned.
+func (s *Service) recordTokenomics(ctx context.Context, preState, postState state.ReadOnlyBeaconState, blockRoot [32]byte) {
+	record, err := tokenomicsRecord(preState, postState)
+	if err != nil {
+		// Transitions over empty epochs are left to the backfill routine.
+		log.WithError(err).Debug("Could not build tokenomics record")
+		return
+	}
+	s.tokenomicsLock.Lock()
+	s.tokenomicsRecords[epochTransition{epoch: record.Epoch, root: blockRoot}] = record
+	s.tokenomicsLock.Unlock()
+	if err := s.cfg.BeaconDB.SaveTokenomicsRecord(ctx, record); err != nil {
+		log.WithError(err).Error("Could not save tokenomics record")
+	}
+}
+
+// saveFinalizedTokenomics saves the records kept by recordTokenomics of the canonical epoch transitions
+// into the epochs up to finalized, overwriting the records of orphaned transitions, and drops the
+// records of these epochs from memory. It returns the epochs whose canonical transition was not
+// recorded, whose saved record may be the one of an orphaned transition.
+func (s *Service) saveFinalizedTokenomics(ctx context.Context, finalized primitives.Epoch) map[primitives.Epoch]bool {
+	records := make(map[epochTransition]*dbtypes.TokenomicsRecord)
+	s.tokenomicsLock.Lock()
+	for transition, record := range s.tokenomicsRecords {
+		if transition.epoch <= finalized {
+			records[transition] = record
+			delete(s.tokenomicsRecords, transition)
+		}
+	}
+	s.tokenomicsLock.Unlock()
+
+	stale := make(map[primitives.Epoch]bool)
+	for transition := range records {
+		stale[transition.epoch] = true
+	}
+	if len(records) == 0 {
+		return stale
+	}
+	headState, err := s.HeadStateReadOnly(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head state")
+		return stale
+	}
+	for transition, record := range records {
+		start, err := slots.EpochStart(transition.epoch)
+		if err != nil {
+			continue
+		}
+		// The canonical transition is the one processed on top of the last block before the epoch.
+		root, err := helpers.BlockRootAtSlot(headState, start-1)
+		if err != nil || bytesutil.ToBytes32(root) != transition.root {
+			continue
+		}
+		if err := s.cfg.BeaconDB.SaveTokenomicsRecord(ctx, record); err != nil {
+			log.WithError(err).Error("Could not save tokenomics record")
+			continue
+		}
+		delete(stale, transition.epoch)
+	}
+	return stale
+}
+
+// runTokenomicsHistory saves the records of the canonical epoch transitions once their epoch is
+// finalized. It also fills in the finalized epochs which were not recorded, e.g. because they were
+// finalized before the feature was enabled or processed during initial sync, and the finalized epochs
+// whose canonical transition never was the one of the head. It runs once at every epoch start.
+func (s *Service) runTokenomicsHistory() {
+	if err := s.waitForSync(); err != nil {
+		log.WithError(err).Error("failed to wait for initial sync")
+		return
+	}
+
+	history := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s, s)
+	// Epochs up to backfilled have already been visited.
+	var backfilled primitives.Epoch
+	backfill := func() {
+		finalized := s.FinalizedCheckpt().Epoch
+		stale := s.saveFinalizedTokenomics(s.ctx, finalized)
+		from := backfilled
+		for epoch := range stale {
+			if epoch <= from {
+				from = epoch - 1
+			}
+		}
+		if finalized > from {
+			s.backfillTokenomicsHistory(s.ctx, history, from, finalized, stale)
+		}
+		if finalized > backfilled {
+			backfilled = finalized
+		}
+	}
+
+	backfill()
+	ticker := slots.NewSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if slots.IsEpochStart(slot) {
+				backfill()
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// backfillTokenomicsHistory saves the tokenomics records of the finalized epochs in (from, to] which are
+// missing or stale, going backwards from `to`. It stops at the first epoch which can not be replayed,
+// as no older history is available in that case (e.g. before the checkpoint of a checkpoint synced node).
+func (s *Service) backfillTokenomicsHistory(
+	ctx context.Context,
+	history *stategen.CanonicalHistory,
+	from, to primitives.Epoch,
+	stale map[primitives.Epoch]bool,
+) {
+	saved := 0
+	for epoch := to; epoch > from; epoch-- {
+		if ctx.Err() != nil {
+			return
+		}
+		if !stale[epoch] {
+			existing, err := s.cfg.BeaconDB.TokenomicsRecord(ctx, epoch)
+			if err != nil {
+				log.WithError(err).Error("Could not get tokenomics record")
+				return
+			}
+			if existing != nil {
+				continue
+			}
+		}
+		record, err := s.replayTokenomicsRecord(ctx, history, epoch)
+		if err != nil {
+			log.WithError(err).WithField("epoch", epoch).Debug("Stopped backfilling tokenomics history")
+			break
+		}
+		if err := s.cfg.BeaconDB.SaveTokenomicsRecord(ctx, record); err != nil {
+			log.WithError(err).Error("Could not save tokenomics record")
+			return
+		}
+		saved++
+	}
+	if saved > 0 {
+		log.WithField("records", saved).Info("Backfilled tokenomics history")
+	}
+}
+
+// replayTokenomicsRecord rebuilds the tokenomics record of the given epoch by replaying the canonical chain.
+func (s *Service) replayTokenomicsRecord(ctx context.Context, history *stategen.CanonicalHistory, epoch primitives.Epoch) (*dbtypes.TokenomicsRecord, error) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	preState, err := history.ReplayerForSlot(startSlot - 1).ReplayBlocks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not replay blocks")
+	}
+	postState, err := transition.ProcessSlots(ctx, preState.Copy(), startSlot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process epoch transition")
+	}
+	return tokenomicsRecord(preState, postState)
+}
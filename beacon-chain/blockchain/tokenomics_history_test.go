@@ -0,0 +1,238 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestTokenomicsRecord(t *testing.T) {
+	preState, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, preState.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	require.NoError(t, preState.SetPreviousEpochReserve(10000000*1e9))
+	// Part of the reserve was already spent by the blocks of the epoch.
+	require.NoError(t, preState.SetCurrentEpochReserve(9999000*1e9))
+	require.NoError(t, preState.SetRewardAdjustmentFactor(params.BeaconConfig().RewardFeedbackPrecision/1000))
+	postState, err := transition.ProcessSlots(context.Background(), preState.Copy(), 2*params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+
+	record, err := tokenomicsRecord(preState, postState)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(2), record.Epoch)
+	assert.Equal(t, postState.RewardAdjustmentFactor(), record.RewardAdjustmentFactor)
+	assert.Equal(t, preState.PreviousEpochReserve(), record.PreviousEpochReserve)
+	assert.Equal(t, postState.CurrentEpochReserve(), record.CurrentEpochReserve)
+	assert.Equal(t, preState.PreviousEpochReserve()-postState.CurrentEpochReserve(), record.ReserveUsage)
+	assert.Equal(t, true, record.ReserveUsage > preState.CurrentEpochReserve()-postState.CurrentEpochReserve())
+	assert.Equal(t, helpers.EpochIssuance(2), record.EpochIssuance)
+	assert.Equal(t, helpers.EpochFeedbackBoost(postState), record.EpochFeedbackBoost)
+	assert.Equal(t, helpers.TargetDepositPlan(2), record.TargetDepositPlan)
+	totalActiveBalance, err := helpers.TotalActiveBalance(postState)
+	require.NoError(t, err)
+	assert.Equal(t, totalActiveBalance, record.TotalActiveBalance)
+
+	_, err = tokenomicsRecord(preState, preState)
+	require.ErrorContains(t, "states are not one epoch transition apart", err)
+}
+
+func TestHandleEpochBoundary_SavesTokenomicsRecord(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnableTokenomicsHistory: true,
+	})
+	defer resetCfg()
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	service.head = &head{state: st}
+	root := [32]byte{'a'}
+
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, root[:]))
+	postState, err := transition.ProcessSlots(ctx, st.Copy(), 2*params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	want, err := tokenomicsRecord(st, postState)
+	require.NoError(t, err)
+	record, err := service.cfg.BeaconDB.TokenomicsRecord(ctx, 2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, record)
+	assert.DeepEqual(t, want, service.tokenomicsRecords[epochTransition{epoch: 2, root: root}])
+}
+
+func TestHandleEpochBoundary_DoesNotSaveTokenomicsRecordWhenDisabled(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	service.head = &head{state: st}
+	root := [32]byte{'a'}
+
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, root[:]))
+	record, err := service.cfg.BeaconDB.TokenomicsRecord(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, true, record == nil, "Saved a tokenomics record with the tokenomics history disabled")
+	assert.Equal(t, 0, len(service.tokenomicsRecords))
+}
+
+func TestSaveFinalizedTokenomics(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	canonicalRoot := [32]byte{'a'}
+	orphanedRoot := [32]byte{'b'}
+	canonical := &dbtypes.TokenomicsRecord{Epoch: 2, CurrentEpochReserve: 1}
+	orphaned := &dbtypes.TokenomicsRecord{Epoch: 2, CurrentEpochReserve: 2}
+	orphanedOnly := &dbtypes.TokenomicsRecord{Epoch: 3, CurrentEpochReserve: 3}
+	unfinalized := &dbtypes.TokenomicsRecord{Epoch: 4, CurrentEpochReserve: 4}
+	service.tokenomicsRecords[epochTransition{epoch: 2, root: canonicalRoot}] = canonical
+	service.tokenomicsRecords[epochTransition{epoch: 2, root: orphanedRoot}] = orphaned
+	service.tokenomicsRecords[epochTransition{epoch: 3, root: orphanedRoot}] = orphanedOnly
+	service.tokenomicsRecords[epochTransition{epoch: 4, root: canonicalRoot}] = unfinalized
+	// The orphaned transition was the last one of the head to be recorded.
+	require.NoError(t, service.cfg.BeaconDB.SaveTokenomicsRecord(ctx, orphaned))
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(5*params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(t, st.UpdateBlockRootAtIndex(uint64(2*params.BeaconConfig().SlotsPerEpoch-1), canonicalRoot))
+	service.head = &head{state: st}
+
+	stale := service.saveFinalizedTokenomics(ctx, 3)
+	assert.DeepEqual(t, map[primitives.Epoch]bool{3: true}, stale)
+	record, err := service.cfg.BeaconDB.TokenomicsRecord(ctx, 2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, canonical, record)
+	assert.Equal(t, 1, len(service.tokenomicsRecords))
+	assert.DeepEqual(t, unfinalized, service.tokenomicsRecords[epochTransition{epoch: 4, root: canonicalRoot}])
+}
+
+func TestHandleEpochBoundary_NotifiesTokenomics(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	require.NoError(t, st.SetPreviousEpochReserve(10000000*1e9))
+	require.NoError(t, st.SetCurrentEpochReserve(10000000*1e9))
+	service.head = &head{state: st}
+	root := [32]byte{'b'}
+
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := service.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, root[:]))
+
+	postState, err := transition.ProcessSlots(ctx, st.Copy(), 2*params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	e := <-stateChannel
+	require.Equal(t, statefeed.Tokenomics, int(e.Type))
+	data, ok := e.Data.(*ethpbv1.EventTokenomics)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, &ethpbv1.EventTokenomics{
+		Epoch:                  2,
+		Block:                  root[:],
+		RewardAdjustmentFactor: postState.RewardAdjustmentFactor(),
+		PreviousEpochReserve:   postState.PreviousEpochReserve(),
+		CurrentEpochReserve:    postState.CurrentEpochReserve(),
+		EpochIssuance:          helpers.EpochIssuance(2),
+		EpochFeedbackBoost:     helpers.EpochFeedbackBoost(postState),
+	}, data)
+}
+
+func TestHandleEpochBoundary_NotifiesTokenomicsOncePerTransition(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch-1))
+	service.head = &head{state: st}
+	root := [32]byte{'b'}
+	otherRoot := [32]byte{'c'}
+
+	stateChannel := make(chan *feed.Event, 3)
+	stateSub := service.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	// The block path and the late block tasks both handle the same epoch boundary.
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, root[:]))
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, root[:]))
+	// A different head block makes a new epoch transition.
+	require.NoError(t, service.handleEpochBoundary(ctx, st.Slot(), st, otherRoot[:]))
+
+	roots := make([][]byte, 0, 2)
+	for len(stateChannel) > 0 {
+		e := <-stateChannel
+		if e.Type != statefeed.Tokenomics {
+			continue
+		}
+		data, ok := e.Data.(*ethpbv1.EventTokenomics)
+		require.Equal(t, true, ok)
+		roots = append(roots, data.Block)
+	}
+	assert.DeepEqual(t, [][]byte{root[:], otherRoot[:]}, roots)
+}
+
+func TestBackfillTokenomicsHistory(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx, fcs := tr.ctx, tr.fcs
+
+	gs, keys := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, service.saveGenesisData(ctx, gs))
+	require.NoError(t, fcs.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Root: service.originBlockRoot}))
+
+	testState := gs.Copy()
+	var epochEndRoot [32]byte
+	for i := primitives.Slot(1); i <= 2*params.BeaconConfig().SlotsPerEpoch; i++ {
+		blk, err := util.GenerateFullBlock(testState, keys, util.DefaultBlockGenConfig(), i)
+		require.NoError(t, err)
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		require.NoError(t, fcs.NewSlot(ctx, i))
+		preState, err := service.getBlockPreState(ctx, wsb.Block())
+		require.NoError(t, err)
+		postState, err := service.validateStateTransition(ctx, preState, wsb)
+		require.NoError(t, err)
+		require.NoError(t, service.savePostStateInfo(ctx, r, wsb, postState))
+		require.NoError(t, service.postBlockProcess(ctx, wsb, r, postState, true))
+		testState, err = service.cfg.StateGen.StateByRoot(ctx, r)
+		require.NoError(t, err)
+		if i == 2*params.BeaconConfig().SlotsPerEpoch-1 {
+			epochEndRoot = r
+		}
+	}
+
+	// Replaying requires the clock to be past the replayed slots.
+	service.SetGenesisTime(time.Now().Add(-time.Duration(3*uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second))
+	history := stategen.NewCanonicalHistory(service.cfg.BeaconDB, service, service)
+	service.backfillTokenomicsHistory(ctx, history, 0, 2, nil)
+	records, err := service.cfg.BeaconDB.TokenomicsRecords(ctx, 0, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, primitives.Epoch(1), records[0].Epoch)
+	assert.Equal(t, primitives.Epoch(2), records[1].Epoch)
+
+	preState, err := service.cfg.StateGen.StateByRoot(ctx, epochEndRoot)
+	require.NoError(t, err)
+	postState, err := transition.ProcessSlots(ctx, preState.Copy(), 2*params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	want, err := tokenomicsRecord(preState, postState)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, records[1])
+}
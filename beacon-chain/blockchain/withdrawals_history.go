@@ -0,0 +1,176 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// withdrawalsBackfillBatchSize is the number of slots of finalized blocks indexed at once by the
+// withdrawals history backfill.
+const withdrawalsBackfillBatchSize = primitives.Slot(256)
+
+// withdrawalRecords returns the records of the withdrawals executed by the payload of the given block.
+func withdrawalRecords(blk interfaces.ReadOnlySignedBeaconBlock) ([]*dbtypes.WithdrawalRecord, error) {
+	if blk.Version() < version.Capella {
+		return nil, nil
+	}
+	payload, err := blk.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload")
+	}
+	withdrawals, err := payload.Withdrawals()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get withdrawals")
+	}
+	records := make([]*dbtypes.WithdrawalRecord, len(withdrawals))
+	for i, w := range withdrawals {
+		records[i] = &dbtypes.WithdrawalRecord{
+			Index:          w.Index,
+			ValidatorIndex: w.ValidatorIndex,
+			Address:        common.BytesToAddress(w.Address),
+			Amount:         w.Amount,
+			Slot:           blk.Block().Slot(),
+		}
+	}
+	return records, nil
+}
+
+// saveCanonicalWithdrawals indexes the withdrawals of the given block if it is the head. Records
+// of blocks which are later reorged out are replaced as the canonical chain executes withdrawals
+// with the same indices, and by the backfill routine once finalized.
+func (s *Service) saveCanonicalWithdrawals(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte) error {
+	headRoot, err := s.HeadRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(headRoot, root[:]) {
+		return nil
+	}
+	records, err := withdrawalRecords(blk)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return s.cfg.BeaconDB.SaveWithdrawalRecords(ctx, records)
+}
+
+// runWithdrawalsHistoryBackfill indexes the withdrawals of finalized blocks which were not indexed
+// at import, e.g. because they were imported before the feature was enabled, during initial sync,
+// or were not the head at the time. It runs once at every epoch start.
+func (s *Service) runWithdrawalsHistoryBackfill() {
+	if err := s.waitForSync(); err != nil {
+		log.WithError(err).Error("failed to wait for initial sync")
+		return
+	}
+
+	backfill := func() {
+		finalized, err := slots.EpochStart(s.FinalizedCheckpt().Epoch)
+		if err != nil {
+			log.WithError(err).Error("Could not get finalized slot")
+			return
+		}
+		s.backfillWithdrawalsHistory(s.ctx, finalized)
+	}
+
+	backfill()
+	ticker := slots.NewSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if slots.IsEpochStart(slot) {
+				backfill()
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// backfillWithdrawalsHistory indexes the withdrawals of the finalized blocks after the slot saved
+// by the previous run and up to the given slot, in batches. Progress is saved after each batch so
+// that a restart resumes where the previous run stopped, and a failed batch is retried next run.
+func (s *Service) backfillWithdrawalsHistory(ctx context.Context, to primitives.Slot) {
+	backfilled, err := s.cfg.BeaconDB.WithdrawalsBackfillSlot(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get withdrawals backfill slot")
+		return
+	}
+	saved := 0
+	for start := backfilled + 1; start <= to; start += withdrawalsBackfillBatchSize {
+		if ctx.Err() != nil {
+			return
+		}
+		end := start + withdrawalsBackfillBatchSize - 1
+		if end > to {
+			end = to
+		}
+		blks, roots, err := s.cfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(end))
+		if err != nil {
+			log.WithError(err).Error("Could not get blocks")
+			return
+		}
+		finalized := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+		blinded := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+		for i, blk := range blks {
+			if !s.cfg.BeaconDB.IsFinalizedBlock(ctx, roots[i]) || blk.Version() < version.Capella {
+				continue
+			}
+			if blk.IsBlinded() {
+				blinded = append(blinded, blk)
+				continue
+			}
+			finalized = append(finalized, blk)
+		}
+		if len(blinded) > 0 {
+			// Blocks are stored blinded unless full execution payloads are saved, in which case
+			// their withdrawals are only known to the execution client.
+			if s.cfg.ExecutionPayloadReconstructor == nil {
+				log.Error("Could not backfill withdrawals history of blinded blocks: no execution payload reconstructor")
+				return
+			}
+			full, err := s.cfg.ExecutionPayloadReconstructor.ReconstructFullBellatrixBlockBatch(ctx, blinded)
+			if err != nil {
+				log.WithError(err).Error("Could not reconstruct full blocks")
+				return
+			}
+			for _, blk := range full {
+				finalized = append(finalized, blk)
+			}
+		}
+		var records []*dbtypes.WithdrawalRecord
+		for _, blk := range finalized {
+			r, err := withdrawalRecords(blk)
+			if err != nil {
+				log.WithError(err).WithField("slot", blk.Block().Slot()).Error("Could not get block withdrawals")
+				return
+			}
+			records = append(records, r...)
+		}
+		if err := s.cfg.BeaconDB.SaveWithdrawalRecords(ctx, records); err != nil {
+			log.WithError(err).Error("Could not save withdrawal records")
+			return
+		}
+		if err := s.cfg.BeaconDB.SaveWithdrawalsBackfillSlot(ctx, end); err != nil {
+			log.WithError(err).Error("Could not save withdrawals backfill slot")
+			return
+		}
+		saved += len(records)
+	}
+	if saved > 0 {
+		log.WithField("records", saved).Info("Backfilled withdrawals history")
+	}
+}
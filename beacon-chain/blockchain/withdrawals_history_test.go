@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// mockReconstructor reconstructs blinded blocks from the full blocks of the same slot.
+type mockReconstructor struct {
+	blocks map[primitives.Slot]interfaces.SignedBeaconBlock
+}
+
+func (m *mockReconstructor) ReconstructFullBlock(_ context.Context, blk interfaces.ReadOnlySignedBeaconBlock) (interfaces.SignedBeaconBlock, error) {
+	full, ok := m.blocks[blk.Block().Slot()]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return full, nil
+}
+
+func (m *mockReconstructor) ReconstructFullBellatrixBlockBatch(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock) ([]interfaces.SignedBeaconBlock, error) {
+	full := make([]interfaces.SignedBeaconBlock, len(blks))
+	for i, blk := range blks {
+		b, err := m.ReconstructFullBlock(ctx, blk)
+		if err != nil {
+			return nil, err
+		}
+		full[i] = b
+	}
+	return full, nil
+}
+
+func capellaBlockWithWithdrawals(t *testing.T, slot primitives.Slot, parentRoot [32]byte, withdrawals ...*enginev1.Withdrawal) (interfaces.SignedBeaconBlock, [32]byte) {
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = slot
+	b.Block.ParentRoot = parentRoot[:]
+	b.Block.Body.ExecutionPayload.Withdrawals = withdrawals
+	wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := wsb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	return wsb, root
+}
+
+func TestWithdrawalRecords(t *testing.T) {
+	phase0, err := consensusblocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	records, err := withdrawalRecords(phase0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	addr := common.HexToAddress("0x0102")
+	blk, _ := capellaBlockWithWithdrawals(t, 7, [32]byte{},
+		&enginev1.Withdrawal{Index: 3, ValidatorIndex: 4, Address: addr.Bytes(), Amount: 5},
+		&enginev1.Withdrawal{Index: 4, ValidatorIndex: 6, Address: addr.Bytes(), Amount: 7},
+	)
+	records, err = withdrawalRecords(blk)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*dbtypes.WithdrawalRecord{
+		{Index: 3, ValidatorIndex: 4, Address: addr, Amount: 5, Slot: 7},
+		{Index: 4, ValidatorIndex: 6, Address: addr, Amount: 7, Slot: 7},
+	}, records)
+}
+
+func TestSaveCanonicalWithdrawals(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	blk, root := capellaBlockWithWithdrawals(t, 1, [32]byte{}, &enginev1.Withdrawal{Index: 0, ValidatorIndex: 1, Address: make([]byte, 20), Amount: 2})
+	service.head = &head{root: [32]byte{'a'}}
+	require.NoError(t, service.saveCanonicalWithdrawals(ctx, blk, root))
+	records, err := service.cfg.BeaconDB.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records), "Saved the withdrawals of a block which is not the head")
+
+	service.head = &head{root: root}
+	require.NoError(t, service.saveCanonicalWithdrawals(ctx, blk, root))
+	records, err = service.cfg.BeaconDB.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, primitives.ValidatorIndex(1), records[0].ValidatorIndex)
+}
+
+func TestBackfillWithdrawalsHistory(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx, db := tr.ctx, tr.db
+
+	genesis, err := consensusblocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	genesisRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	addr := make([]byte, 20)
+	b1, r1 := capellaBlockWithWithdrawals(t, 1, genesisRoot, &enginev1.Withdrawal{Index: 0, ValidatorIndex: 1, Address: addr, Amount: 10})
+	b2, r2 := capellaBlockWithWithdrawals(t, 2, r1,
+		&enginev1.Withdrawal{Index: 1, ValidatorIndex: 2, Address: addr, Amount: 20},
+		&enginev1.Withdrawal{Index: 2, ValidatorIndex: 3, Address: addr, Amount: 30},
+	)
+	// A block competing with b2 which is not part of the finalized chain.
+	fork, _ := capellaBlockWithWithdrawals(t, 2, r1, &enginev1.Withdrawal{Index: 1, ValidatorIndex: 9, Address: addr, Amount: 90})
+	b3, _ := capellaBlockWithWithdrawals(t, 3, r2, &enginev1.Withdrawal{Index: 3, ValidatorIndex: 4, Address: addr, Amount: 40})
+	require.NoError(t, db.SaveBlocks(ctx, []interfaces.ReadOnlySignedBeaconBlock{b1, b2, fork, b3}))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 2, Root: r2[:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: r2[:]}))
+
+	// Blocks are stored blinded, so that nothing is indexed without a payload reconstructor.
+	service.backfillWithdrawalsHistory(ctx, 2)
+	records, err := db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+	backfilled, err := db.WithdrawalsBackfillSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), backfilled)
+
+	service.cfg.ExecutionPayloadReconstructor = &mockReconstructor{
+		blocks: map[primitives.Slot]interfaces.SignedBeaconBlock{1: b1, 2: b2, 3: b3},
+	}
+	service.backfillWithdrawalsHistory(ctx, 2)
+	records, err = db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	for i, want := range []primitives.ValidatorIndex{1, 2, 3} {
+		assert.Equal(t, uint64(i), records[i].Index)
+		assert.Equal(t, want, records[i].ValidatorIndex)
+	}
+	backfilled, err = db.WithdrawalsBackfillSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(2), backfilled)
+
+	// A later run resumes after the last backfilled slot.
+	require.NoError(t, db.SaveWithdrawalRecords(ctx, []*dbtypes.WithdrawalRecord{{Index: 0, ValidatorIndex: 100, Slot: 1}}))
+	service.backfillWithdrawalsHistory(ctx, 2)
+	records, err = db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ToSlot: 1}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, primitives.ValidatorIndex(100), records[0].ValidatorIndex)
+}
@@ -27,6 +27,8 @@ const (
 	NewHead
 	// MissedSlot is sent when we need to notify users that a slot was missed.
 	MissedSlot
+	// Tokenomics is sent after an epoch transition with the tokenomics of the new epoch.
+	Tokenomics
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -0,0 +1,71 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+)
+
+func TestBailOutRecoveryScore(t *testing.T) {
+	tests := []struct {
+		valnum int
+		want   uint64
+	}{
+		{valnum: 0, want: 396670510735312},
+		{valnum: 1<<13 - 1, want: 396670510735312},
+		{valnum: 1 << 13, want: 424422339421802},
+		{valnum: 1<<14 - 1, want: 424422339421802},
+		{valnum: 1 << 14, want: 445262211294194},
+		{valnum: 1 << 15, want: 460632171078136},
+		{valnum: 1 << 16, want: 471826519177477},
+		{valnum: 1 << 17, want: 479908445837684},
+		{valnum: 1 << 18, want: 485707547285690},
+		{valnum: 1 << 19, want: 489850697296405},
+		{valnum: 1 << 20, want: 492801774069274},
+		{valnum: 1 << 21, want: 494899265141145},
+		{valnum: 1<<22 - 1, want: 494899265141145},
+		{valnum: 1 << 22, want: 496387815679376},
+		{valnum: 1 << 30, want: 496387815679376},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, BailOutRecoveryScore(tt.valnum), "%d validators", tt.valnum)
+	}
+}
+
+func TestBailOutRecoveryScore_CustomSchedule(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BailOutRecoverySchedule = []params.BailOutRecoveryStep{
+		{MinValidators: 0, RecoveryScore: 5},
+		{MinValidators: 100, RecoveryScore: 7},
+	}
+	params.OverrideBeaconConfig(cfg)
+
+	assert.Equal(t, uint64(5), BailOutRecoveryScore(99))
+	assert.Equal(t, uint64(7), BailOutRecoveryScore(100))
+	assert.Equal(t, uint64(7), BailOutRecoveryScore(1<<22))
+}
+
+func TestMissedTargetVotesBeforeBailOut(t *testing.T) {
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	bias := params.BeaconConfig().BailOutScoreBias
+
+	tests := []struct {
+		name  string
+		score uint64
+		want  uint64
+	}{
+		{name: "zero score", score: 0, want: (threshold + bias - 1) / bias},
+		{name: "one vote away", score: threshold - bias, want: 1},
+		{name: "less than a vote away", score: threshold - 1, want: 1},
+		{name: "partial vote", score: threshold - bias - 1, want: 2},
+		{name: "at threshold", score: threshold, want: 0},
+		{name: "above threshold", score: threshold + bias, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MissedTargetVotesBeforeBailOut(tt.score))
+		})
+	}
+}
@@ -238,17 +238,20 @@ func EpochIssuance(epoch primitives.Epoch) uint64 {
 	return cfg.MaxTokenSupply / cfg.IssuancePrecision * cfg.IssuanceRate[year] / cfg.EpochsPerYear
 }
 
-// TargetDepositPlan returns the target deposit plan for the given epoch.
+// TargetDepositPlan returns the target deposit plan for the given epoch, following the
+// DEPOSIT_PLAN segments of the config and DEPOSIT_PLAN_FINAL after the last one.
 func TargetDepositPlan(epoch primitives.Epoch) uint64 {
 	cfg := params.BeaconConfig()
 	e := uint64(epoch)
-	if e < cfg.EpochsPerYear*cfg.DepositPlanEarlyEnd {
-		return cfg.DepositPlanEarlySlope*e + cfg.DepositPlanEarlyOffset
-	} else if e < cfg.EpochsPerYear*cfg.DepositPlanLaterEnd {
-		return cfg.DepositPlanLaterSlope*e + cfg.DepositPlanLaterOffset
-	} else {
-		return cfg.DepositPlanFinal
+	startYear := uint64(0)
+	for _, s := range cfg.DepositPlan {
+		if e < cfg.EpochsPerYear*s.EndYear {
+			slope := s.Increase / (cfg.EpochsPerYear * (s.EndYear - startYear))
+			return slope*e + s.Offset
+		}
+		startYear = s.EndYear
 	}
+	return cfg.DepositPlanFinal
 }
 
 func ProcessRewardfactorUpdate(state state.BeaconState) error {
@@ -280,14 +283,30 @@ func TotalRewardWithReserveUsage(s state.ReadOnlyBeaconState) (uint64, uint64) {
 	return epochIssuance + feedbackBoost, feedbackBoost
 }
 
+// EpochReserveUsage returns the amount taken out of the reserve during the epoch of preState, where
+// preState is any state of that epoch and postState is the state right after the transition to the
+// next epoch. The reserve of the epoch start is kept in the previous epoch reserve until the next
+// transition, so the usage covers the rewards paid in blocks as well as at the epoch transition.
+func EpochReserveUsage(preState, postState state.ReadOnlyBeaconState) uint64 {
+	if preState.PreviousEpochReserve() < postState.CurrentEpochReserve() {
+		return 0
+	}
+	return preState.PreviousEpochReserve() - postState.CurrentEpochReserve()
+}
+
 // EpochFeedbackBoost returns the boost reward from feedback model in tokenomics.
 func EpochFeedbackBoost(s state.ReadOnlyBeaconState) uint64 {
+	return FeedbackBoost(s.RewardAdjustmentFactor(), s.PreviousEpochReserve())
+}
+
+// FeedbackBoost returns the boost reward for the given reward adjustment factor,
+// capped by the given previous epoch reserve.
+func FeedbackBoost(rewardAdjustmentFactor, previousEpochReserve uint64) uint64 {
 	cfg := params.BeaconConfig()
-	rewardAdjustmentFactor := s.RewardAdjustmentFactor()
 	feedbackBoost := cfg.MaxTokenSupply / cfg.RewardFeedbackPrecision * rewardAdjustmentFactor / cfg.EpochsPerYear
 
-	if reserve := s.PreviousEpochReserve(); feedbackBoost > reserve {
-		return reserve
+	if feedbackBoost > previousEpochReserve {
+		return previousEpochReserve
 	}
 	return feedbackBoost
 }
@@ -343,13 +362,19 @@ func EpochFeedbackBoost(s state.ReadOnlyBeaconState) uint64 {
 //
 //		return bias
 func CalculateRewardAdjustmentFactor(state state.ReadOnlyBeaconState) (uint64, error) {
-	cfg := params.BeaconConfig()
-	epoch := slots.ToEpoch(state.Slot())
 	futureDeposit, err := TotalBalanceWithQueue(state)
 	if err != nil {
 		return 0, err
 	}
-	targetDeposit := TargetDepositPlan(time.NextEpoch(state))
+	return NextRewardAdjustmentFactor(state.RewardAdjustmentFactor(), futureDeposit, slots.ToEpoch(state.Slot())), nil
+}
+
+// NextRewardAdjustmentFactor applies the feedback model to the given reward adjustment factor,
+// using the future deposit (active + pending - exiting balance) observed at the given epoch.
+// It is the stateless core of CalculateRewardAdjustmentFactor.
+func NextRewardAdjustmentFactor(bias, futureDeposit uint64, epoch primitives.Epoch) uint64 {
+	cfg := params.BeaconConfig()
+	targetDeposit := TargetDepositPlan(epoch + 1)
 
 	bigFutureDeposit := big.NewInt(int64(futureDeposit)) // lint:ignore uintcast -- changeRate will not exceed int64 because of total issuance.
 	bigTargetDeposit := big.NewInt(int64(targetDeposit)) // lint:ignore uintcast -- changeRate will not exceed int64 because of total issuance.
@@ -365,7 +390,6 @@ func CalculateRewardAdjustmentFactor(state state.ReadOnlyBeaconState) (uint64, e
 	targetChangeRate := big.NewInt(int64(cfg.TargetChangeRate))
 	changeRate := new(big.Int).Div(new(big.Int).Mul(targetChangeRate, mitigatingFactor), bigRewardPrecision).Uint64() // lint:ignore uintcast -- changeRate will not exceed int64 because of value limit.
 
-	bias := state.RewardAdjustmentFactor()
 	if futureDeposit >= targetDeposit {
 		if bias <= changeRate {
 			bias = 0
@@ -376,7 +400,7 @@ func CalculateRewardAdjustmentFactor(state state.ReadOnlyBeaconState) (uint64, e
 		bias += changeRate
 	}
 
-	return TruncateRewardAdjustmentFactor(bias, epoch), nil
+	return TruncateRewardAdjustmentFactor(bias, epoch)
 }
 
 // TruncateRewardAdjustmentFactor truncates the given bias to the min and max bounds.
@@ -377,6 +377,47 @@ func TestTargetDepositPlan(t *testing.T) {
 	}
 }
 
+func TestTargetDepositPlan_Dolphin(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.DolphinConfig())
+	epochsPerYear := params.BeaconConfig().EpochsPerYear
+
+	// The values of the deposit plan formerly hard-coded for Dolphin.
+	earlySlope := uint64(160000000 * 1e9 / (epochsPerYear * 4))
+	laterSlope := uint64(100000000 * 1e9 / (epochsPerYear * 6))
+	for _, e := range []uint64{0, 1, epochsPerYear*4 - 1, epochsPerYear * 4, epochsPerYear*10 - 1, epochsPerYear * 10, epochsPerYear * 20} {
+		want := uint64(300000000 * 1e9)
+		switch {
+		case e < epochsPerYear*4:
+			want = earlySlope*e + 40000000*1e9
+		case e < epochsPerYear*10:
+			want = laterSlope*e + 133333334*1e9
+		}
+		require.Equal(t, want, TargetDepositPlan(primitives.Epoch(e)), "epoch %d", e)
+	}
+}
+
+func TestTargetDepositPlan_Segments(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.EpochsPerYear = 100
+	cfg.DepositPlan = []params.DepositPlanSegment{
+		{EndYear: 1, Increase: 1000, Offset: 10},
+		{EndYear: 2, Increase: 500, Offset: 510},
+		{EndYear: 5, Increase: 0, Offset: 2000},
+	}
+	cfg.DepositPlanFinal = 3000
+	params.OverrideBeaconConfig(cfg)
+
+	assert.Equal(t, uint64(10), TargetDepositPlan(0))
+	assert.Equal(t, uint64(10*99+10), TargetDepositPlan(99))
+	assert.Equal(t, uint64(5*100+510), TargetDepositPlan(100))
+	assert.Equal(t, uint64(5*199+510), TargetDepositPlan(199))
+	assert.Equal(t, uint64(2000), TargetDepositPlan(200))
+	assert.Equal(t, uint64(2000), TargetDepositPlan(499))
+	assert.Equal(t, uint64(3000), TargetDepositPlan(500))
+}
+
 func TestProcessRewardfactorUpdate_OK(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -444,6 +485,36 @@ func TestTotalRewardWithReserveUsage_OK(t *testing.T) {
 	}
 }
 
+func TestEpochReserveUsage(t *testing.T) {
+	tests := []struct {
+		name            string
+		previousReserve uint64
+		currentReserve  uint64
+		postReserve     uint64
+		want            uint64
+	}{
+		{name: "spent in blocks and at the transition", previousReserve: 1000, currentReserve: 900, postReserve: 700, want: 300},
+		{name: "nothing spent", previousReserve: 1000, currentReserve: 1000, postReserve: 1000, want: 0},
+		{name: "reserve grew", previousReserve: 1000, currentReserve: 1000, postReserve: 1200, want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pre := buildState(params.BeaconConfig().SlotsPerEpoch-1, 10)
+			pre.PreviousEpochReserve = test.previousReserve
+			pre.CurrentEpochReserve = test.currentReserve
+			preState, err := state_native.InitializeFromProtoPhase0(pre)
+			require.NoError(t, err)
+			post := buildState(params.BeaconConfig().SlotsPerEpoch, 10)
+			post.PreviousEpochReserve = test.postReserve
+			post.CurrentEpochReserve = test.postReserve
+			postState, err := state_native.InitializeFromProtoPhase0(post)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want, EpochReserveUsage(preState, postState))
+		})
+	}
+}
+
 func TestCalculateRewardAdjustmentFactor_OK(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -57,6 +58,16 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// Tokenomics history operations.
+	TokenomicsRecord(ctx context.Context, epoch primitives.Epoch) (*dbtypes.TokenomicsRecord, error)
+	TokenomicsRecords(ctx context.Context, from, to primitives.Epoch, limit int) ([]*dbtypes.TokenomicsRecord, error)
+	// Withdrawals history operations.
+	WithdrawalRecords(ctx context.Context, f *dbtypes.WithdrawalsFilter, limit int) ([]*dbtypes.WithdrawalRecord, error)
+	WithdrawalsBackfillSlot(ctx context.Context) (primitives.Slot, error)
+	// History pruning operations.
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
+	// Fork choice persistence operations.
+	ForkChoiceSnapshot(ctx context.Context) ([]byte, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -88,6 +99,13 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Tokenomics history operations.
+	SaveTokenomicsRecord(ctx context.Context, record *dbtypes.TokenomicsRecord) error
+	// Withdrawals history operations.
+	SaveWithdrawalRecords(ctx context.Context, records []*dbtypes.WithdrawalRecord) error
+	SaveWithdrawalsBackfillSlot(ctx context.Context, slot primitives.Slot) error
+	// History pruning operations.
+	PruneHistory(ctx context.Context, cutoff primitives.Slot, maxBlocks int) (int, error)
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 }
@@ -108,6 +126,10 @@ type HeadAccessDatabase interface {
 	// initialization method needed for origin checkpoint sync
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveBackfillFinalizedBlockRoots(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, childRoot [32]byte) error
+
+	// Fork choice persistence operations.
+	SaveForkChoiceSnapshot(ctx context.Context, enc []byte) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
@@ -219,3 +219,46 @@ func (s *Store) FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (in
 	tracing.AnnotateError(span, err)
 	return blk, err
 }
+
+// SaveBackfillFinalizedBlockRoots adds blocks backfilled before the origin checkpoint to the finalized
+// block roots index. The blocks must be ordered by ascending slot, each one being the parent of the
+// next one, and the last one being the parent of the already saved block of root childRoot.
+func (s *Store) SaveBackfillFinalizedBlockRoots(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, childRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillFinalizedBlockRoots")
+	defer span.End()
+
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		if err := blocks.BeaconBlockIsNil(blk); err != nil {
+			return err
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		roots[i] = root
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+		for i, blk := range blks {
+			child := childRoot
+			if i+1 < len(blks) {
+				child = roots[i+1]
+			}
+			parentRoot := blk.Block().ParentRoot()
+			enc, err := encode(ctx, &ethpb.FinalizedBlockRootContainer{
+				ParentRoot: parentRoot[:],
+				ChildRoot:  child[:],
+			})
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(roots[i][:], enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	tracing.AnnotateError(span, err)
+	return err
+}
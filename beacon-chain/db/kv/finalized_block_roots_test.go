@@ -193,6 +193,30 @@ func TestStore_IsFinalizedChildBlock(t *testing.T) {
 	})
 }
 
+func TestStore_SaveBackfillFinalizedBlockRoots(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	// Blocks 1 to 4 are backfilled below the origin block at slot 5.
+	blks := makeBlocks(t, 0, 5, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	originRoot := bytesutil.ToBytes32(sszRootOrDie(t, blks[4]))
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, false, db.IsFinalizedBlock(ctx, bytesutil.ToBytes32(sszRootOrDie(t, blks[i]))))
+	}
+
+	require.NoError(t, db.SaveBackfillFinalizedBlockRoots(ctx, blks[:4], originRoot))
+	for i := 0; i < 4; i++ {
+		root := bytesutil.ToBytes32(sszRootOrDie(t, blks[i]))
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Block at index %d was not considered finalized in the index", i)
+		child, err := db.FinalizedChildBlock(ctx, root)
+		require.NoError(t, err)
+		require.NotNil(t, child)
+		assert.DeepEqual(t, sszRootOrDie(t, blks[i+1]), sszRootOrDie(t, child))
+	}
+}
+
 func sszRootOrDie(t *testing.T, block interfaces.ReadOnlySignedBeaconBlock) []byte {
 	root, err := block.Block().HashTreeRoot()
 	require.NoError(t, err)
@@ -0,0 +1,49 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// ForkChoiceSnapshot returns the last fork choice store snapshot saved to the db,
+// or nil if there is none.
+func (s *Store) ForkChoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkChoiceSnapshot")
+	defer span.End()
+
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(chainMetadataBucket).Get(forkChoiceSnapshotKey); v != nil {
+			enc = make([]byte, len(v))
+			copy(enc, v)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not snappy decode fork choice snapshot")
+	}
+	return dec, nil
+}
+
+// SaveForkChoiceSnapshot saves an encoded fork choice store, replacing the previous snapshot.
+func (s *Store) SaveForkChoiceSnapshot(ctx context.Context, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoiceSnapshot")
+	defer span.End()
+
+	if len(enc) == 0 {
+		return errors.New("empty fork choice snapshot")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(forkChoiceSnapshotKey, snappy.Encode(nil, enc))
+	})
+}
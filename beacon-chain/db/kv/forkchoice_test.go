@@ -0,0 +1,25 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ForkChoiceSnapshot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	enc, err := db.ForkChoiceSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(enc))
+	require.ErrorContains(t, "empty fork choice snapshot", db.SaveForkChoiceSnapshot(ctx, nil))
+
+	require.NoError(t, db.SaveForkChoiceSnapshot(ctx, []byte("first")))
+	require.NoError(t, db.SaveForkChoiceSnapshot(ctx, []byte("second")))
+	enc, err = db.ForkChoiceSnapshot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("second"), enc)
+}
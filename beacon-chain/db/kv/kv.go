@@ -122,6 +122,9 @@ var Buckets = [][]byte{
 	blockParentRootIndicesBucket,
 	finalizedBlockRootsIndexBucket,
 	blockRootValidatorHashesBucket,
+	withdrawalsAddressIndicesBucket,
+	withdrawalsValidatorIndicesBucket,
+	withdrawalsSlotIndicesBucket,
 	// State management service bucket.
 	newStateServiceCompatibleBucket,
 	// Migrations
@@ -129,6 +132,8 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	tokenomicsHistoryBucket,
+	withdrawalsHistoryBucket,
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
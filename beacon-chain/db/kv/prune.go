@@ -0,0 +1,242 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// EarliestAvailableSlot returns the slot below which blocks and states have been pruned from the db,
+// or 0 if history was never pruned.
+func (s *Store) EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.EarliestAvailableSlot")
+	defer span.End()
+
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(blocksBucket).Get(earliestAvailableSlotKey)
+		if enc != nil {
+			slot = bytesutil.BytesToSlotBigEndian(enc)
+		}
+		return nil
+	})
+	return slot, err
+}
+
+// PruneHistory deletes up to maxBlocks of the lowest blocks with a slot below the given cutoff, along
+// with their indices, state summaries and states, and returns the number of blocks deleted. The genesis
+// block and state are always kept. The cutoff is lowered to the slot of the highest state saved at or
+// below it, so that the states of the blocks kept can still be regenerated. A state saved at a skip slot
+// is kept until the cutoff moves past its own slot, even if its block is pruned. Callers are expected to call
+// PruneHistory repeatedly until it returns 0, to bound the time spent holding the db lock.
+func (s *Store) PruneHistory(ctx context.Context, cutoff primitives.Slot, maxBlocks int) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneHistory")
+	defer span.End()
+
+	pruned := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		blkBkt := tx.Bucket(blocksBucket)
+		cutoff = pruneCutoff(tx, cutoff)
+		earliest := primitives.Slot(0)
+		if enc := blkBkt.Get(earliestAvailableSlotKey); enc != nil {
+			earliest = bytesutil.BytesToSlotBigEndian(enc)
+		}
+		if cutoff <= earliest {
+			return nil
+		}
+
+		// Collect the roots of whole slots, starting at slot 1 to keep the genesis block.
+		slotRoots := make(map[primitives.Slot][][32]byte)
+		slotsPruned := make([]primitives.Slot, 0)
+		next := cutoff
+		c := tx.Bucket(blockSlotIndicesBucket).Cursor()
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(1)); k != nil; k, v = c.Next() {
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			if slot >= cutoff {
+				break
+			}
+			if pruned >= maxBlocks {
+				next = slot
+				break
+			}
+			roots, err := splitRoots(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not split block roots at slot %d", slot)
+			}
+			slotRoots[slot] = roots
+			slotsPruned = append(slotsPruned, slot)
+			pruned += len(roots)
+		}
+
+		checkpoints := tx.Bucket(checkpointBucket)
+		protected := make(map[[32]byte]bool)
+		for _, key := range [][]byte{finalizedCheckpointKey, justifiedCheckpointKey} {
+			if enc := checkpoints.Get(key); enc != nil {
+				cp := &ethpb.Checkpoint{}
+				if err := decode(ctx, enc, cp); err != nil {
+					return err
+				}
+				protected[bytesutil.ToBytes32(cp.Root)] = true
+			}
+		}
+		stateSlots, err := stateSlotsByRoot(tx)
+		if err != nil {
+			return err
+		}
+		// States kept by an earlier pruning because their slot was not below the cutoff then, while
+		// their block was pruned, are deleted once the cutoff moves past them.
+		for root, slot := range stateSlots {
+			if slot == 0 || slot >= cutoff || blkBkt.Get(root[:]) != nil || protected[root] {
+				continue
+			}
+			if err := s.deleteStateAtSlot(ctx, tx, root, slot); err != nil {
+				return errors.Wrapf(err, "could not prune state of block root=%#x at slot %d", root, slot)
+			}
+			if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+				return err
+			}
+			s.stateSummaryCache.delete(root)
+		}
+		for _, slot := range slotsPruned {
+			for _, root := range slotRoots[slot] {
+				if protected[root] {
+					return errors.Wrapf(ErrDeleteJustifiedAndFinalized, "block root=%#x at slot %d", root, slot)
+				}
+				if err := s.deleteBlockHistory(ctx, tx, root, slot, stateSlots, cutoff); err != nil {
+					return errors.Wrapf(err, "could not prune block root=%#x at slot %d", root, slot)
+				}
+			}
+		}
+		if err := blkBkt.Put(earliestAvailableSlotKey, bytesutil.SlotToBytesBigEndian(next)); err != nil {
+			return err
+		}
+
+		// Point backfill at the lowest block left if its block was pruned.
+		bfRoot := blkBkt.Get(backfillBlockRootKey)
+		if bfRoot == nil || blkBkt.Get(bfRoot) != nil {
+			return nil
+		}
+		_, v := tx.Bucket(blockSlotIndicesBucket).Cursor().Seek(bytesutil.SlotToBytesBigEndian(next))
+		if len(v) < 32 {
+			return errors.New("no block left above the pruned history")
+		}
+		return blkBkt.Put(backfillBlockRootKey, v[:32])
+	})
+	return pruned, err
+}
+
+// pruneCutoff lowers the cutoff to the slot of the highest state saved at or below it. If there is no
+// such state but the genesis state, blocks below the cutoff may only be pruned if the node was initialized
+// from a checkpoint, as states below the origin checkpoint cannot be regenerated anyway.
+func pruneCutoff(tx *bolt.Tx, cutoff primitives.Slot) primitives.Slot {
+	c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+	k, _ := c.Seek(bytesutil.SlotToBytesBigEndian(cutoff + 1))
+	if k == nil {
+		k, _ = c.Last()
+	} else {
+		k, _ = c.Prev()
+	}
+	if k != nil {
+		if slot := bytesutil.BytesToSlotBigEndian(k); slot > 0 {
+			return slot
+		}
+	}
+	if tx.Bucket(blocksBucket).Get(originCheckpointBlockRootKey) != nil {
+		return cutoff
+	}
+	return 0
+}
+
+// stateSlotsByRoot returns the slot of every state in the state slot index, by the block root it is saved
+// with. The slot of a state differs from the slot of its block when the state was saved at a skip slot.
+func stateSlotsByRoot(tx *bolt.Tx) (map[[32]byte]primitives.Slot, error) {
+	stateSlots := make(map[[32]byte]primitives.Slot)
+	err := tx.Bucket(stateSlotIndicesBucket).ForEach(func(k, v []byte) error {
+		roots, err := splitRoots(v)
+		if err != nil {
+			return errors.Wrapf(err, "could not split state roots at slot %d", bytesutil.BytesToSlotBigEndian(k))
+		}
+		for _, r := range roots {
+			stateSlots[r] = bytesutil.BytesToSlotBigEndian(k)
+		}
+		return nil
+	})
+	return stateSlots, err
+}
+
+// deleteBlockHistory deletes a block with its indices, finalized index entry and the attestations indexed by
+// its root. The state summary and state saved with the block root are deleted as well, unless the state is at
+// a slot not below the cutoff, which happens when the state was saved at a skip slot after the block.
+func (s *Store) deleteBlockHistory(
+	ctx context.Context,
+	tx *bolt.Tx,
+	root [32]byte,
+	slot primitives.Slot,
+	stateSlots map[[32]byte]primitives.Slot,
+	cutoff primitives.Slot,
+) error {
+	blkBkt := tx.Bucket(blocksBucket)
+	keepState := false
+	if tx.Bucket(stateBucket).Get(root[:]) != nil {
+		stateSlot, ok := stateSlots[root]
+		if !ok {
+			stateSlot = slot
+		}
+		if stateSlot >= cutoff {
+			keepState = true
+		} else if err := s.deleteStateAtSlot(ctx, tx, root, stateSlot); err != nil {
+			return err
+		}
+	}
+	if enc := blkBkt.Get(root[:]); enc != nil {
+		blk, err := unmarshalBlock(ctx, enc)
+		if err != nil {
+			return err
+		}
+		if err := deleteValueForIndices(ctx, createBlockIndicesFromBlock(ctx, blk.Block()), root[:], tx); err != nil {
+			return errors.Wrap(err, "could not delete root for DB indices")
+		}
+	} else if err := deleteValueForIndices(ctx, map[string][]byte{
+		string(blockSlotIndicesBucket): bytesutil.SlotToBytesBigEndian(slot),
+	}, root[:], tx); err != nil {
+		return errors.Wrap(err, "could not delete root for DB indices")
+	}
+	if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root[:]); err != nil {
+		return err
+	}
+	if err := blkBkt.Delete(root[:]); err != nil {
+		return err
+	}
+	s.blockCache.Del(string(root[:]))
+	if err := tx.Bucket(finalizedBlockRootsIndexBucket).Delete(root[:]); err != nil {
+		return err
+	}
+	if !keepState {
+		s.stateSummaryCache.delete(root)
+		if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+			return err
+		}
+	}
+
+	attBkt := tx.Bucket(attestationsBucket)
+	attRoots, err := splitRoots(tx.Bucket(attestationHeadBlockRootBucket).Get(root[:]))
+	if err != nil {
+		return err
+	}
+	for _, r := range attRoots {
+		if err := attBkt.Delete(r[:]); err != nil {
+			return err
+		}
+	}
+	for _, bkt := range [][]byte{attestationHeadBlockRootBucket, attestationSourceRootIndicesBucket, attestationTargetRootIndicesBucket} {
+		if err := tx.Bucket(bkt).Delete(root[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
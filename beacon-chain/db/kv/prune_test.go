@@ -0,0 +1,155 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+// setupPruneDB saves blocks at slots 1 to 20 with their state summaries, and states at the given slots.
+func setupPruneDB(t *testing.T, stateSlots ...primitives.Slot) (*Store, []interfaces.ReadOnlySignedBeaconBlock, [][32]byte) {
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, 20, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: r[:]}))
+	}
+	for _, sl := range stateSlots {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(sl))
+		require.NoError(t, db.SaveState(ctx, st, roots[sl-1]))
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[19][:]}))
+	return db, blks, roots
+}
+
+func TestStore_PruneHistory(t *testing.T) {
+	ctx := context.Background()
+	db, _, roots := setupPruneDB(t, 8, 16)
+
+	// The cutoff is lowered to the state at slot 8, and blocks are pruned 3 at a time.
+	for _, want := range []int{3, 3, 1, 0} {
+		n, err := db.PruneHistory(ctx, 12, 3)
+		require.NoError(t, err)
+		require.Equal(t, want, n)
+	}
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(8), earliest)
+
+	for i, r := range roots {
+		kept := i+1 >= 8
+		assert.Equal(t, kept, db.HasBlock(ctx, r), "unexpected block presence at slot %d", i+1)
+		assert.Equal(t, kept, db.HasStateSummary(ctx, r), "unexpected state summary presence at slot %d", i+1)
+	}
+	assert.Equal(t, true, db.HasState(ctx, roots[7]))
+	ok, slotRoots, err := db.BlockRootsBySlot(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, 0, len(slotRoots))
+	children, err := db.BlockRoots(ctx, filters.NewFilter().SetParentRoot(roots[3][:]))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(children))
+
+	// Pruning up to the state at slot 16 removes the state at slot 8.
+	n, err := db.PruneHistory(ctx, 16, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, false, db.HasState(ctx, roots[7]))
+	assert.Equal(t, true, db.HasState(ctx, roots[15]))
+	earliest, err = db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(16), earliest)
+}
+
+func TestStore_PruneHistory_NoState(t *testing.T) {
+	ctx := context.Background()
+	db, _, _ := setupPruneDB(t)
+	n, err := db.PruneHistory(ctx, 12, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), earliest)
+}
+
+func TestStore_PruneHistory_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	db, _, roots := setupPruneDB(t, 16)
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, roots[15]))
+	require.NoError(t, db.SaveBackfillBlockRoot(ctx, roots[4]))
+
+	// Without a state below the cutoff, blocks backfilled before the origin are pruned up to the cutoff.
+	n, err := db.PruneHistory(ctx, 8, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+	bfRoot, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[7], bfRoot)
+}
+
+func TestStore_PruneHistory_Finalized(t *testing.T) {
+	ctx := context.Background()
+	db, _, roots := setupPruneDB(t, 16)
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: roots[9][:]}))
+	_, err := db.PruneHistory(ctx, 16, 100)
+	require.ErrorIs(t, err, ErrDeleteJustifiedAndFinalized)
+	assert.Equal(t, true, db.HasBlock(ctx, roots[0]))
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), earliest)
+}
+
+func TestStore_PruneHistory_SkipSlotState(t *testing.T) {
+	ctx := context.Background()
+	db, _, roots := setupPruneDB(t, 16)
+	// The state at slot 8 is saved with the root of the block at slot 6, as if slots 7 and 8 were skipped.
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(8))
+	require.NoError(t, db.SaveState(ctx, st, roots[5]))
+	stateIndexed := func(slot primitives.Slot) bool {
+		var indexed bool
+		require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+			indexed = tx.Bucket(stateSlotIndicesBucket).Get(bytesutil.SlotToBytesBigEndian(slot)) != nil
+			return nil
+		}))
+		return indexed
+	}
+
+	// The block of the state is pruned, but the state at the cutoff is kept.
+	n, err := db.PruneHistory(ctx, 12, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.Equal(t, false, db.HasBlock(ctx, roots[5]))
+	assert.Equal(t, true, db.HasState(ctx, roots[5]))
+	assert.Equal(t, true, stateIndexed(8))
+	states, err := db.HighestSlotStatesBelow(ctx, 9)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(states))
+	assert.Equal(t, primitives.Slot(8), states[0].Slot())
+
+	// Once the cutoff moves past it, the state is deleted along with its slot index entry.
+	n, err = db.PruneHistory(ctx, 16, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, false, db.HasState(ctx, roots[5]))
+	assert.Equal(t, false, stateIndexed(8))
+	assert.Equal(t, true, db.HasState(ctx, roots[15]))
+}
@@ -7,19 +7,21 @@ package kv
 // it easy to scan for keys that have a certain shard number as a prefix and return those
 // corresponding attestations.
 var (
-	attestationsBucket      = []byte("attestations")
-	blocksBucket            = []byte("blocks")
-	stateBucket             = []byte("state")
-	stateSummaryBucket      = []byte("state-summary")
-	proposerSlashingsBucket = []byte("proposer-slashings")
-	attesterSlashingsBucket = []byte("attester-slashings")
-	voluntaryExitsBucket    = []byte("voluntary-exits")
-	chainMetadataBucket     = []byte("chain-metadata")
-	checkpointBucket        = []byte("check-point")
-	powchainBucket          = []byte("powchain")
-	stateValidatorsBucket   = []byte("state-validators")
-	feeRecipientBucket      = []byte("fee-recipient")
-	registrationBucket      = []byte("registration")
+	attestationsBucket       = []byte("attestations")
+	blocksBucket             = []byte("blocks")
+	stateBucket              = []byte("state")
+	stateSummaryBucket       = []byte("state-summary")
+	proposerSlashingsBucket  = []byte("proposer-slashings")
+	attesterSlashingsBucket  = []byte("attester-slashings")
+	voluntaryExitsBucket     = []byte("voluntary-exits")
+	chainMetadataBucket      = []byte("chain-metadata")
+	checkpointBucket         = []byte("check-point")
+	powchainBucket           = []byte("powchain")
+	stateValidatorsBucket    = []byte("state-validators")
+	feeRecipientBucket       = []byte("fee-recipient")
+	registrationBucket       = []byte("registration")
+	tokenomicsHistoryBucket  = []byte("tokenomics-history")
+	withdrawalsHistoryBucket = []byte("withdrawals-history")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -37,6 +39,9 @@ var (
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
 	blockRootValidatorHashesBucket      = []byte("block-root-validator-hashes")
+	withdrawalsAddressIndicesBucket     = []byte("withdrawals-address-indices")
+	withdrawalsValidatorIndicesBucket   = []byte("withdrawals-validator-indices")
+	withdrawalsSlotIndicesBucket        = []byte("withdrawals-slot-indices")
 
 	// Specific item keys.
 	headBlockRootKey           = []byte("head-root")
@@ -46,6 +51,7 @@ var (
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
+	withdrawalsBackfillSlotKey = []byte("withdrawals-backfill-slot")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
@@ -59,6 +65,10 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// block root tracking the progress of backfill, or pointing at genesis if backfill has not been initiated
 	backfillBlockRootKey = []byte("backfill-block-root")
+	// slot below which history was pruned
+	earliestAvailableSlotKey = []byte("earliest-available-slot")
+	// encoded fork choice store saved to speed up restarts
+	forkChoiceSnapshotKey = []byte("fork-choice-snapshot")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
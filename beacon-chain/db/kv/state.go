@@ -409,44 +409,50 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 		if err != nil {
 			return err
 		}
-		indicesByBucket := createStateIndicesFromStateSlot(ctx, slot)
-		if err := deleteValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
-			return errors.Wrap(err, "could not delete root for DB indices")
-		}
+		return s.deleteStateAtSlot(ctx, tx, blockRoot, slot)
+	})
+}
 
-		ok, err := s.isStateValidatorMigrationOver()
+// deleteStateAtSlot deletes the state of the given block root and slot, along with its slot index and
+// validator entry keys, within the given transaction.
+func (s *Store) deleteStateAtSlot(ctx context.Context, tx *bolt.Tx, blockRoot [32]byte, slot primitives.Slot) error {
+	indicesByBucket := createStateIndicesFromStateSlot(ctx, slot)
+	if err := deleteValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
+		return errors.Wrap(err, "could not delete root for DB indices")
+	}
+
+	ok, err := s.isStateValidatorMigrationOver()
+	if err != nil {
+		return err
+	}
+	if ok {
+		// remove the validator entry keys for the corresponding state.
+		idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
+		compressedValidatorHashes := idxBkt.Get(blockRoot[:])
+		err = idxBkt.Delete(blockRoot[:])
 		if err != nil {
 			return err
 		}
-		if ok {
-			// remove the validator entry keys for the corresponding state.
-			idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
-			compressedValidatorHashes := idxBkt.Get(blockRoot[:])
-			err = idxBkt.Delete(blockRoot[:])
-			if err != nil {
-				return err
-			}
 
-			// remove the respective validator entries from the cache.
-			if len(compressedValidatorHashes) == 0 {
-				return errors.Errorf("invalid compressed validator keys length")
-			}
-			validatorHashes, sErr := snappy.Decode(nil, compressedValidatorHashes)
-			if sErr != nil {
-				return errors.Wrap(sErr, "failed to uncompress validator keys")
-			}
-			if len(validatorHashes)%hashLength != 0 {
-				return errors.Errorf("invalid validator keys length: %d", len(validatorHashes))
-			}
-			for i := 0; i < len(validatorHashes); i += hashLength {
-				key := validatorHashes[i : i+hashLength]
-				s.validatorEntryCache.Del(key)
-				validatorEntryCacheDelete.Inc()
-			}
+		// remove the respective validator entries from the cache.
+		if len(compressedValidatorHashes) == 0 {
+			return errors.Errorf("invalid compressed validator keys length")
 		}
+		validatorHashes, sErr := snappy.Decode(nil, compressedValidatorHashes)
+		if sErr != nil {
+			return errors.Wrap(sErr, "failed to uncompress validator keys")
+		}
+		if len(validatorHashes)%hashLength != 0 {
+			return errors.Errorf("invalid validator keys length: %d", len(validatorHashes))
+		}
+		for i := 0; i < len(validatorHashes); i += hashLength {
+			key := validatorHashes[i : i+hashLength]
+			s.validatorEntryCache.Del(key)
+			validatorEntryCacheDelete.Inc()
+		}
+	}
 
-		return bkt.Delete(blockRoot[:])
-	})
+	return tx.Bucket(stateBucket).Delete(blockRoot[:])
 }
 
 // DeleteStates by block roots.
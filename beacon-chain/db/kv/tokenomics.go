@@ -0,0 +1,82 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// TokenomicsRecord returns the tokenomics record of the given epoch.
+// Nil is returned if no record was saved for the epoch.
+func (s *Store) TokenomicsRecord(ctx context.Context, epoch primitives.Epoch) (*dbtypes.TokenomicsRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.TokenomicsRecord")
+	defer span.End()
+
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		enc = tx.Bucket(tokenomicsHistoryBucket).Get(bytesutil.EpochToBytesBigEndian(epoch))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	record := &dbtypes.TokenomicsRecord{}
+	if err := record.UnmarshalBinary(enc); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// TokenomicsRecords returns at most `limit` tokenomics records with epochs between
+// `from` and `to` (inclusive), in ascending order of epoch.
+func (s *Store) TokenomicsRecords(ctx context.Context, from, to primitives.Epoch, limit int) ([]*dbtypes.TokenomicsRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.TokenomicsRecords")
+	defer span.End()
+
+	if from > to {
+		return nil, errors.Errorf("start epoch %d is greater than end epoch %d", from, to)
+	}
+	records := make([]*dbtypes.TokenomicsRecord, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(tokenomicsHistoryBucket).Cursor()
+		end := bytesutil.EpochToBytesBigEndian(to)
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(from)); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			if limit > 0 && len(records) >= limit {
+				break
+			}
+			record := &dbtypes.TokenomicsRecord{}
+			if err := record.UnmarshalBinary(v); err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// SaveTokenomicsRecord saves the tokenomics record of an epoch, replacing any existing
+// record of the same epoch.
+func (s *Store) SaveTokenomicsRecord(ctx context.Context, record *dbtypes.TokenomicsRecord) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveTokenomicsRecord")
+	defer span.End()
+
+	if record == nil {
+		return errors.New("nil tokenomics record")
+	}
+	enc, err := record.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(tokenomicsHistoryBucket).Put(bytesutil.EpochToBytesBigEndian(record.Epoch), enc)
+	})
+}
@@ -0,0 +1,78 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_TokenomicsRecord_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	record, err := db.TokenomicsRecord(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, (*dbtypes.TokenomicsRecord)(nil), record)
+
+	want := &dbtypes.TokenomicsRecord{
+		Epoch:                  10,
+		RewardAdjustmentFactor: 1,
+		PreviousEpochReserve:   2,
+		CurrentEpochReserve:    3,
+		EpochIssuance:          4,
+		EpochFeedbackBoost:     5,
+		ReserveUsage:           6,
+		TotalActiveBalance:     7,
+		TargetDepositPlan:      8,
+	}
+	require.NoError(t, db.SaveTokenomicsRecord(ctx, want))
+	record, err = db.TokenomicsRecord(ctx, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, record)
+
+	// Saving the same epoch again overwrites the record.
+	want.RewardAdjustmentFactor = 100
+	require.NoError(t, db.SaveTokenomicsRecord(ctx, want))
+	record, err = db.TokenomicsRecord(ctx, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, record)
+}
+
+func TestStore_TokenomicsRecords_Range(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	for _, e := range []primitives.Epoch{1, 2, 3, 5, 8, 256} {
+		require.NoError(t, db.SaveTokenomicsRecord(ctx, &dbtypes.TokenomicsRecord{Epoch: e, EpochIssuance: uint64(e) * 10}))
+	}
+
+	tests := []struct {
+		name   string
+		from   primitives.Epoch
+		to     primitives.Epoch
+		limit  int
+		epochs []primitives.Epoch
+	}{
+		{name: "all", from: 0, to: 1000, epochs: []primitives.Epoch{1, 2, 3, 5, 8, 256}},
+		{name: "inclusive bounds", from: 2, to: 5, epochs: []primitives.Epoch{2, 3, 5}},
+		{name: "limited", from: 2, to: 1000, limit: 2, epochs: []primitives.Epoch{2, 3}},
+		{name: "gap", from: 9, to: 255, epochs: []primitives.Epoch{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := db.TokenomicsRecords(ctx, tt.from, tt.to, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.epochs), len(records))
+			for i, r := range records {
+				assert.Equal(t, tt.epochs[i], r.Epoch)
+				assert.Equal(t, uint64(tt.epochs[i])*10, r.EpochIssuance)
+			}
+		})
+	}
+
+	_, err := db.TokenomicsRecords(ctx, 5, 4, 0)
+	require.ErrorContains(t, "start epoch 5 is greater than end epoch 4", err)
+}
@@ -0,0 +1,172 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// WithdrawalRecords returns at most `limit` withdrawal records matching the given filter, in
+// ascending order of withdrawal index. All matching records are returned if limit is not positive.
+func (s *Store) WithdrawalRecords(ctx context.Context, f *dbtypes.WithdrawalsFilter, limit int) ([]*dbtypes.WithdrawalRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.WithdrawalRecords")
+	defer span.End()
+
+	if f == nil {
+		return nil, errors.New("nil withdrawals filter")
+	}
+	if f.FromSlot > f.ToSlot {
+		return nil, errors.Errorf("start slot %d is greater than end slot %d", f.FromSlot, f.ToSlot)
+	}
+	records := make([]*dbtypes.WithdrawalRecord, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// Withdrawal indices grow with slots, so that no record before the first withdrawal of
+		// the first slot in range may match.
+		k, v := tx.Bucket(withdrawalsSlotIndicesBucket).Cursor().Seek(bytesutil.SlotToBytesBigEndian(f.FromSlot))
+		if k == nil {
+			return nil
+		}
+		start := bytesutil.BytesToUint64BigEndian(v)
+		if f.StartIndex > start {
+			start = f.StartIndex
+		}
+
+		// Iterate over the most selective index available.
+		var prefix []byte
+		c := tx.Bucket(withdrawalsHistoryBucket).Cursor()
+		switch {
+		case f.Address != nil:
+			prefix = f.Address.Bytes()
+			c = tx.Bucket(withdrawalsAddressIndicesBucket).Cursor()
+		case f.ValidatorIndex != nil:
+			prefix = bytesutil.Uint64ToBytesBigEndian(uint64(*f.ValidatorIndex))
+			c = tx.Bucket(withdrawalsValidatorIndicesBucket).Cursor()
+		}
+		history := tx.Bucket(withdrawalsHistoryBucket)
+		for k, _ := c.Seek(append(bytesutil.SafeCopyBytes(prefix), bytesutil.Uint64ToBytesBigEndian(start)...)); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			if limit > 0 && len(records) >= limit {
+				break
+			}
+			enc := history.Get(k[len(prefix):])
+			if enc == nil {
+				continue
+			}
+			record := &dbtypes.WithdrawalRecord{}
+			if err := record.UnmarshalBinary(enc); err != nil {
+				return err
+			}
+			if record.Slot > f.ToSlot {
+				break
+			}
+			if record.Slot < f.FromSlot {
+				continue
+			}
+			if f.Address != nil && record.Address != *f.Address {
+				continue
+			}
+			if f.ValidatorIndex != nil && record.ValidatorIndex != *f.ValidatorIndex {
+				continue
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// SaveWithdrawalRecords saves the given withdrawal records, replacing any existing record of the
+// same withdrawal index, as happens when the block which executed it has been reorged out.
+func (s *Store) SaveWithdrawalRecords(ctx context.Context, records []*dbtypes.WithdrawalRecord) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveWithdrawalRecords")
+	defer span.End()
+
+	firstIndices := make(map[primitives.Slot]uint64)
+	for _, record := range records {
+		if record == nil {
+			return errors.New("nil withdrawal record")
+		}
+		if first, ok := firstIndices[record.Slot]; !ok || record.Index < first {
+			firstIndices[record.Slot] = record.Index
+		}
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		history := tx.Bucket(withdrawalsHistoryBucket)
+		addressIndices := tx.Bucket(withdrawalsAddressIndicesBucket)
+		validatorIndices := tx.Bucket(withdrawalsValidatorIndicesBucket)
+		slotIndices := tx.Bucket(withdrawalsSlotIndicesBucket)
+		for _, record := range records {
+			key := bytesutil.Uint64ToBytesBigEndian(record.Index)
+			if enc := history.Get(key); enc != nil {
+				old := &dbtypes.WithdrawalRecord{}
+				if err := old.UnmarshalBinary(enc); err != nil {
+					return err
+				}
+				if err := addressIndices.Delete(append(old.Address.Bytes(), key...)); err != nil {
+					return err
+				}
+				if err := validatorIndices.Delete(append(bytesutil.Uint64ToBytesBigEndian(uint64(old.ValidatorIndex)), key...)); err != nil {
+					return err
+				}
+				oldSlot := bytesutil.SlotToBytesBigEndian(old.Slot)
+				if bytes.Equal(slotIndices.Get(oldSlot), key) {
+					if err := slotIndices.Delete(oldSlot); err != nil {
+						return err
+					}
+				}
+			}
+			enc, err := record.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			if err := history.Put(key, enc); err != nil {
+				return err
+			}
+			if err := addressIndices.Put(append(record.Address.Bytes(), key...), []byte{}); err != nil {
+				return err
+			}
+			if err := validatorIndices.Put(append(bytesutil.Uint64ToBytesBigEndian(uint64(record.ValidatorIndex)), key...), []byte{}); err != nil {
+				return err
+			}
+		}
+		for slot, first := range firstIndices {
+			if err := slotIndices.Put(bytesutil.SlotToBytesBigEndian(slot), bytesutil.Uint64ToBytesBigEndian(first)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// WithdrawalsBackfillSlot returns the slot up to which the withdrawals of finalized blocks have
+// been indexed by the backfill routine.
+func (s *Store) WithdrawalsBackfillSlot(ctx context.Context) (primitives.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.WithdrawalsBackfillSlot")
+	defer span.End()
+
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(withdrawalsBackfillSlotKey)
+		if len(enc) != 0 {
+			slot = bytesutil.BytesToSlotBigEndian(enc)
+		}
+		return nil
+	})
+	return slot, err
+}
+
+// SaveWithdrawalsBackfillSlot saves the slot up to which the withdrawals of finalized blocks have
+// been indexed by the backfill routine.
+func (s *Store) SaveWithdrawalsBackfillSlot(ctx context.Context, slot primitives.Slot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveWithdrawalsBackfillSlot")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(withdrawalsBackfillSlotKey, bytesutil.SlotToBytesBigEndian(slot))
+	})
+}
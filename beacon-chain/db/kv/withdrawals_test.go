@@ -0,0 +1,120 @@
+package kv
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func withdrawalIndices(records []*dbtypes.WithdrawalRecord) []uint64 {
+	indices := make([]uint64, len(records))
+	for i, r := range records {
+		indices[i] = r.Index
+	}
+	return indices
+}
+
+func TestStore_WithdrawalRecords(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	addr1 := common.HexToAddress("0x01")
+	addr2 := common.HexToAddress("0x02")
+	// Two withdrawals at slot 10, one at slot 11 and three at slot 20.
+	records := []*dbtypes.WithdrawalRecord{
+		{Index: 0, ValidatorIndex: 1, Address: addr1, Amount: 100, Slot: 10},
+		{Index: 1, ValidatorIndex: 2, Address: addr2, Amount: 200, Slot: 10},
+		{Index: 2, ValidatorIndex: 3, Address: addr1, Amount: 300, Slot: 11},
+		{Index: 3, ValidatorIndex: 1, Address: addr1, Amount: 400, Slot: 20},
+		{Index: 4, ValidatorIndex: 2, Address: addr2, Amount: 500, Slot: 20},
+		{Index: 5, ValidatorIndex: 4, Address: addr2, Amount: 600, Slot: 20},
+	}
+	require.NoError(t, db.SaveWithdrawalRecords(ctx, records))
+
+	validator := primitives.ValidatorIndex(2)
+	tests := []struct {
+		name    string
+		filter  *dbtypes.WithdrawalsFilter
+		limit   int
+		indices []uint64
+	}{
+		{name: "all", filter: &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, indices: []uint64{0, 1, 2, 3, 4, 5}},
+		{name: "limit", filter: &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, limit: 2, indices: []uint64{0, 1}},
+		{name: "start index", filter: &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64, StartIndex: 4}, indices: []uint64{4, 5}},
+		{name: "slot range", filter: &dbtypes.WithdrawalsFilter{FromSlot: 11, ToSlot: 19}, indices: []uint64{2}},
+		{name: "slot range between blocks", filter: &dbtypes.WithdrawalsFilter{FromSlot: 12, ToSlot: 19}, indices: []uint64{}},
+		{name: "slot range past last block", filter: &dbtypes.WithdrawalsFilter{FromSlot: 21, ToSlot: math.MaxUint64}, indices: []uint64{}},
+		{name: "address", filter: &dbtypes.WithdrawalsFilter{Address: &addr1, ToSlot: math.MaxUint64}, indices: []uint64{0, 2, 3}},
+		{name: "address and slot range", filter: &dbtypes.WithdrawalsFilter{Address: &addr2, FromSlot: 11, ToSlot: 20}, indices: []uint64{4, 5}},
+		{name: "validator", filter: &dbtypes.WithdrawalsFilter{ValidatorIndex: &validator, ToSlot: math.MaxUint64}, indices: []uint64{1, 4}},
+		{name: "address and validator", filter: &dbtypes.WithdrawalsFilter{Address: &addr2, ValidatorIndex: &validator, ToSlot: math.MaxUint64, StartIndex: 2}, indices: []uint64{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.WithdrawalRecords(ctx, tt.filter, tt.limit)
+			require.NoError(t, err)
+			require.DeepEqual(t, tt.indices, withdrawalIndices(got))
+		})
+	}
+
+	got, err := db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{FromSlot: 11, ToSlot: 11}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.DeepEqual(t, records[2], got[0])
+
+	_, err = db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{FromSlot: 2, ToSlot: 1}, 0)
+	require.ErrorContains(t, "start slot 2 is greater than end slot 1", err)
+}
+
+func TestStore_SaveWithdrawalRecords_Replaces(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	addr1 := common.HexToAddress("0x01")
+	addr2 := common.HexToAddress("0x02")
+	// Withdrawals from a block at slot 10 which is then reorged out by a block at slot 11.
+	require.NoError(t, db.SaveWithdrawalRecords(ctx, []*dbtypes.WithdrawalRecord{
+		{Index: 0, ValidatorIndex: 1, Address: addr1, Amount: 100, Slot: 10},
+		{Index: 1, ValidatorIndex: 2, Address: addr1, Amount: 200, Slot: 10},
+	}))
+	require.NoError(t, db.SaveWithdrawalRecords(ctx, []*dbtypes.WithdrawalRecord{
+		{Index: 0, ValidatorIndex: 3, Address: addr2, Amount: 300, Slot: 11},
+		{Index: 1, ValidatorIndex: 4, Address: addr2, Amount: 400, Slot: 11},
+	}))
+
+	got, err := db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	assert.Equal(t, primitives.ValidatorIndex(3), got[0].ValidatorIndex)
+	assert.Equal(t, primitives.Slot(11), got[1].Slot)
+
+	got, err = db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{Address: &addr1, ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+	validator := primitives.ValidatorIndex(1)
+	got, err = db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{ValidatorIndex: &validator, ToSlot: math.MaxUint64}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+	got, err = db.WithdrawalRecords(ctx, &dbtypes.WithdrawalsFilter{FromSlot: 10, ToSlot: 10}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+}
+
+func TestStore_WithdrawalsBackfillSlot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	slot, err := db.WithdrawalsBackfillSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), slot)
+	require.NoError(t, db.SaveWithdrawalsBackfillSlot(ctx, 64))
+	slot, err = db.WithdrawalsBackfillSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(64), slot)
+}
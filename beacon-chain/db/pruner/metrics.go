@@ -0,0 +1,17 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prunedBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pruner_blocks_pruned_total",
+		Help: "The number of blocks pruned from the db with their indices and states.",
+	})
+	earliestAvailableSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pruner_earliest_available_slot",
+		Help: "The slot below which history was pruned from the db.",
+	})
+)
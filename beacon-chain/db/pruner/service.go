@@ -0,0 +1,166 @@
+// Package pruner deletes blocks and states older than a retention window from the beacon db.
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+const (
+	defaultBatchSize = 64
+	// batchPause leaves the db to block imports between batches.
+	batchPause = 100 * time.Millisecond
+)
+
+// BackfillStatus is reloaded from the db after pruning, so that it does not consider pruned blocks available.
+type BackfillStatus interface {
+	Reload(ctx context.Context) error
+}
+
+// Config to set up the pruner service.
+type Config struct {
+	DB             db.NoHeadAccessDatabase
+	ClockWaiter    startup.ClockWaiter
+	BackfillStatus BackfillStatus
+	// RetentionEpochs is the number of epochs of history kept, 0 disabling pruning.
+	RetentionEpochs primitives.Epoch
+	// BatchSize is the maximum number of blocks deleted in a single db transaction.
+	BatchSize int
+}
+
+// Service prunes the finalized blocks and states older than the retention window at every epoch.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewService configures the pruner service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the pruner service. It returns right away if pruning is disabled.
+func (s *Service) Start() {
+	if s.cfg.RetentionEpochs == 0 {
+		return
+	}
+	clock, err := s.cfg.ClockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Pruner failed to receive startup event")
+		return
+	}
+	log.WithField("retentionEpochs", s.cfg.RetentionEpochs).Info("Pruning history older than the retention window")
+	ticker := slots.NewSlotTicker(clock.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if !slots.IsEpochStart(slot) {
+				continue
+			}
+			if err := s.prune(s.ctx, slot); err != nil && s.ctx.Err() == nil {
+				log.WithError(err).Error("Could not prune history")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop the pruner service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the pruner service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// prune deletes the history below the retention window ending at the given slot, never going past the
+// finalized block.
+func (s *Service) prune(ctx context.Context, current primitives.Slot) error {
+	epoch := slots.ToEpoch(current)
+	if epoch <= s.cfg.RetentionEpochs {
+		return nil
+	}
+	cutoffEpoch := epoch - s.cfg.RetentionEpochs
+	cp, err := s.cfg.DB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if cp.Epoch < cutoffEpoch {
+		cutoffEpoch = cp.Epoch
+	}
+	cutoff, err := slots.EpochStart(cutoffEpoch)
+	if err != nil {
+		return err
+	}
+	finalized, err := s.cfg.DB.Block(ctx, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+	if err := blocks.BeaconBlockIsNil(finalized); err == nil && finalized.Block().Slot() < cutoff {
+		cutoff = finalized.Block().Slot()
+	}
+
+	total := 0
+	for {
+		n, err := s.cfg.DB.PruneHistory(ctx, cutoff, s.cfg.BatchSize)
+		if err != nil {
+			return errors.Wrapf(err, "could not prune history below slot %d", cutoff)
+		}
+		total += n
+		prunedBlocksTotal.Add(float64(n))
+		if n == 0 {
+			break
+		}
+		select {
+		case <-time.After(batchPause):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	earliest, err := s.cfg.DB.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get earliest available slot")
+	}
+	earliestAvailableSlot.Set(float64(earliest))
+	if s.cfg.BackfillStatus != nil {
+		if err := s.cfg.BackfillStatus.Reload(ctx); err != nil {
+			return errors.Wrap(err, "could not reload backfill status")
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"blocks":       total,
+		"earliestSlot": earliest,
+	}).Info("Pruned history")
+	return nil
+}
@@ -0,0 +1,79 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockBackfillStatus struct {
+	reloads int
+}
+
+func (m *mockBackfillStatus) Reload(_ context.Context) error {
+	m.reloads++
+	return nil
+}
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	spe := params.BeaconConfig().SlotsPerEpoch
+	d := dbtest.SetupDB(t)
+
+	// Blocks over 4 epochs with a state at the start of each epoch, finalized at the start of epoch 3.
+	genesis := util.NewBeaconBlock()
+	util.SaveBlock(t, ctx, d, genesis)
+	parent, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, parent))
+	roots := make(map[primitives.Slot][32]byte)
+	for slot := primitives.Slot(1); slot <= 4*spe; slot++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = slot
+		blk.Block.ParentRoot = parent[:]
+		util.SaveBlock(t, ctx, d, blk)
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots[slot] = root
+		parent = root
+		if slot%spe == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(slot))
+			require.NoError(t, d.SaveState(ctx, st, root))
+		}
+	}
+	finalized := roots[3*spe]
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: finalized[:]}))
+
+	bfs := &mockBackfillStatus{}
+	s := NewService(ctx, &Config{DB: d, BackfillStatus: bfs, RetentionEpochs: 2, BatchSize: 16})
+
+	// Nothing to prune within the retention window.
+	require.NoError(t, s.prune(ctx, 2*spe))
+	assert.Equal(t, 0, bfs.reloads)
+
+	require.NoError(t, s.prune(ctx, 4*spe))
+	assert.Equal(t, 1, bfs.reloads)
+	earliest, err := d.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2*spe, earliest)
+	assert.Equal(t, false, d.HasBlock(ctx, roots[2*spe-1]))
+	assert.Equal(t, true, d.HasBlock(ctx, roots[2*spe]))
+	assert.Equal(t, false, d.HasState(ctx, roots[spe]))
+	assert.Equal(t, true, d.HasState(ctx, roots[2*spe]))
+
+	// The cutoff never goes past the finalized checkpoint.
+	require.NoError(t, s.prune(ctx, 10*spe))
+	earliest, err = d.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3*spe, earliest)
+	assert.Equal(t, true, d.HasBlock(ctx, finalized))
+}
@@ -0,0 +1,66 @@
+// Package types defines the records kept in the beacon database
+// which are not backed by a protobuf message.
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// tokenomicsRecordSize is the size of an encoded TokenomicsRecord in bytes.
+const tokenomicsRecordSize = 9 * 8
+
+// TokenomicsRecord is a snapshot of Over tokenomics taken from the first state of an epoch,
+// right after the epoch transition which ran helpers.ProcessRewardfactorUpdate.
+type TokenomicsRecord struct {
+	Epoch                  primitives.Epoch
+	RewardAdjustmentFactor uint64
+	// PreviousEpochReserve is the reserve at the start of the epoch before Epoch, which ReserveUsage
+	// is measured against. It is not the previous epoch reserve of the first state of Epoch, which the
+	// epoch transition sets to CurrentEpochReserve.
+	PreviousEpochReserve uint64
+	CurrentEpochReserve  uint64
+	// EpochIssuance and EpochFeedbackBoost are the rewards to be paid during Epoch.
+	EpochIssuance      uint64
+	EpochFeedbackBoost uint64
+	// ReserveUsage is the amount taken out of the reserve during the epoch before Epoch.
+	ReserveUsage       uint64
+	TotalActiveBalance uint64
+	TargetDepositPlan  uint64
+}
+
+// MarshalBinary encodes the record as a sequence of big endian uint64 values.
+func (r *TokenomicsRecord) MarshalBinary() ([]byte, error) {
+	enc := make([]byte, 0, tokenomicsRecordSize)
+	for _, v := range r.fields() {
+		enc = binary.BigEndian.AppendUint64(enc, *v)
+	}
+	return enc, nil
+}
+
+// UnmarshalBinary decodes a record encoded by MarshalBinary.
+func (r *TokenomicsRecord) UnmarshalBinary(enc []byte) error {
+	if len(enc) != tokenomicsRecordSize {
+		return errors.Errorf("wrong tokenomics record size, expected %d, got %d", tokenomicsRecordSize, len(enc))
+	}
+	for i, v := range r.fields() {
+		*v = binary.BigEndian.Uint64(enc[i*8 : (i+1)*8])
+	}
+	return nil
+}
+
+func (r *TokenomicsRecord) fields() []*uint64 {
+	return []*uint64{
+		(*uint64)(&r.Epoch),
+		&r.RewardAdjustmentFactor,
+		&r.PreviousEpochReserve,
+		&r.CurrentEpochReserve,
+		&r.EpochIssuance,
+		&r.EpochFeedbackBoost,
+		&r.ReserveUsage,
+		&r.TotalActiveBalance,
+		&r.TargetDepositPlan,
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// withdrawalRecordSize is the size of an encoded WithdrawalRecord in bytes.
+const withdrawalRecordSize = 4*8 + common.AddressLength
+
+// WithdrawalRecord is a withdrawal executed by the payload of the canonical block at Slot.
+type WithdrawalRecord struct {
+	Index          uint64
+	ValidatorIndex primitives.ValidatorIndex
+	Address        common.Address
+	Amount         uint64
+	Slot           primitives.Slot
+}
+
+// MarshalBinary encodes the record as its big endian uint64 values followed by the address.
+func (r *WithdrawalRecord) MarshalBinary() ([]byte, error) {
+	enc := make([]byte, 0, withdrawalRecordSize)
+	enc = binary.BigEndian.AppendUint64(enc, r.Index)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(r.ValidatorIndex))
+	enc = binary.BigEndian.AppendUint64(enc, r.Amount)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(r.Slot))
+	return append(enc, r.Address[:]...), nil
+}
+
+// UnmarshalBinary decodes a record encoded by MarshalBinary.
+func (r *WithdrawalRecord) UnmarshalBinary(enc []byte) error {
+	if len(enc) != withdrawalRecordSize {
+		return errors.Errorf("wrong withdrawal record size, expected %d, got %d", withdrawalRecordSize, len(enc))
+	}
+	r.Index = binary.BigEndian.Uint64(enc[0:8])
+	r.ValidatorIndex = primitives.ValidatorIndex(binary.BigEndian.Uint64(enc[8:16]))
+	r.Amount = binary.BigEndian.Uint64(enc[16:24])
+	r.Slot = primitives.Slot(binary.BigEndian.Uint64(enc[24:32]))
+	copy(r.Address[:], enc[32:])
+	return nil
+}
+
+// WithdrawalsFilter selects withdrawal records. Nil fields match any record.
+type WithdrawalsFilter struct {
+	Address        *common.Address
+	ValidatorIndex *primitives.ValidatorIndex
+	// FromSlot and ToSlot are inclusive bounds on the slot of the records.
+	FromSlot primitives.Slot
+	ToSlot   primitives.Slot
+	// StartIndex is the lowest withdrawal index of the records, used to paginate results.
+	StartIndex uint64
+}
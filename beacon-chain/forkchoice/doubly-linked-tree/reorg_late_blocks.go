@@ -1,8 +1,6 @@
 package doublylinkedtree
 
 import (
-	"time"
-
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -43,7 +41,7 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 		return
 	}
 
-	if head.slot != slots.CurrentSlot(f.store.genesisTime) {
+	if head.slot != f.store.currentSlot() {
 		return
 	}
 
@@ -107,7 +105,7 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	}
 
 	// Only reorg blocks from the previous slot.
-	if head.slot+1 != slots.CurrentSlot(f.store.genesisTime) {
+	if head.slot+1 != f.store.currentSlot() {
 		return head.root
 	}
 	// Do not reorg on epoch boundaries
@@ -148,7 +146,7 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	}
 
 	// Only reorg if we are proposing early
-	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, f.store.now())
 	if err != nil {
 		log.WithError(err).Error("could not check if proposing early")
 		return head.root
@@ -0,0 +1,536 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// scenariosDir holds the fork choice scenarios run by TestForkChoice_Scenarios. To reproduce an incident,
+// add a YAML file describing the blocks, votes and timing observed on the network, with the heads and
+// reorg decisions expected at each step.
+var scenariosDir = filepath.Join("testdata", "scenarios")
+
+// genesisBlockName is the name of the block every scenario starts from, at slot 0.
+const genesisBlockName = "genesis"
+
+// scenario is a fork choice simulation. The store starts at slot 0 with the genesis block justified
+// and finalized, and steps are then applied in order. Each step happens at a time given by a slot
+// and an offset in seconds from the start of that slot, defaulting to the time of the previous step.
+type scenario struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Validators  uint64          `json:"validators"`
+	Balance     uint64          `json:"balance"`
+	Steps       []*scenarioStep `json:"steps"`
+}
+
+// scenarioStep holds exactly one action or check.
+type scenarioStep struct {
+	Slot        *uint64              `json:"slot"`
+	Offset      *uint64              `json:"offset"`
+	Block       *scenarioBlock       `json:"block"`
+	Attestation *scenarioAttestation `json:"attestation"`
+	Slashing    validatorList        `json:"slashing"`
+	Balances    *scenarioBalances    `json:"balances"`
+	Justify     *scenarioCheckpoint  `json:"justify"`
+	Finalize    *scenarioCheckpoint  `json:"finalize"`
+	Check       *scenarioCheck       `json:"check"`
+}
+
+// scenarioBlock is inserted with the justified and finalized checkpoints of its post state, which
+// default to the checkpoints of the store.
+type scenarioBlock struct {
+	Name      string              `json:"name"`
+	Parent    string              `json:"parent"`
+	Slot      uint64              `json:"slot"`
+	Justified *scenarioCheckpoint `json:"justified"`
+	Finalized *scenarioCheckpoint `json:"finalized"`
+}
+
+// scenarioAttestation is a vote of the validators for a block, with a target epoch defaulting to the
+// epoch of the step.
+type scenarioAttestation struct {
+	Block       string        `json:"block"`
+	Validators  validatorList `json:"validators"`
+	TargetEpoch *uint64       `json:"target_epoch"`
+}
+
+// scenarioBalances changes the effective balance of validators, for example when they are exited by a
+// bailout. Like on a node, the new balances are only used once the justified checkpoint is updated.
+type scenarioBalances struct {
+	Validators validatorList `json:"validators"`
+	Balance    uint64        `json:"balance"`
+}
+
+type scenarioCheckpoint struct {
+	Epoch uint64 `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// scenarioCheck computes the head and compares the store with the fields that are set.
+type scenarioCheck struct {
+	Head                string              `json:"head"`
+	ProposerHead        string              `json:"proposer_head"`
+	ShouldOverrideFCU   *bool               `json:"should_override_fcu"`
+	ProposerBoost       *string             `json:"proposer_boost"`
+	Weights             map[string]uint64   `json:"weights"`
+	JustifiedCheckpoint *scenarioCheckpoint `json:"justified_checkpoint"`
+	FinalizedCheckpoint *scenarioCheckpoint `json:"finalized_checkpoint"`
+}
+
+// validatorList is a list of validator indices, where each item is either an index or an inclusive
+// range of indices such as "0-31".
+type validatorList []uint64
+
+func (l *validatorList) UnmarshalJSON(enc []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(enc, &items); err != nil {
+		return errors.Wrap(err, "validators must be a list")
+	}
+	indices := make([]uint64, 0, len(items))
+	for _, item := range items {
+		var index uint64
+		if err := json.Unmarshal(item, &index); err == nil {
+			indices = append(indices, index)
+			continue
+		}
+		var r string
+		if err := json.Unmarshal(item, &r); err != nil {
+			return fmt.Errorf("invalid validator item %s", item)
+		}
+		bounds := strings.Split(r, "-")
+		if len(bounds) != 2 {
+			return fmt.Errorf("invalid validator range %q", r)
+		}
+		from, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid validator range %q", r)
+		}
+		to, err := strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid validator range %q", r)
+		}
+		if from > to {
+			return fmt.Errorf("invalid validator range %q", r)
+		}
+		for i := from; i <= to; i++ {
+			indices = append(indices, i)
+		}
+	}
+	*l = indices
+	return nil
+}
+
+// simulator drives a fork choice store through the steps of a scenario.
+type simulator struct {
+	f        *ForkChoice
+	balances []uint64
+	roots    map[string][32]byte
+	names    map[[32]byte]string
+	slot     primitives.Slot
+	offset   uint64
+}
+
+// simulatorGenesisTime is the genesis time of the stores driven by the simulator, whose clock is the
+// time of the simulation rather than the wall clock.
+const simulatorGenesisTime = 1606824023
+
+func newSimulator(ctx context.Context, sc *scenario) (*simulator, error) {
+	validators := sc.Validators
+	if validators == 0 {
+		validators = 64
+	}
+	balance := sc.Balance
+	if balance == 0 {
+		balance = params.BeaconConfig().MaxEffectiveBalance
+	}
+	s := &simulator{
+		f:        New(),
+		balances: make([]uint64, validators),
+		roots:    make(map[string][32]byte),
+		names:    make(map[[32]byte]string),
+	}
+	for i := range s.balances {
+		s.balances[i] = balance
+	}
+	s.f.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) {
+		b := make([]uint64, len(s.balances))
+		copy(b, s.balances)
+		return b, nil
+	})
+	s.f.SetGenesisTime(simulatorGenesisTime)
+	s.f.store.clock = func() uint64 {
+		return simulatorGenesisTime + uint64(s.slot)*params.BeaconConfig().SecondsPerSlot + s.offset
+	}
+
+	genesisRoot, err := s.name(genesisBlockName)
+	if err != nil {
+		return nil, err
+	}
+	cp := &forkchoicetypes.Checkpoint{Epoch: 0, Root: genesisRoot}
+	s.f.store.unrealizedJustifiedCheckpoint = cp
+	s.f.store.unrealizedFinalizedCheckpoint = cp
+	if err := s.f.UpdateJustifiedCheckpoint(ctx, cp); err != nil {
+		return nil, err
+	}
+	if err := s.f.UpdateFinalizedCheckpoint(cp); err != nil {
+		return nil, err
+	}
+	st, err := scenarioBlockState(0, [32]byte{}, cp, cp)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.f.InsertNode(ctx, st, genesisRoot); err != nil {
+		return nil, errors.Wrap(err, "could not insert genesis block")
+	}
+	return s, nil
+}
+
+// runScenario applies the steps of the scenario to a new fork choice store, and returns an error
+// on the first step that fails or whose check does not match the store.
+func runScenario(ctx context.Context, sc *scenario) error {
+	s, err := newSimulator(ctx, sc)
+	if err != nil {
+		return err
+	}
+	for i, step := range sc.Steps {
+		if err := s.apply(ctx, step); err != nil {
+			return errors.Wrapf(err, "step %d at slot %d, offset %ds", i, s.slot, s.offset)
+		}
+	}
+	return nil
+}
+
+func (s *simulator) apply(ctx context.Context, step *scenarioStep) error {
+	actions := 0
+	for _, set := range []bool{step.Block != nil, step.Attestation != nil, step.Slashing != nil, step.Balances != nil,
+		step.Justify != nil, step.Finalize != nil, step.Check != nil} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("expected exactly one action in step, got %d", actions)
+	}
+
+	slot, offset := s.slot, s.offset
+	if step.Slot != nil {
+		slot, offset = primitives.Slot(*step.Slot), 0
+	}
+	if step.Offset != nil {
+		offset = *step.Offset
+	}
+	if err := s.advance(ctx, slot, offset); err != nil {
+		return err
+	}
+
+	switch {
+	case step.Block != nil:
+		return s.applyBlock(ctx, step.Block)
+	case step.Attestation != nil:
+		root, err := s.root(step.Attestation.Block)
+		if err != nil {
+			return err
+		}
+		target := slots.ToEpoch(s.slot)
+		if step.Attestation.TargetEpoch != nil {
+			target = primitives.Epoch(*step.Attestation.TargetEpoch)
+		}
+		s.f.ProcessAttestation(ctx, step.Attestation.Validators, root, target)
+	case step.Slashing != nil:
+		for _, index := range step.Slashing {
+			s.f.InsertSlashedIndex(ctx, primitives.ValidatorIndex(index))
+		}
+	case step.Balances != nil:
+		for _, index := range step.Balances.Validators {
+			if index >= uint64(len(s.balances)) {
+				return fmt.Errorf("unknown validator %d", index)
+			}
+			s.balances[index] = step.Balances.Balance
+		}
+	case step.Justify != nil:
+		cp, err := s.checkpoint(step.Justify)
+		if err != nil {
+			return err
+		}
+		return s.f.UpdateJustifiedCheckpoint(ctx, cp)
+	case step.Finalize != nil:
+		cp, err := s.checkpoint(step.Finalize)
+		if err != nil {
+			return err
+		}
+		return s.f.UpdateFinalizedCheckpoint(cp)
+	case step.Check != nil:
+		return s.check(ctx, step.Check)
+	}
+	return nil
+}
+
+// advance moves the time of the simulation forward, calling the fork choice on-tick handler at the
+// start of every slot.
+func (s *simulator) advance(ctx context.Context, slot primitives.Slot, offset uint64) error {
+	if slot < s.slot || (slot == s.slot && offset < s.offset) {
+		return fmt.Errorf("time goes backwards to slot %d, offset %ds", slot, offset)
+	}
+	if offset >= params.BeaconConfig().SecondsPerSlot {
+		return fmt.Errorf("offset %ds is not within the slot", offset)
+	}
+	for s.slot < slot {
+		s.slot, s.offset = s.slot+1, 0
+		if err := s.f.NewSlot(ctx, s.slot); err != nil {
+			return errors.Wrapf(err, "could not process slot %d", s.slot)
+		}
+	}
+	s.offset = offset
+	return nil
+}
+
+func (s *simulator) applyBlock(ctx context.Context, b *scenarioBlock) error {
+	if _, ok := s.roots[b.Name]; ok {
+		return fmt.Errorf("duplicate block %s", b.Name)
+	}
+	parentRoot, err := s.root(b.Parent)
+	if err != nil {
+		return err
+	}
+	root, err := s.name(b.Name)
+	if err != nil {
+		return err
+	}
+	jc, fc := s.f.JustifiedCheckpoint(), s.f.FinalizedCheckpoint()
+	if b.Justified != nil {
+		if jc, err = s.checkpoint(b.Justified); err != nil {
+			return err
+		}
+	}
+	if b.Finalized != nil {
+		if fc, err = s.checkpoint(b.Finalized); err != nil {
+			return err
+		}
+	}
+	st, err := scenarioBlockState(primitives.Slot(b.Slot), parentRoot, jc, fc)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(s.f.InsertNode(ctx, st, root), "could not insert block %s", b.Name)
+}
+
+func (s *simulator) check(ctx context.Context, c *scenarioCheck) error {
+	head, err := s.f.Head(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not compute head")
+	}
+	if c.Head != "" {
+		if err := s.expectRoot("head", c.Head, head); err != nil {
+			return err
+		}
+	}
+	if c.ProposerHead != "" {
+		if err := s.expectRoot("proposer head", c.ProposerHead, s.f.GetProposerHead()); err != nil {
+			return err
+		}
+	}
+	if c.ShouldOverrideFCU != nil {
+		if got := s.f.ShouldOverrideFCU(); got != *c.ShouldOverrideFCU {
+			return fmt.Errorf("should override forkchoice update is %t, expected %t", got, *c.ShouldOverrideFCU)
+		}
+	}
+	if c.ProposerBoost != nil {
+		if *c.ProposerBoost == "" {
+			if boost := s.f.ProposerBoost(); boost != [32]byte{} {
+				return fmt.Errorf("proposer boost is %s, expected none", s.names[boost])
+			}
+		} else if err := s.expectRoot("proposer boost", *c.ProposerBoost, s.f.ProposerBoost()); err != nil {
+			return err
+		}
+	}
+	for name, want := range c.Weights {
+		root, err := s.root(name)
+		if err != nil {
+			return err
+		}
+		got, err := s.f.Weight(root)
+		if err != nil {
+			return errors.Wrapf(err, "could not get weight of block %s", name)
+		}
+		if got != want {
+			return fmt.Errorf("weight of block %s is %d, expected %d", name, got, want)
+		}
+	}
+	if c.JustifiedCheckpoint != nil {
+		if err := s.expectCheckpoint("justified", c.JustifiedCheckpoint, s.f.JustifiedCheckpoint()); err != nil {
+			return err
+		}
+	}
+	if c.FinalizedCheckpoint != nil {
+		if err := s.expectCheckpoint("finalized", c.FinalizedCheckpoint, s.f.FinalizedCheckpoint()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *simulator) expectRoot(field, want string, got [32]byte) error {
+	wantRoot, err := s.root(want)
+	if err != nil {
+		return err
+	}
+	if got != wantRoot {
+		return fmt.Errorf("%s is %s, expected %s", field, s.describe(got), want)
+	}
+	return nil
+}
+
+func (s *simulator) expectCheckpoint(field string, want *scenarioCheckpoint, got *forkchoicetypes.Checkpoint) error {
+	wantCp, err := s.checkpoint(want)
+	if err != nil {
+		return err
+	}
+	if got.Epoch != wantCp.Epoch || got.Root != wantCp.Root {
+		return fmt.Errorf("%s checkpoint is %s at epoch %d, expected %s at epoch %d",
+			field, s.describe(got.Root), got.Epoch, want.Root, want.Epoch)
+	}
+	return nil
+}
+
+// name registers a new block name and returns its root.
+func (s *simulator) name(name string) ([32]byte, error) {
+	if name == "" || len(name) > 32 {
+		return [32]byte{}, fmt.Errorf("block name %q must have between 1 and 32 characters", name)
+	}
+	var root [32]byte
+	copy(root[:], name)
+	s.roots[name] = root
+	s.names[root] = name
+	return root, nil
+}
+
+func (s *simulator) root(name string) ([32]byte, error) {
+	root, ok := s.roots[name]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("unknown block %q", name)
+	}
+	return root, nil
+}
+
+func (s *simulator) describe(root [32]byte) string {
+	if name, ok := s.names[root]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", root)
+}
+
+func (s *simulator) checkpoint(cp *scenarioCheckpoint) (*forkchoicetypes.Checkpoint, error) {
+	root, err := s.root(cp.Root)
+	if err != nil {
+		return nil, err
+	}
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(cp.Epoch), Root: root}, nil
+}
+
+// scenarioBlockState returns a post state with the given checkpoints for a block of the given slot and parent.
+func scenarioBlockState(slot primitives.Slot, parentRoot [32]byte, jc, fc *forkchoicetypes.Checkpoint) (state.BeaconState, error) {
+	base := &ethpb.BeaconStateBellatrix{
+		Slot:                         slot,
+		RandaoMixes:                  make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+		CurrentJustifiedCheckpoint:   &ethpb.Checkpoint{Epoch: jc.Epoch, Root: jc.Root[:]},
+		FinalizedCheckpoint:          &ethpb.Checkpoint{Epoch: fc.Epoch, Root: fc.Root[:]},
+		LatestExecutionPayloadHeader: &enginev1.ExecutionPayloadHeader{},
+		LatestBlockHeader:            &ethpb.BeaconBlockHeader{ParentRoot: parentRoot[:]},
+	}
+	return state_native.InitializeFromProtoBellatrix(base)
+}
+
+func TestForkChoice_Scenarios(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(scenariosDir, "*.yaml"))
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(files), "no scenarios in %s", scenariosDir)
+	for _, file := range files {
+		enc, err := os.ReadFile(file)
+		require.NoError(t, err)
+		sc := &scenario{}
+		require.NoError(t, yaml.Unmarshal(enc, sc), "could not decode %s", file)
+		t.Run(sc.Name, func(t *testing.T) {
+			require.NoError(t, runScenario(context.Background(), sc))
+		})
+	}
+}
+
+func TestForkChoice_ScenarioFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		err      string
+	}{
+		{
+			name: "wrong head",
+			scenario: `
+steps:
+  - slot: 1
+    block: {name: a, parent: genesis, slot: 1}
+  - check: {head: genesis}`,
+			err: "head is a, expected genesis",
+		},
+		{
+			name: "unknown parent",
+			scenario: `
+steps:
+  - slot: 1
+    block: {name: a, parent: b, slot: 1}`,
+			err: `unknown block "b"`,
+		},
+		{
+			name: "time goes backwards",
+			scenario: `
+steps:
+  - slot: 2
+    block: {name: a, parent: genesis, slot: 2}
+  - slot: 1
+    check: {head: a}`,
+			err: "time goes backwards",
+		},
+		{
+			name: "several actions",
+			scenario: `
+steps:
+  - block: {name: a, parent: genesis, slot: 1}
+    check: {head: a}`,
+			err: "expected exactly one action in step, got 2",
+		},
+		{
+			name: "invalid validator range",
+			scenario: `
+steps:
+  - attestation: {block: genesis, validators: [3-1]}`,
+			err: `invalid validator range "3-1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &scenario{}
+			err := yaml.Unmarshal([]byte(tt.scenario), sc)
+			if err == nil {
+				err = runScenario(context.Background(), sc)
+			}
+			require.ErrorContains(t, tt.err, err)
+		})
+	}
+}
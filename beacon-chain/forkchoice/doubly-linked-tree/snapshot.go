@@ -0,0 +1,347 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// snapshotVersion is bumped whenever the snapshot encoding changes, so that
+// snapshots written by a previous version are discarded instead of misread.
+const snapshotVersion = byte(1)
+
+const (
+	// nodeSnapshotSize is the size of an encoded node: slot, root, parent root, payload hash,
+	// four epochs, balance, weight, optimistic flag and timestamp.
+	nodeSnapshotSize = 8 + 3*fieldparams.RootLength + 4*8 + 2*8 + 1 + 8
+	// voteSnapshotSize is the size of an encoded vote: current root, next root and next epoch.
+	voteSnapshotSize = 2*fieldparams.RootLength + 8
+)
+
+var (
+	errUnknownSnapshotVersion = errors.New("unknown fork choice snapshot version")
+	errSnapshotTooShort       = errors.New("fork choice snapshot too short")
+	errSnapshotTrailingBytes  = errors.New("trailing bytes in fork choice snapshot")
+	errDuplicateNode          = errors.New("duplicate node in fork choice snapshot")
+)
+
+// Snapshot encodes the fork choice store: the nodes with their weights and optimistic status,
+// the checkpoints, proposer boost, equivocating indices, votes and balances.
+// The caller of this function MUST hold a read lock in forkchoice.
+func (f *ForkChoice) Snapshot() ([]byte, error) {
+	s := f.store
+	if s.treeRootNode == nil || s.headNode == nil {
+		return nil, errors.Wrap(ErrNilNode, "could not snapshot empty fork choice store")
+	}
+	checkpoints := s.snapshotCheckpoints()
+	for _, cp := range checkpoints {
+		if *cp == nil {
+			return nil, errInvalidNilCheckpoint
+		}
+	}
+
+	nodes := make([]*Node, 0, len(s.nodeByRoot))
+	// Parents are encoded before their children, so that the tree can be rebuilt in a single pass,
+	// and children in order so that the rebuilt tree is identical.
+	stack := []*Node{s.treeRootNode}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		nodes = append(nodes, n)
+		for i := len(n.children) - 1; i >= 0; i-- {
+			stack = append(stack, n.children[i])
+		}
+	}
+	slashed := make([]primitives.ValidatorIndex, 0, len(s.slashedIndices))
+	for idx := range s.slashedIndices {
+		slashed = append(slashed, idx)
+	}
+	sort.Slice(slashed, func(i, j int) bool { return slashed[i] < slashed[j] })
+
+	size := 1 + 8 + fieldparams.RootLength + len(checkpoints)*(8+fieldparams.RootLength) + 3*fieldparams.RootLength + 3*8 +
+		8 + len(nodes)*nodeSnapshotSize + 8 + len(slashed)*8 + 8 + len(f.votes)*voteSnapshotSize +
+		8 + len(f.balances)*8 + 8 + len(f.justifiedBalances)*8
+	w := &snapshotWriter{buf: make([]byte, 0, size)}
+	w.buf = append(w.buf, snapshotVersion)
+	w.uint64(s.genesisTime)
+	w.root(s.originRoot)
+	for _, cp := range checkpoints {
+		w.uint64(uint64((*cp).Epoch))
+		w.root((*cp).Root)
+	}
+	w.root(s.proposerBoostRoot)
+	w.root(s.previousProposerBoostRoot)
+	w.uint64(s.previousProposerBoostScore)
+	w.uint64(s.committeeWeight)
+	w.uint64(f.numActiveValidators)
+	w.root(s.headNode.root)
+
+	w.uint64(uint64(len(nodes)))
+	for _, n := range nodes {
+		var parentRoot [fieldparams.RootLength]byte
+		if n.parent != nil {
+			parentRoot = n.parent.root
+		}
+		w.uint64(uint64(n.slot))
+		w.root(n.root)
+		w.root(parentRoot)
+		w.root(n.payloadHash)
+		w.uint64(uint64(n.justifiedEpoch))
+		w.uint64(uint64(n.unrealizedJustifiedEpoch))
+		w.uint64(uint64(n.finalizedEpoch))
+		w.uint64(uint64(n.unrealizedFinalizedEpoch))
+		w.uint64(n.balance)
+		w.uint64(n.weight)
+		w.bool(n.optimistic)
+		w.uint64(n.timestamp)
+	}
+	w.uint64(uint64(len(slashed)))
+	for _, idx := range slashed {
+		w.uint64(uint64(idx))
+	}
+	w.uint64(uint64(len(f.votes)))
+	for _, v := range f.votes {
+		w.root(v.currentRoot)
+		w.root(v.nextRoot)
+		w.uint64(uint64(v.nextEpoch))
+	}
+	w.uint64s(f.balances)
+	w.uint64s(f.justifiedBalances)
+	return w.buf, nil
+}
+
+// RestoreSnapshot replaces the fork choice store with the one encoded by Snapshot. The decoded store is
+// passed to verify, if not nil, before replacing the current store, which is left untouched on error.
+// The caller of this function MUST hold a lock in forkchoice.
+func (f *ForkChoice) RestoreSnapshot(ctx context.Context, enc []byte, verify forkchoice.SnapshotVerifier) error {
+	restored, err := decodeSnapshot(ctx, enc)
+	if err != nil {
+		return errors.Wrap(err, "could not decode fork choice snapshot")
+	}
+	if verify != nil {
+		dump, err := restored.ForkChoiceDump(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not dump restored fork choice store")
+		}
+		if err := verify(ctx, dump); err != nil {
+			return err
+		}
+	}
+	f.store = restored.store
+	f.votes = restored.votes
+	f.balances = restored.balances
+	f.justifiedBalances = restored.justifiedBalances
+	f.numActiveValidators = restored.numActiveValidators
+	nodeCount.Set(float64(len(f.store.nodeByRoot)))
+	return nil
+}
+
+// decodeSnapshot rebuilds a fork choice store from its encoding.
+func decodeSnapshot(ctx context.Context, enc []byte) (*ForkChoice, error) {
+	if len(enc) == 0 {
+		return nil, errSnapshotTooShort
+	}
+	if enc[0] != snapshotVersion {
+		return nil, errors.Wrapf(errUnknownSnapshotVersion, "version %d", enc[0])
+	}
+	r := &snapshotReader{buf: enc[1:]}
+	f := New()
+	s := f.store
+	s.genesisTime = r.uint64()
+	s.originRoot = r.root()
+	for _, cp := range s.snapshotCheckpoints() {
+		*cp = &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+	}
+	s.proposerBoostRoot = r.root()
+	s.previousProposerBoostRoot = r.root()
+	s.previousProposerBoostScore = r.uint64()
+	s.committeeWeight = r.uint64()
+	f.numActiveValidators = r.uint64()
+	headRoot := r.root()
+
+	currentSlot := s.currentSlot()
+	count := r.count(nodeSnapshotSize)
+	for i := 0; i < count; i++ {
+		n := &Node{
+			slot: primitives.Slot(r.uint64()),
+			root: r.root(),
+		}
+		parentRoot := r.root()
+		n.payloadHash = r.root()
+		n.justifiedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedJustifiedEpoch = primitives.Epoch(r.uint64())
+		n.finalizedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedFinalizedEpoch = primitives.Epoch(r.uint64())
+		n.balance = r.uint64()
+		n.weight = r.uint64()
+		n.optimistic = r.bool()
+		n.timestamp = r.uint64()
+		if r.err != nil {
+			return nil, r.err
+		}
+		if _, ok := s.nodeByRoot[n.root]; ok {
+			return nil, errors.Wrapf(errDuplicateNode, "root %#x", n.root)
+		}
+		if i == 0 {
+			s.treeRootNode = n
+			s.highestReceivedNode = n
+		} else {
+			parent, ok := s.nodeByRoot[parentRoot]
+			if !ok {
+				return nil, errors.Wrapf(errInvalidParentRoot, "node %#x with parent %#x", n.root, parentRoot)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		}
+		s.nodeByRoot[n.root] = n
+		s.nodeByPayload[n.payloadHash] = n
+		if n.slot > s.highestReceivedNode.slot {
+			s.highestReceivedNode = n
+		}
+		if n.slot+params.BeaconConfig().SlotsPerEpoch > currentSlot {
+			s.receivedBlocksLastEpoch[n.slot%params.BeaconConfig().SlotsPerEpoch] = n.slot
+		}
+	}
+
+	slashedCount := r.count(8)
+	for i := 0; i < slashedCount; i++ {
+		s.slashedIndices[primitives.ValidatorIndex(r.uint64())] = true
+	}
+	voteCount := r.count(voteSnapshotSize)
+	f.votes = make([]Vote, voteCount)
+	for i := range f.votes {
+		f.votes[i] = Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: primitives.Epoch(r.uint64())}
+	}
+	f.balances = r.uint64s()
+	f.justifiedBalances = r.uint64s()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.buf) != 0 {
+		return nil, errors.Wrapf(errSnapshotTrailingBytes, "%d bytes", len(r.buf))
+	}
+
+	if s.treeRootNode == nil {
+		return nil, errors.Wrap(ErrNilNode, "no node in fork choice snapshot")
+	}
+	head, ok := s.nodeByRoot[headRoot]
+	if !ok {
+		return nil, errors.Wrapf(ErrNilNode, "unknown head root %#x", headRoot)
+	}
+	s.headNode = head
+	if s.proposerBoostRoot != params.BeaconConfig().ZeroHash {
+		if _, ok := s.nodeByRoot[s.proposerBoostRoot]; !ok {
+			return nil, errors.Wrapf(errInvalidProposerBoostRoot, "root %#x", s.proposerBoostRoot)
+		}
+	}
+	if err := s.treeRootNode.updateBestDescendant(ctx, s.justifiedCheckpoint.Epoch, s.finalizedCheckpoint.Epoch, slots.ToEpoch(currentSlot)); err != nil {
+		return nil, errors.Wrap(err, "could not update best descendants")
+	}
+	return f, nil
+}
+
+// snapshotCheckpoints returns the checkpoints of the store in the order they are encoded.
+func (s *Store) snapshotCheckpoints() []**forkchoicetypes.Checkpoint {
+	return []**forkchoicetypes.Checkpoint{
+		&s.justifiedCheckpoint,
+		&s.prevJustifiedCheckpoint,
+		&s.unrealizedJustifiedCheckpoint,
+		&s.unrealizedFinalizedCheckpoint,
+		&s.finalizedCheckpoint,
+	}
+}
+
+type snapshotWriter struct {
+	buf []byte
+}
+
+func (w *snapshotWriter) uint64(v uint64) {
+	w.buf = binary.BigEndian.AppendUint64(w.buf, v)
+}
+
+func (w *snapshotWriter) uint64s(vs []uint64) {
+	w.uint64(uint64(len(vs)))
+	for _, v := range vs {
+		w.uint64(v)
+	}
+}
+
+func (w *snapshotWriter) root(r [fieldparams.RootLength]byte) {
+	w.buf = append(w.buf, r[:]...)
+}
+
+func (w *snapshotWriter) bool(b bool) {
+	if b {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// snapshotReader decodes the values written by snapshotWriter. The first error is kept,
+// after which every read returns a zero value.
+type snapshotReader struct {
+	buf []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = errSnapshotTooShort
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *snapshotReader) root() [fieldparams.RootLength]byte {
+	var root [fieldparams.RootLength]byte
+	copy(root[:], r.next(fieldparams.RootLength))
+	return root
+}
+
+func (r *snapshotReader) bool() bool {
+	b := r.next(1)
+	return b != nil && b[0] == 1
+}
+
+// count reads the length of a list of items of the given size, checking that they fit in what is
+// left of the snapshot before anything gets allocated.
+func (r *snapshotReader) count(itemSize int) int {
+	n := r.uint64()
+	if r.err == nil && n > uint64(len(r.buf)/itemSize) {
+		r.err = errSnapshotTooShort
+	}
+	if r.err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func (r *snapshotReader) uint64s() []uint64 {
+	vs := make([]uint64, r.count(8))
+	for i := range vs {
+		vs[i] = r.uint64()
+	}
+	return vs
+}
@@ -0,0 +1,120 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// snapshotForkChoice builds a store with two branches, votes, a boosted block and an equivocating validator.
+func snapshotForkChoice(t *testing.T) *ForkChoice {
+	ctx := context.Background()
+	f := setup(1, 1)
+	f.justifiedBalances = []uint64{10, 20, 30, 40}
+	f.store.committeeWeight = 25
+	f.numActiveValidators = 4
+	for _, b := range []struct{ slot, root, parent uint64 }{{1, 1, 0}, {2, 2, 1}, {3, 3, 2}, {3, 4, 1}} {
+		parent := params.BeaconConfig().ZeroHash
+		if b.parent != 0 {
+			parent = indexToHash(b.parent)
+		}
+		st, root, err := prepareForkchoiceState(ctx, primitives.Slot(b.slot), indexToHash(b.root), parent, indexToHash(100+b.root), 1, 1)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, root))
+	}
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(2)))
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 1)
+	f.ProcessAttestation(ctx, []uint64{2, 3}, indexToHash(4), 1)
+	f.InsertSlashedIndex(ctx, 3)
+	f.store.proposerBoostRoot = indexToHash(3)
+	f.store.unrealizedJustifiedCheckpoint = &forkchoicetypes.Checkpoint{Epoch: 2, Root: indexToHash(2)}
+	_, err := f.Head(ctx)
+	require.NoError(t, err)
+	return f
+}
+
+func TestForkChoice_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := snapshotForkChoice(t)
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restored := New()
+	require.NoError(t, restored.RestoreSnapshot(ctx, enc, nil))
+	want, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	got, err := restored.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, got)
+	assert.DeepEqual(t, f.votes, restored.votes)
+	assert.DeepEqual(t, f.balances, restored.balances)
+	assert.DeepEqual(t, f.justifiedBalances, restored.justifiedBalances)
+	assert.DeepEqual(t, f.store.slashedIndices, restored.store.slashedIndices)
+	assert.Equal(t, f.store.committeeWeight, restored.store.committeeWeight)
+	assert.Equal(t, f.numActiveValidators, restored.numActiveValidators)
+	assert.Equal(t, f.HighestReceivedBlockSlot(), restored.HighestReceivedBlockSlot())
+	assert.Equal(t, f.FinalizedPayloadBlockHash(), restored.FinalizedPayloadBlockHash())
+
+	// Both stores keep computing the same head once restored.
+	restored.SetBalancesByRooter(f.balancesByRoot)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(3), 2)
+	restored.ProcessAttestation(ctx, []uint64{2}, indexToHash(3), 2)
+	wantHead, err := f.Head(ctx)
+	require.NoError(t, err)
+	gotHead, err := restored.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantHead, gotHead)
+	w1, err := f.Weight(indexToHash(3))
+	require.NoError(t, err)
+	w2, err := restored.Weight(indexToHash(3))
+	require.NoError(t, err)
+	assert.Equal(t, w1, w2)
+
+	// The encoding is deterministic.
+	enc2, err := restored.Snapshot()
+	require.NoError(t, err)
+	enc1, err := f.Snapshot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, enc1, enc2)
+}
+
+func TestForkChoice_RestoreSnapshot_Verify(t *testing.T) {
+	ctx := context.Background()
+	enc, err := snapshotForkChoice(t).Snapshot()
+	require.NoError(t, err)
+
+	f := setup(0, 0)
+	errRejected := errors.New("rejected")
+	var nodes int
+	err = f.RestoreSnapshot(ctx, enc, func(_ context.Context, dump *v1.ForkChoiceDump) error {
+		nodes = len(dump.ForkChoiceNodes)
+		return errRejected
+	})
+	require.ErrorIs(t, err, errRejected)
+	assert.Equal(t, 5, nodes)
+	// The store is left untouched when the snapshot is rejected.
+	assert.Equal(t, 1, f.NodeCount())
+}
+
+func TestForkChoice_RestoreSnapshot_Invalid(t *testing.T) {
+	ctx := context.Background()
+	enc, err := snapshotForkChoice(t).Snapshot()
+	require.NoError(t, err)
+
+	f := New()
+	_, err = f.Snapshot()
+	require.ErrorIs(t, err, ErrNilNode)
+	require.ErrorIs(t, f.RestoreSnapshot(ctx, nil, nil), errSnapshotTooShort)
+	require.ErrorIs(t, f.RestoreSnapshot(ctx, enc[:len(enc)-1], nil), errSnapshotTooShort)
+	require.ErrorIs(t, f.RestoreSnapshot(ctx, append(enc, 0), nil), errSnapshotTrailingBytes)
+	wrongVersion := append([]byte{snapshotVersion + 1}, enc[1:]...)
+	require.ErrorIs(t, f.RestoreSnapshot(ctx, wrongVersion, nil), errUnknownSnapshotVersion)
+	assert.Equal(t, 0, f.NodeCount())
+}
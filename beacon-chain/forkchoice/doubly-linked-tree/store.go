@@ -13,6 +13,23 @@ import (
 	"go.opencensus.io/trace"
 )
 
+// now returns the current unix time in seconds from the clock of the store.
+func (s *Store) now() uint64 {
+	if s.clock != nil {
+		return s.clock()
+	}
+	return uint64(time.Now().Unix())
+}
+
+// currentSlot returns the current slot from the clock of the store.
+func (s *Store) currentSlot() primitives.Slot {
+	now := s.now()
+	if now < s.genesisTime {
+		return 0
+	}
+	return primitives.Slot((now - s.genesisTime) / params.BeaconConfig().SecondsPerSlot)
+}
+
 // head starts from justified root and then follows the best descendant links
 // to find the best block for head.
 func (s *Store) head(ctx context.Context) ([32]byte, error) {
@@ -86,7 +103,7 @@ func (s *Store) insert(ctx context.Context,
 		unrealizedFinalizedEpoch: finalizedEpoch,
 		optimistic:               true,
 		payloadHash:              payloadHash,
-		timestamp:                uint64(time.Now().Unix()),
+		timestamp:                s.now(),
 	}
 
 	s.nodeByPayload[payloadHash] = n
@@ -102,12 +119,12 @@ func (s *Store) insert(ctx context.Context,
 	} else {
 		parent.children = append(parent.children, n)
 		// Apply proposer boost
-		timeNow := uint64(time.Now().Unix())
+		timeNow := s.now()
 		if timeNow < s.genesisTime {
 			return n, nil
 		}
 		secondsIntoSlot := (timeNow - s.genesisTime) % params.BeaconConfig().SecondsPerSlot
-		currentSlot := slots.CurrentSlot(s.genesisTime)
+		currentSlot := s.currentSlot()
 		boostThreshold := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
 		if currentSlot == slot && secondsIntoSlot < boostThreshold {
 			s.proposerBoostRoot = root
@@ -125,7 +142,7 @@ func (s *Store) insert(ctx context.Context,
 	nodeCount.Set(float64(len(s.nodeByRoot)))
 
 	// Only update received block slot if it's within epoch from current time.
-	if slot+params.BeaconConfig().SlotsPerEpoch > slots.CurrentSlot(s.genesisTime) {
+	if slot+params.BeaconConfig().SlotsPerEpoch > s.currentSlot() {
 		s.receivedBlocksLastEpoch[slot%params.BeaconConfig().SlotsPerEpoch] = slot
 	}
 	// Update highest slot tracking.
@@ -229,7 +246,7 @@ func (f *ForkChoice) HighestReceivedBlockSlot() primitives.Slot {
 // ReceivedBlocksLastEpoch returns the number of blocks received in the last epoch
 func (f *ForkChoice) ReceivedBlocksLastEpoch() (uint64, error) {
 	count := uint64(0)
-	lowerBound := slots.CurrentSlot(f.store.genesisTime)
+	lowerBound := f.store.currentSlot()
 	var err error
 	if lowerBound > fieldparams.SlotsPerEpoch {
 		lowerBound, err = lowerBound.SafeSub(fieldparams.SlotsPerEpoch)
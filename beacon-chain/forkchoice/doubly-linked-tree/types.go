@@ -41,6 +41,7 @@ type Store struct {
 	highestReceivedNode           *Node                                      // The highest slot node.
 	receivedBlocksLastEpoch       [fieldparams.SlotsPerEpoch]primitives.Slot // Using `highestReceivedSlot`. The slot of blocks received in the last epoch.
 	allTipsAreInvalid             bool                                       // tracks if all tips are not viable for head
+	clock                         func() uint64                              // returns the current unix time in seconds, the wall clock is used when nil.
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
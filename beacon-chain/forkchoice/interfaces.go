@@ -14,6 +14,10 @@ import (
 // with the given block root
 type BalancesByRooter func(context.Context, [32]byte) ([]uint64, error)
 
+// SnapshotVerifier is a handler to check the fork choice store decoded from a
+// snapshot, before it replaces the current store
+type SnapshotVerifier func(context.Context, *v1.ForkChoiceDump) error
+
 // ForkChoicer represents the full fork choice interface composed of all the sub-interfaces.
 type ForkChoicer interface {
 	Lock()
@@ -25,6 +29,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Persister            // to save and restore fork choice across restarts.
 }
 
 // HeadRetriever retrieves head root and optimistic info of the current chain.
@@ -82,3 +87,9 @@ type Setter interface {
 	SetBalancesByRooter(BalancesByRooter)
 	InsertSlashedIndex(context.Context, primitives.ValidatorIndex)
 }
+
+// Persister encodes the fork choice store, and restores it from an encoding.
+type Persister interface {
+	Snapshot() ([]byte, error)
+	RestoreSnapshot(context.Context, []byte, SnapshotVerifier) error
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
 	interopcoldstart "github.com/prysmaticlabs/prysm/v4/beacon-chain/deterministic-genesis"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
@@ -256,6 +257,16 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Backfill Service")
+	if err := beacon.registerBackfillService(bfs, beacon.initialSyncComplete); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Pruner Service")
+	if err := beacon.registerPrunerService(bfs); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return nil, err
@@ -642,6 +653,7 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithDepositCache(b.depositCache),
 		blockchain.WithChainStartFetcher(web3Service),
 		blockchain.WithExecutionEngineCaller(web3Service),
+		blockchain.WithExecutionPayloadReconstructor(web3Service),
 		blockchain.WithAttestationPool(b.attestationPool),
 		blockchain.WithExitPool(b.exitPool),
 		blockchain.WithBailoutPool(b.bailoutPool),
@@ -755,6 +767,34 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService(bfs *backfill.Status, initialSyncComplete chan struct{}) error {
+	bf := backfill.NewService(b.ctx, &backfill.Config{
+		DB:                  b.db,
+		P2P:                 b.fetchP2P(),
+		ClockWaiter:         b.clockWaiter,
+		Status:              bfs,
+		InitialSyncComplete: initialSyncComplete,
+		BatchSize:           b.cliCtx.Uint64(flags.BackfillBatchSize.Name),
+		BlocksPerSecond:     b.cliCtx.Uint64(flags.BackfillBlocksPerSecond.Name),
+	})
+	return b.services.RegisterService(bf)
+}
+
+func (b *BeaconNode) registerPrunerService(bfs *backfill.Status) error {
+	retention := primitives.Epoch(b.cliCtx.Uint64(flags.HistoryRetentionEpochs.Name))
+	// Blocks must be kept for as long as peers may request them.
+	if minRetention := params.BeaconConfig().MinEpochsForBlockRequests; retention != 0 && retention < minRetention {
+		return fmt.Errorf("%s must be 0 or at least %d epochs, got %d", flags.HistoryRetentionEpochs.Name, minRetention, retention)
+	}
+	p := pruner.NewService(b.ctx, &pruner.Config{
+		DB:              b.db,
+		ClockWaiter:     b.clockWaiter,
+		BackfillStatus:  bfs,
+		RetentionEpochs: retention,
+	})
+	return b.services.RegisterService(p)
+}
+
 func (b *BeaconNode) registerSlasherService() error {
 	if !features.Get().EnableSlasher {
 		return nil
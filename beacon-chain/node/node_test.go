@@ -173,6 +173,23 @@ func TestMonitor_RegisteredCorrectly(t *testing.T) {
 	require.Equal(t, false, mService.TrackedValidators[100])
 }
 
+func TestRegisterPrunerService_RetentionEpochs(t *testing.T) {
+	minRetention := uint64(params.BeaconConfig().MinEpochsForBlockRequests)
+	for _, retention := range []uint64{0, minRetention - 1, minRetention} {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.Uint64(flags.HistoryRetentionEpochs.Name, retention, "")
+		cliCtx := cli.NewContext(&app, set, nil)
+		n := &BeaconNode{ctx: context.Background(), cliCtx: cliCtx, services: runtime.NewServiceRegistry()}
+		err := n.registerPrunerService(nil)
+		if retention != 0 && retention < minRetention {
+			require.ErrorContains(t, "history-retention-epochs must be 0 or at least", err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
 func Test_hasNetworkFlag(t *testing.T) {
 	tests := []struct {
 		name         string
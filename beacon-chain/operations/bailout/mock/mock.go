@@ -27,11 +27,23 @@ func (m *PoolMock) BailoutsForInclusion(_ state.ReadOnlyBeaconState, _ primitive
 	return m.Exits, nil
 }
 
+// PeekBailoutsForInclusion --
+func (m *PoolMock) PeekBailoutsForInclusion(_ state.ReadOnlyBeaconState, _ int) ([]*eth.BailOut, error) {
+	return m.Exits, nil
+}
+
 // InsertVoluntaryExit --
 func (m *PoolMock) UpdateBailOuts(_ state.ReadOnlyBeaconState) {
 	m.Exits = append(m.Exits, &eth.BailOut{})
 }
 
+// Rebuild --
+func (m *PoolMock) Rebuild(_ state.ReadOnlyBeaconState) error {
+	m.Exits = []*eth.BailOut{}
+	m.initialized = true
+	return nil
+}
+
 // MarkIncluded --
 func (*PoolMock) MarkIncluded(_ *eth.SignedVoluntaryExit) {
 	panic("implement me")
@@ -21,7 +21,9 @@ type PoolManager interface {
 	IsInitialized() bool
 	PendingBailOuts() ([]*ethpb.BailOut, error)
 	BailoutsForInclusion(state state.ReadOnlyBeaconState, voluntaryLen int) ([]*ethpb.BailOut, error)
+	PeekBailoutsForInclusion(state state.ReadOnlyBeaconState, voluntaryLen int) ([]*ethpb.BailOut, error)
 	UpdateBailOuts(state state.ReadOnlyBeaconState)
+	Rebuild(state state.ReadOnlyBeaconState) error
 	MarkIncluded(exit *ethpb.BailOut)
 }
 
@@ -108,35 +110,51 @@ func (p *Pool) PendingBailOuts() ([]*ethpb.BailOut, error) {
 }
 
 // BailoutsForInclusion returns objects that are ready for inclusion at the given slot. This method will not
-// return more than the block enforced MaxVoluntaryExits.
+// return more than the block enforced MaxVoluntaryExits. Invalid bail outs found along the way are removed
+// from the pool.
 func (p *Pool) BailoutsForInclusion(state state.ReadOnlyBeaconState, voluntaryLen int) ([]*ethpb.BailOut, error) {
+	result, invalid, err := p.selectForInclusion(state, voluntaryLen)
+	if err != nil {
+		return nil, err
+	}
+	for _, exit := range invalid {
+		p.MarkIncluded(exit)
+	}
+	return result, nil
+}
+
+// PeekBailoutsForInclusion returns the same objects as BailoutsForInclusion without modifying the pool,
+// so that it can be used to inspect the pool.
+func (p *Pool) PeekBailoutsForInclusion(state state.ReadOnlyBeaconState, voluntaryLen int) ([]*ethpb.BailOut, error) {
+	result, _, err := p.selectForInclusion(state, voluntaryLen)
+	return result, err
+}
+
+// selectForInclusion returns the bail outs that are ready for inclusion, up to the block enforced
+// MaxVoluntaryExits, along with the invalid bail outs skipped to select them.
+func (p *Pool) selectForInclusion(state state.ReadOnlyBeaconState, voluntaryLen int) ([]*ethpb.BailOut, []*ethpb.BailOut, error) {
 	p.lock.RLock()
+	defer p.lock.RUnlock()
 	length := int(math.Min(float64(params.BeaconConfig().MaxVoluntaryExits-uint64(voluntaryLen)), float64(p.pending.Len())))
 	result := make([]*ethpb.BailOut, 0, length)
+	var invalid []*ethpb.BailOut
 	node := p.pending.First()
 	for node != nil && len(result) < length {
 		exit, err := node.Value()
 		if err != nil {
-			p.lock.RUnlock()
-			return nil, err
+			return nil, nil, err
 		}
-
 		if err = blocks.VerifyBailOut(state, exit.ValidatorIndex); err != nil {
-			p.lock.RUnlock()
-			// MarkIncluded removes the invalid exit from the pool
-			p.MarkIncluded(exit)
-			p.lock.RLock()
+			invalid = append(invalid, exit)
 		} else {
 			result = append(result, exit)
 		}
 		node, err = node.Next()
 		if err != nil {
-			p.lock.RUnlock()
-			return nil, err
+			return nil, nil, err
 		}
 	}
-	p.lock.RUnlock()
-	return result, nil
+	return result, invalid, nil
 }
 
 // UpdateBailOuts updates the initialized pool with the current state.
@@ -161,6 +179,51 @@ func (p *Pool) UpdateBailOuts(state state.ReadOnlyBeaconState) {
 	}
 }
 
+// Rebuild replaces the content of the pool with the bail outs that are valid against the given
+// state, ordered by descending bail out score and then by ascending validator index. It is meant
+// to be called with the head state whenever the head changes, so that the pool follows reorgs
+// and never hands out entries that are stale on the canonical chain.
+func (p *Pool) Rebuild(state state.ReadOnlyBeaconState) error {
+	bailoutScores, err := state.BailOutScores()
+	if err != nil {
+		return err
+	}
+
+	type candidate struct {
+		score uint64
+		index types.ValidatorIndex
+	}
+	var candidates []candidate
+	for i, s := range bailoutScores {
+		if s < params.BeaconConfig().BailOutScoreThreshold || s == math.MaxUint64 {
+			continue
+		}
+		idx := types.ValidatorIndex(i)
+		if err := blocks.VerifyBailOut(state, idx); err != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{score: s, index: idx})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].index < candidates[j].index
+	})
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.pending = doublylinkedlist.List[*ethpb.BailOut]{}
+	p.m = make(map[types.ValidatorIndex]*doublylinkedlist.Node[*ethpb.BailOut], len(candidates))
+	for _, c := range candidates {
+		p.pending.Append(doublylinkedlist.NewNode(&ethpb.BailOut{ValidatorIndex: c.index}))
+		p.m[c.index] = p.pending.Last()
+	}
+	p.initialized = true
+	return nil
+}
+
 // insertVoluntaryExit into the pool.
 func (p *Pool) insertBailOut(exit *ethpb.BailOut) {
 	p.lock.Lock()
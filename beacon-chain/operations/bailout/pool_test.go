@@ -192,9 +192,37 @@ func TestBailoutsForInclusion(t *testing.T) {
 		exits, err := pool.BailoutsForInclusion(st, 15)
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(exits))
+		assert.Equal(t, 0, pool.pending.Len())
 	})
 }
 
+func TestPeekBailoutsForInclusion(t *testing.T) {
+	spb := &ethpb.BeaconStateCapella{
+		Slot: types.Slot(uint64(params.BeaconConfig().ShardCommitteePeriod) * uint64(params.BeaconConfig().SlotsPerEpoch)),
+		Validators: []*ethpb.Validator{
+			{ExitEpoch: params.BeaconConfig().FarFutureEpoch},
+			{ExitEpoch: 0},
+			{ExitEpoch: params.BeaconConfig().FarFutureEpoch},
+		},
+	}
+	st, err := state_native.InitializeFromProtoCapella(spb)
+	require.NoError(t, err)
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	require.NoError(t, st.SetBailOutScores([]uint64{threshold, threshold, threshold}))
+
+	pool := NewPool()
+	for i := types.ValidatorIndex(0); i < 3; i++ {
+		pool.insertBailOut(&ethpb.BailOut{ValidatorIndex: i})
+	}
+	exits, err := pool.PeekBailoutsForInclusion(st, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(exits))
+	assert.Equal(t, types.ValidatorIndex(0), exits[0].ValidatorIndex)
+	assert.Equal(t, types.ValidatorIndex(2), exits[1].ValidatorIndex)
+	// The invalid bail out is left in the pool.
+	assert.Equal(t, 3, pool.pending.Len())
+}
+
 func TestInsertBailOut(t *testing.T) {
 	t.Run("empty pool", func(t *testing.T) {
 		pool := NewPool()
@@ -419,3 +447,72 @@ func TestMarkIncluded(t *testing.T) {
 		assert.NotNil(t, pool.m[1])
 	})
 }
+
+func TestRebuild(t *testing.T) {
+	spb := &ethpb.BeaconStateCapella{
+		Fork: &ethpb.Fork{
+			CurrentVersion:  params.BeaconConfig().GenesisForkVersion,
+			PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+		},
+	}
+	spb.Slot = types.Slot(uint64(params.BeaconConfig().ShardCommitteePeriod) * uint64(params.BeaconConfig().SlotsPerEpoch))
+	numValidators := 6
+	validators := make([]*ethpb.Validator, numValidators)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	// Validator 4 has already exited on this branch.
+	validators[4].ExitEpoch = 0
+	spb.Validators = validators
+	st, err := state_native.InitializeFromProtoCapella(spb)
+	require.NoError(t, err)
+
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	scores := []uint64{
+		threshold - 1,
+		threshold + 5,
+		threshold + 10,
+		threshold + 5,
+		threshold + 20,
+		math.MaxUint64,
+	}
+	require.NoError(t, st.SetBailOutScores(scores))
+
+	pool := NewPool()
+	// A stale entry from another branch must not survive the rebuild.
+	pool.insertBailOut(&ethpb.BailOut{ValidatorIndex: 0})
+	require.NoError(t, pool.Rebuild(st))
+	assert.Equal(t, true, pool.IsInitialized())
+	pending, err := pool.PendingBailOuts()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(pending))
+	assert.Equal(t, types.ValidatorIndex(2), pending[0].ValidatorIndex)
+	assert.Equal(t, types.ValidatorIndex(1), pending[1].ValidatorIndex)
+	assert.Equal(t, types.ValidatorIndex(3), pending[2].ValidatorIndex)
+
+	// Switching to a branch where validator 2 bailed out and validator 0 crossed the threshold.
+	reorged := st.Copy()
+	v, err := reorged.ValidatorAtIndex(2)
+	require.NoError(t, err)
+	v.ExitEpoch = 1
+	require.NoError(t, reorged.UpdateValidatorAtIndex(2, v))
+	reorgedScores := append([]uint64{}, scores...)
+	reorgedScores[0] = threshold + 30
+	require.NoError(t, reorged.SetBailOutScores(reorgedScores))
+	require.NoError(t, pool.Rebuild(reorged))
+	pending, err = pool.PendingBailOuts()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(pending))
+	assert.Equal(t, types.ValidatorIndex(0), pending[0].ValidatorIndex)
+	assert.Equal(t, types.ValidatorIndex(1), pending[1].ValidatorIndex)
+	assert.Equal(t, types.ValidatorIndex(3), pending[2].ValidatorIndex)
+
+	// Switching back restores the original candidate set.
+	require.NoError(t, pool.Rebuild(st))
+	pending, err = pool.PendingBailOuts()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(pending))
+	assert.Equal(t, types.ValidatorIndex(2), pending[0].ValidatorIndex)
+}
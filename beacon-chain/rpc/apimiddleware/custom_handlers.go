@@ -420,6 +420,10 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				data = &EventFinalizedCheckpointJson{}
 			case events.ChainReorgTopic:
 				data = &EventChainReorgJson{}
+			case events.BailOutTopic:
+				data = &EventBailOutJson{}
+			case events.TokenomicsTopic:
+				data = &EventTokenomicsJson{}
 			case events.SyncCommitteeContributionTopic:
 				data = &SignedContributionAndProofJson{}
 			case events.BLSToExecutionChangeTopic:
@@ -1184,6 +1184,24 @@ type EventChainReorgJson struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type EventBailOutJson struct {
+	ValidatorIndex      string `json:"validator_index"`
+	Pubkey              string `json:"pubkey" hex:"true"`
+	Slot                string `json:"slot"`
+	Block               string `json:"block" hex:"true"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type EventTokenomicsJson struct {
+	Epoch                  string `json:"epoch"`
+	Block                  string `json:"block" hex:"true"`
+	RewardAdjustmentFactor string `json:"reward_adjustment_factor"`
+	PreviousEpochReserve   string `json:"previous_epoch_reserve"`
+	CurrentEpochReserve    string `json:"current_epoch_reserve"`
+	EpochIssuance          string `json:"epoch_issuance"`
+	EpochFeedbackBoost     string `json:"epoch_feedback_boost"`
+}
+
 type EventPayloadAttributeStreamV1Json struct {
 	Version string `json:"version"`
 	Data    *EventPayloadAttributeV1Json
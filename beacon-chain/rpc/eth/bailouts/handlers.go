@@ -0,0 +1,237 @@
+package bailouts
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// GetBailOutScores returns the bailout scores of the requested validators in the requested state.
+// Validators are requested by index or hex encoded public key with the `id` query parameter, which
+// may be repeated or hold a comma separated list. All validators are returned if no id is given,
+// and well-formed yet unknown ids are ignored, as in the standard validators endpoint.
+//
+// Along with the score, each validator comes with BailOutScoreThreshold, the score recovered on
+// a correct target vote and the number of consecutive missed target votes before the validator
+// can be forcibly exited by a bail out.
+func (s *Server) GetBailOutScores(w http.ResponseWriter, r *http.Request) {
+	stateId := mux.Vars(r)["state_id"]
+	if stateId == "" {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "state_id is required in URL params",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	var rawIds []string
+	for _, id := range r.URL.Query()["id"] {
+		rawIds = append(rawIds, strings.Split(id, ",")...)
+	}
+	st, err := s.Stater.State(r.Context(), []byte(stateId))
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+	indices, err := validatorIndices(st, rawIds)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not decode validator ids", http.StatusBadRequest))
+		return
+	}
+	bailOutScores, err := st.BailOutScores()
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get bailout scores", http.StatusInternalServerError))
+		return
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	root, err := helpers.BlockRootAtSlot(st, st.Slot()-1)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get block root", http.StatusInternalServerError))
+		return
+	}
+	var blockRoot = [32]byte(root)
+	isFinalized := s.FinalizationFetcher.IsFinalized(r.Context(), blockRoot)
+
+	threshold := strconv.FormatUint(params.BeaconConfig().BailOutScoreThreshold, 10)
+	recovery := strconv.FormatUint(helpers.BailOutRecoveryScore(st.NumValidators()), 10)
+	data := make([]*BailOutScore, len(indices))
+	for i, idx := range indices {
+		if uint64(idx) >= uint64(len(bailOutScores)) {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("no bailout score for validator %d", idx),
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		pubkey := st.PubkeyAtIndex(idx)
+		score := bailOutScores[idx]
+		data[i] = &BailOutScore{
+			Index:                          strconv.FormatUint(uint64(idx), 10),
+			Pubkey:                         hexutil.Encode(pubkey[:]),
+			BailOutScore:                   strconv.FormatUint(score, 10),
+			BailOutScoreThreshold:          threshold,
+			RecoveryScore:                  recovery,
+			MissedTargetVotesBeforeBailOut: strconv.FormatUint(helpers.MissedTargetVotesBeforeBailOut(score), 10),
+		}
+	}
+
+	network.WriteJson(w, &GetBailOutScoresResponse{
+		Data:                data,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	})
+}
+
+// GetPoolBailOuts returns the bail outs pending in the node's pool, in the order in which they are
+// considered for inclusion, along with the bailout scores of their validators in the head state.
+func (s *Server) GetPoolBailOuts(w http.ResponseWriter, r *http.Request) {
+	pending, err := s.BailoutPool.PendingBailOuts()
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get pending bail outs", http.StatusInternalServerError))
+		return
+	}
+	headState, err := s.HeadFetcher.HeadStateReadOnly(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get head state", http.StatusInternalServerError))
+		return
+	}
+	data, err := pendingBailOuts(headState, pending)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get bailout scores", http.StatusInternalServerError))
+		return
+	}
+	network.WriteJson(w, &GetPoolBailOutsResponse{Data: data})
+}
+
+// GetBailOutsForInclusion previews the bail outs the proposer of the next slot would include in its block.
+// Bail outs share the MaxVoluntaryExits limit with the voluntary exits of the block, which take precedence,
+// so the voluntary exits currently in the pool are selected first, as during block production.
+// Bail outs which are no longer valid are skipped, but the pool is left untouched.
+func (s *Server) GetBailOutsForInclusion(w http.ResponseWriter, r *http.Request) {
+	headState, err := s.HeadFetcher.HeadState(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get head state", http.StatusInternalServerError))
+		return
+	}
+	slot := s.TimeFetcher.CurrentSlot() + 1
+	st, err := transition.ProcessSlotsIfPossible(r.Context(), headState, slot)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not process slots", http.StatusInternalServerError))
+		return
+	}
+	if st.Version() == version.Phase0 {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "bail outs are not supported before Altair",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	exits, err := s.ExitPool.ExitsForInclusion(st, slot)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get voluntary exits for inclusion", http.StatusInternalServerError))
+		return
+	}
+	included, err := s.BailoutPool.PeekBailoutsForInclusion(st, len(exits))
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get bail outs for inclusion", http.StatusInternalServerError))
+		return
+	}
+	data, err := pendingBailOuts(st, included)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get bailout scores", http.StatusInternalServerError))
+		return
+	}
+	network.WriteJson(w, &GetBailOutsForInclusionResponse{
+		Slot:              strconv.FormatUint(uint64(slot), 10),
+		VoluntaryExits:    strconv.Itoa(len(exits)),
+		MaxVoluntaryExits: strconv.FormatUint(params.BeaconConfig().MaxVoluntaryExits, 10),
+		Data:              data,
+	})
+}
+
+// pendingBailOuts returns the given bail outs along with the public keys and bailout scores of their validators in st.
+func pendingBailOuts(st state.ReadOnlyBeaconState, bailOuts []*ethpb.BailOut) ([]*PendingBailOut, error) {
+	bailOutScores, err := st.BailOutScores()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*PendingBailOut, len(bailOuts))
+	for i, b := range bailOuts {
+		if uint64(b.ValidatorIndex) >= uint64(len(bailOutScores)) {
+			return nil, fmt.Errorf("no bailout score for validator %d", b.ValidatorIndex)
+		}
+		pubkey := st.PubkeyAtIndex(b.ValidatorIndex)
+		data[i] = &PendingBailOut{
+			ValidatorIndex: strconv.FormatUint(uint64(b.ValidatorIndex), 10),
+			Pubkey:         hexutil.Encode(pubkey[:]),
+			BailOutScore:   strconv.FormatUint(bailOutScores[b.ValidatorIndex], 10),
+		}
+	}
+	return data, nil
+}
+
+// validatorIndices resolves validator indices and hex encoded public keys to indices in the given state.
+// Well-formed ids which do not belong to any validator of the state are skipped.
+func validatorIndices(st state.ReadOnlyBeaconState, rawIds []string) ([]primitives.ValidatorIndex, error) {
+	numValidators := uint64(st.NumValidators())
+	if len(rawIds) == 0 {
+		indices := make([]primitives.ValidatorIndex, numValidators)
+		for i := range indices {
+			indices[i] = primitives.ValidatorIndex(i)
+		}
+		return indices, nil
+	}
+	indices := make([]primitives.ValidatorIndex, 0, len(rawIds))
+	for _, id := range rawIds {
+		if strings.HasPrefix(id, "0x") {
+			pubkey, err := hexutil.Decode(id)
+			if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+				return nil, fmt.Errorf("%s is not a validator index or pubkey", id)
+			}
+			idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+			if !ok {
+				// Ignore well-formed yet unknown public keys.
+				continue
+			}
+			indices = append(indices, idx)
+			continue
+		}
+		index, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a validator index or pubkey", id)
+		}
+		if index >= numValidators {
+			// Ignore well-formed yet unknown indexes.
+			continue
+		}
+		indices = append(indices, primitives.ValidatorIndex(index))
+	}
+	return indices, nil
+}
+
+func handleWrapError(err error, message string, code int) *network.DefaultErrorJson {
+	return &network.DefaultErrorJson{
+		Message: errors.Wrapf(err, message).Error(),
+		Code:    code,
+	}
+}
@@ -0,0 +1,212 @@
+package bailouts
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/bailout"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestGetBailOutScores(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 4)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	bias := params.BeaconConfig().BailOutScoreBias
+	scores := []uint64{0, threshold - 2*bias, threshold, threshold + bias}
+	require.NoError(t, st.SetBailOutScores(scores))
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+	pubkey1 := st.PubkeyAtIndex(1)
+
+	getScores := func(t *testing.T, query string) *GetBailOutScoresResponse {
+		request := httptest.NewRequest("GET", "/over/v1/beacon/states/{state_id}/bailout_scores"+query, nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBailOutScores(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetBailOutScoresResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	t.Run("all validators", func(t *testing.T) {
+		resp := getScores(t, "")
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		require.Equal(t, 4, len(resp.Data))
+		for i, score := range resp.Data {
+			assert.Equal(t, strconv.Itoa(i), score.Index)
+			assert.Equal(t, strconv.FormatUint(scores[i], 10), score.BailOutScore)
+			assert.Equal(t, strconv.FormatUint(threshold, 10), score.BailOutScoreThreshold)
+			assert.Equal(t, strconv.FormatUint(helpers.BailOutRecoveryScore(4), 10), score.RecoveryScore)
+		}
+		assert.Equal(t, strconv.FormatUint(helpers.MissedTargetVotesBeforeBailOut(0), 10), resp.Data[0].MissedTargetVotesBeforeBailOut)
+		assert.Equal(t, "2", resp.Data[1].MissedTargetVotesBeforeBailOut)
+		assert.Equal(t, "0", resp.Data[2].MissedTargetVotesBeforeBailOut)
+		assert.Equal(t, "0", resp.Data[3].MissedTargetVotesBeforeBailOut)
+	})
+	t.Run("by index and pubkey", func(t *testing.T) {
+		resp := getScores(t, "?id=3&id="+hexutil.Encode(pubkey1[:])+",2")
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, "3", resp.Data[0].Index)
+		assert.Equal(t, "1", resp.Data[1].Index)
+		assert.Equal(t, hexutil.Encode(pubkey1[:]), resp.Data[1].Pubkey)
+		assert.Equal(t, "2", resp.Data[2].Index)
+	})
+	t.Run("unknown ids are ignored", func(t *testing.T) {
+		unknown := make([]byte, 48)
+		resp := getScores(t, "?id=100&id="+hexutil.Encode(unknown)+"&id=0")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].Index)
+	})
+}
+
+func TestGetBailOutScores_BadRequest(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 4)
+	mockChainService := &mock.ChainService{}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+
+	testCases := []struct {
+		name         string
+		query        string
+		urlParams    map[string]string
+		errorMessage string
+	}{
+		{
+			name:         "no state_id",
+			urlParams:    map[string]string{},
+			errorMessage: "state_id is required in URL params",
+		},
+		{
+			name:         "invalid id",
+			query:        "?id=foo",
+			urlParams:    map[string]string{"state_id": "head"},
+			errorMessage: "foo is not a validator index or pubkey",
+		},
+		{
+			name:         "invalid pubkey",
+			query:        "?id=0x1234",
+			urlParams:    map[string]string{"state_id": "head"},
+			errorMessage: "0x1234 is not a validator index or pubkey",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/over/v1/beacon/states/{state_id}/bailout_scores"+testCase.query, nil)
+			request = mux.SetURLVars(request, testCase.urlParams)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetBailOutScores(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, testCase.errorMessage, e.Message)
+		})
+	}
+}
+
+func TestGetPoolBailOuts(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 8)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	scores := []uint64{0, threshold + 2, 0, threshold, 0, threshold + 1, 0, 0}
+	require.NoError(t, st.SetBailOutScores(scores))
+	pool := bailout.NewPool()
+	pool.Initialize(st)
+	s := &Server{
+		HeadFetcher: &mock.ChainService{State: st},
+		BailoutPool: pool,
+	}
+
+	request := httptest.NewRequest("GET", "/over/v1/beacon/pool/bail_outs", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetPoolBailOuts(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &GetPoolBailOutsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 3, len(resp.Data))
+	// Pending bail outs come in order of bailout score.
+	for i, idx := range []int{1, 5, 3} {
+		pubkey := st.PubkeyAtIndex(primitives.ValidatorIndex(idx))
+		assert.Equal(t, strconv.Itoa(idx), resp.Data[i].ValidatorIndex)
+		assert.Equal(t, hexutil.Encode(pubkey[:]), resp.Data[i].Pubkey)
+		assert.Equal(t, strconv.FormatUint(scores[idx], 10), resp.Data[i].BailOutScore)
+	}
+}
+
+func TestGetBailOutsForInclusion(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxVoluntaryExits = 2
+	params.OverrideBeaconConfig(cfg)
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 8)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	scores := []uint64{threshold + 3, threshold + 2, 0, threshold, 0, threshold + 1, 0, 0}
+	require.NoError(t, st.SetBailOutScores(scores))
+	pool := bailout.NewPool()
+	pool.Initialize(st)
+
+	// The validator with the highest score has already exited, so that its bail out is no longer valid.
+	val, err := st.ValidatorAtIndex(0)
+	require.NoError(t, err)
+	val.ExitEpoch = 1
+	require.NoError(t, st.UpdateValidatorAtIndex(0, val))
+
+	slot := params.BeaconConfig().SlotsPerEpoch
+	s := &Server{
+		HeadFetcher: &mock.ChainService{State: st},
+		TimeFetcher: &mock.ChainService{Slot: &slot},
+		BailoutPool: pool,
+		ExitPool:    voluntaryexits.NewPool(),
+	}
+
+	request := httptest.NewRequest("GET", "/over/v1/beacon/pool/bail_outs/inclusion", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetBailOutsForInclusion(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &GetBailOutsForInclusionResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, strconv.FormatUint(uint64(slot+1), 10), resp.Slot)
+	assert.Equal(t, "0", resp.VoluntaryExits)
+	assert.Equal(t, "2", resp.MaxVoluntaryExits)
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, "1", resp.Data[0].ValidatorIndex)
+	assert.Equal(t, "5", resp.Data[1].ValidatorIndex)
+
+	// The pool is not modified, the invalid bail out is still pending.
+	pending, err := pool.PendingBailOuts()
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(pending))
+}
@@ -0,0 +1,18 @@
+package bailouts
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/bailout"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+)
+
+type Server struct {
+	FinalizationFetcher   blockchain.FinalizationFetcher
+	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+	Stater                lookup.Stater
+	HeadFetcher           blockchain.HeadFetcher
+	TimeFetcher           blockchain.TimeFetcher
+	BailoutPool           bailout.PoolManager
+	ExitPool              voluntaryexits.PoolManager
+}
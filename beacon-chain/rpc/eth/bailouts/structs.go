@@ -0,0 +1,33 @@
+package bailouts
+
+type GetBailOutScoresResponse struct {
+	Data                []*BailOutScore `json:"data"`
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
+}
+
+type BailOutScore struct {
+	Index                          string `json:"index"`
+	Pubkey                         string `json:"pubkey"`
+	BailOutScore                   string `json:"bail_out_score"`
+	BailOutScoreThreshold          string `json:"bail_out_score_threshold"`
+	RecoveryScore                  string `json:"recovery_score"`
+	MissedTargetVotesBeforeBailOut string `json:"missed_target_votes_before_bail_out"`
+}
+
+type GetPoolBailOutsResponse struct {
+	Data []*PendingBailOut `json:"data"`
+}
+
+type GetBailOutsForInclusionResponse struct {
+	Slot              string            `json:"slot"`
+	VoluntaryExits    string            `json:"voluntary_exits"`
+	MaxVoluntaryExits string            `json:"max_voluntary_exits"`
+	Data              []*PendingBailOut `json:"data"`
+}
+
+type PendingBailOut struct {
+	ValidatorIndex string `json:"validator_index"`
+	Pubkey         string `json:"pubkey"`
+	BailOutScore   string `json:"bail_out_score"`
+}
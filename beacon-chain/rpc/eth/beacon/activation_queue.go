@@ -0,0 +1,177 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	corehelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	mathutil "github.com/prysmaticlabs/prysm/v4/math"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+const (
+	// activationStatusPending is the status of a validator which is not eligible for activation yet.
+	activationStatusPending = "pending"
+	// activationStatusQueued is the status of a validator waiting in the activation queue.
+	activationStatusQueued = "queued"
+	// activationStatusScheduled is the status of a validator dequeued with a future activation epoch.
+	activationStatusScheduled = "scheduled"
+	// activationStatusActivated is the status of a validator which has been activated.
+	activationStatusActivated = "activated"
+)
+
+// activationQueue is an index of the activation queue of a head state.
+type activationQueue struct {
+	root           []byte
+	st             state.ReadOnlyBeaconState
+	epoch          primitives.Epoch
+	finalizedEpoch primitives.Epoch
+	churnLimit     uint64
+	// queue holds the validators waiting to be dequeued for activation, in the order of
+	// process_registry_updates: by activation eligibility epoch, then by index.
+	queue     []primitives.ValidatorIndex
+	positions map[primitives.ValidatorIndex]uint64
+}
+
+// ActivationQueueCache keeps the activation queue index of the latest head, so that
+// the validator registry is only scanned once per head instead of once per request.
+type ActivationQueueCache struct {
+	lock  sync.Mutex
+	queue *activationQueue
+}
+
+// NewActivationQueueCache returns an empty activation queue cache.
+func NewActivationQueueCache() *ActivationQueueCache {
+	return &ActivationQueueCache{}
+}
+
+// get returns the activation queue index of the current head, rebuilding it if the head changed.
+func (c *ActivationQueueCache) get(ctx context.Context, headFetcher blockchain.HeadFetcher) (*activationQueue, error) {
+	root, err := headFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head root")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.queue != nil && bytes.Equal(c.queue.root, root) {
+		return c.queue, nil
+	}
+	st, err := headFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	q, err := newActivationQueue(root, st)
+	if err != nil {
+		return nil, err
+	}
+	c.queue = q
+	return q, nil
+}
+
+func newActivationQueue(root []byte, st state.ReadOnlyBeaconState) (*activationQueue, error) {
+	epoch := slots.ToEpoch(st.Slot())
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	var eligibilityEpochs []primitives.Epoch
+	q := &activationQueue{
+		root:           root,
+		st:             st,
+		epoch:          epoch,
+		finalizedEpoch: st.FinalizedCheckpointEpoch(),
+		positions:      make(map[primitives.ValidatorIndex]uint64),
+	}
+	var activeCount, activeBalance uint64
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if corehelpers.IsActiveValidatorUsingTrie(val, epoch) {
+			activeCount++
+			activeBalance += val.EffectiveBalance()
+			return nil
+		}
+		if val.ActivationEligibilityEpoch() != farFutureEpoch && val.ActivationEpoch() == farFutureEpoch {
+			q.queue = append(q.queue, primitives.ValidatorIndex(idx))
+			eligibilityEpochs = append(eligibilityEpochs, val.ActivationEligibilityEpoch())
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not read validators")
+	}
+	sort.Sort(queueByEligibility{indices: q.queue, eligibilityEpochs: eligibilityEpochs})
+	for i, idx := range q.queue {
+		q.positions[idx] = uint64(i)
+	}
+
+	// Total active balance as in helpers.TotalActiveBalance, which is at least one increment.
+	activeBalance = mathutil.Max(params.BeaconConfig().EffectiveBalanceIncrement, activeBalance)
+	churnLimit, err := corehelpers.ValidatorChurnLimit(activeCount, activeBalance, epoch, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get churn limit")
+	}
+	q.churnLimit = churnLimit
+	return q, nil
+}
+
+// expectedActivationEpoch returns the epoch at which the validator at the given queue position is
+// expected to be activated, assuming the churn limit stays the same. A validator is only dequeued
+// once its activation eligibility epoch is finalized, which takes at least two epochs.
+func (q *activationQueue) expectedActivationEpoch(position uint64, eligibilityEpoch primitives.Epoch) primitives.Epoch {
+	dequeueEpoch := q.epoch + primitives.Epoch(position/q.churnLimit)
+	if eligibilityEpoch > q.finalizedEpoch && dequeueEpoch < eligibilityEpoch+2 {
+		dequeueEpoch = eligibilityEpoch + 2
+	}
+	return corehelpers.ActivationExitEpoch(dequeueEpoch)
+}
+
+// estimate returns the activation estimate of the validator with the given index.
+func (q *activationQueue) estimate(idx primitives.ValidatorIndex) (*ValidatorActivationEstimate, error) {
+	val, err := q.st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get validator %d", idx)
+	}
+	pubKey := val.PublicKey()
+	estimate := &ValidatorActivationEstimate{
+		Index:                      uint64(idx),
+		Pubkey:                     hexutil.Encode(pubKey[:]),
+		Status:                     activationStatusPending,
+		ActivationEligibilityEpoch: uint64(val.ActivationEligibilityEpoch()),
+		ActivationEpoch:            uint64(val.ActivationEpoch()),
+	}
+	switch {
+	case val.ActivationEpoch() <= q.epoch:
+		estimate.Status = activationStatusActivated
+	case val.ActivationEpoch() != params.BeaconConfig().FarFutureEpoch:
+		estimate.Status = activationStatusScheduled
+	default:
+		if position, ok := q.positions[idx]; ok {
+			estimate.Status = activationStatusQueued
+			estimate.QueuePosition = position + 1
+			estimate.ActivationEpoch = uint64(q.expectedActivationEpoch(position, val.ActivationEligibilityEpoch()))
+		}
+	}
+	return estimate, nil
+}
+
+type queueByEligibility struct {
+	indices           []primitives.ValidatorIndex
+	eligibilityEpochs []primitives.Epoch
+}
+
+func (s queueByEligibility) Len() int { return len(s.indices) }
+
+func (s queueByEligibility) Swap(i, j int) {
+	s.indices[i], s.indices[j] = s.indices[j], s.indices[i]
+	s.eligibilityEpochs[i], s.eligibilityEpochs[j] = s.eligibilityEpochs[j], s.eligibilityEpochs[i]
+}
+
+func (s queueByEligibility) Less(i, j int) bool {
+	if s.eligibilityEpochs[i] == s.eligibilityEpochs[j] {
+		return s.indices[i] < s.indices[j]
+	}
+	return s.eligibilityEpochs[i] < s.eligibilityEpochs[j]
+}
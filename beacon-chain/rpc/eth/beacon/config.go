@@ -2,12 +2,14 @@ package beacon
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
@@ -104,7 +106,16 @@ func prepareConfigSpec() (map[string]string, error) {
 		case reflect.Uint64:
 			data[tagValue] = strconv.FormatUint(vField.Uint(), 10)
 		case reflect.Slice:
-			data[tagValue] = hexutil.Encode(vField.Bytes())
+			if vField.Type().Elem().Kind() == reflect.Struct {
+				// Schedules such as the deposit plan are lists of segments, returned as JSON.
+				enc, err := json.Marshal(vField.Interface())
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not marshal %s", tagValue)
+				}
+				data[tagValue] = string(enc)
+			} else {
+				data[tagValue] = hexutil.Encode(vField.Bytes())
+			}
 		case reflect.Array:
 			if vField.Type().Elem().Kind() == reflect.Uint64 && vField.Len() == 11 {
 				var arrayValues []string
@@ -104,6 +104,7 @@ func TestGetSpec(t *testing.T) {
 	config.MaxWithdrawalsPerPayload = 74
 	config.MaxBlsToExecutionChanges = 75
 	config.MaxValidatorsPerWithdrawalsSweep = 76
+	config.MinEpochsForBlockRequests = 77
 
 	// config added in chronos starts with 200
 	config.MaxTokenSupply = 200
@@ -126,6 +127,8 @@ func TestGetSpec(t *testing.T) {
 	config.LightLayerWeight = 217
 	config.IssuanceRate = [11]uint64{218, 218, 218, 218, 218, 218, 218, 218, 218, 218, 218}
 	config.IssuancePrecision = 219
+	config.DepositPlan = []params.DepositPlanSegment{{EndYear: 220, Increase: 221, Offset: 222}}
+	config.BailOutRecoverySchedule = []params.BailOutRecoveryStep{{MinValidators: 223, RecoveryScore: 224}}
 
 	var dbp [4]byte
 	copy(dbp[:], []byte{'0', '0', '0', '1'})
@@ -158,7 +161,7 @@ func TestGetSpec(t *testing.T) {
 	resp, err := server.GetSpec(context.Background(), &emptypb.Empty{})
 	require.NoError(t, err)
 
-	assert.Equal(t, 126, len(resp.Data))
+	assert.Equal(t, 131, len(resp.Data))
 	for k, v := range resp.Data {
 		switch k {
 		case "CONFIG_NAME":
@@ -383,6 +386,8 @@ func TestGetSpec(t *testing.T) {
 			assert.Equal(t, "75", v)
 		case "MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP":
 			assert.Equal(t, "76", v)
+		case "MIN_EPOCHS_FOR_BLOCK_REQUESTS":
+			assert.Equal(t, "77", v)
 		case "REORG_MAX_EPOCHS_SINCE_FINALIZATION":
 			assert.Equal(t, "2", v)
 		case "REORG_WEIGHT_THRESHOLD":
@@ -411,7 +416,7 @@ func TestGetSpec(t *testing.T) {
 		case "CHURN_LIMIT_BIAS":
 			assert.Equal(t, "209", v)
 		case "MAX_BOOST_YIELD":
-			assert.Equal(t, [11]uint64{210, 210, 210, 210, 210, 210, 210, 210, 210, 210, 210}, v)
+			assert.Equal(t, "[210,210,210,210,210,210,210,210,210,210,210]", v)
 		case "TARGET_CHANGE_RATE":
 			assert.Equal(t, "211", v)
 		case "ISSUANCE_PER_YEAR":
@@ -427,9 +432,13 @@ func TestGetSpec(t *testing.T) {
 		case "LIGHT_LAYER_WEIGHT":
 			assert.Equal(t, "217", v)
 		case "ISSUANCE_RATE":
-			assert.Equal(t, [11]uint64{218, 218, 218, 218, 218, 218, 218, 218, 218, 218, 218}, v)
+			assert.Equal(t, "[218,218,218,218,218,218,218,218,218,218,218]", v)
 		case "ISSUANCE_PRECISION":
 			assert.Equal(t, "219", v)
+		case "DEPOSIT_PLAN":
+			assert.Equal(t, `[{"END_YEAR":"220","INCREASE":"221","OFFSET":"222"}]`, v)
+		case "BAILOUT_RECOVERY_SCHEDULE":
+			assert.Equal(t, `[{"MIN_VALIDATORS":"223","RECOVERY_SCORE":"224"}]`, v)
 		case "SAFE_SLOTS_TO_IMPORT_OPTIMISTICALLY":
 		default:
 			t.Errorf("Incorrect key: %s", k)
@@ -437,6 +446,20 @@ func TestGetSpec(t *testing.T) {
 	}
 }
 
+func TestGetSpec_Schedules(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	config := params.BeaconConfig().Copy()
+	config.DepositPlan = []params.DepositPlanSegment{{EndYear: 1, Increase: 2, Offset: 3}, {EndYear: 4, Increase: 5, Offset: 6}}
+	config.BailOutRecoverySchedule = []params.BailOutRecoveryStep{{MinValidators: 0, RecoveryScore: 7}, {MinValidators: 8, RecoveryScore: 9}}
+	params.OverrideBeaconConfig(config)
+
+	server := &Server{}
+	resp, err := server.GetSpec(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"END_YEAR":"1","INCREASE":"2","OFFSET":"3"},{"END_YEAR":"4","INCREASE":"5","OFFSET":"6"}]`, resp.Data["DEPOSIT_PLAN"])
+	assert.Equal(t, `[{"MIN_VALIDATORS":"0","RECOVERY_SCORE":"7"},{"MIN_VALIDATORS":"8","RECOVERY_SCORE":"9"}]`, resp.Data["BAILOUT_RECOVERY_SCHEDULE"])
+}
+
 func TestGetDepositContract(t *testing.T) {
 	const chainId = 99
 	const address = "0x0000000000000000000000000000000000000009"
@@ -46,4 +46,5 @@ type Server struct {
 	FinalizationFetcher           blockchain.FinalizationFetcher
 	BLSChangesPool                blstoexec.PoolManager
 	ForkchoiceFetcher             blockchain.ForkchoiceFetcher
+	ActivationQueueCache          *ActivationQueueCache
 }
@@ -3,7 +3,7 @@ package beacon
 import (
 	"context"
 	"encoding/hex"
-	"math"
+	"encoding/json"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -11,6 +11,7 @@ import (
 
 	"github.com/pkg/errors"
 	corehelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
@@ -329,14 +330,24 @@ type ValidatorEstimatedActivationResponse struct {
 	Status        uint64 `json:"status"`
 }
 
-type Validator struct {
-	Index                      uint64
-	PublicKey                  []byte
-	ActivationEligibilityEpoch uint64
-	ActivationEpoch            uint64
+type ValidatorEstimatedActivationsResponse struct {
+	Epoch       uint64                         `json:"epoch"`
+	ChurnLimit  uint64                         `json:"churn_limit"`
+	QueueLength uint64                         `json:"queue_length"`
+	Data        []*ValidatorActivationEstimate `json:"data"`
 }
 
-// ListValidators returns filterable list of validators with their balance, status and index.
+type ValidatorActivationEstimate struct {
+	Index                      uint64 `json:"index"`
+	Pubkey                     string `json:"pubkey"`
+	Status                     string `json:"status"`
+	ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch"`
+	QueuePosition              uint64 `json:"queue_position"`
+	ActivationEpoch            uint64 `json:"activation_epoch"`
+}
+
+// EstimatedActivation returns the activation estimate of the validator with the public key given in the URL path.
+// Without public key, it returns the estimate of a validator entering the activation queue right now.
 func (bs *Server) EstimatedActivation(w http.ResponseWriter, r *http.Request) {
 	segments := strings.Split(r.URL.Path, "/")
 	pubKey := segments[len(segments)-1]
@@ -347,108 +358,217 @@ func (bs *Server) EstimatedActivation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
-	st, err := bs.HeadFetcher.HeadState(ctx)
+	q, err := bs.ActivationQueueCache.get(r.Context(), bs.HeadFetcher)
 	if err != nil {
-		handleHTTPError(w, "could not get head state : "+err.Error(), http.StatusBadRequest)
+		handleHTTPError(w, "could not get activation queue : "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	headSlot := q.st.Slot()
+	eth1DataVotesLength := params.BeaconConfig().Eth1DataVotesLength()
+	remainingSlotsInPeriod := eth1DataVotesLength - uint64(headSlot.ModSlot(primitives.Slot(eth1DataVotesLength)))
+	baseEligibleSlots := params.BeaconConfig().Eth1FollowDistance +
+		eth1DataVotesLength/2 +
+		uint64(params.BeaconConfig().SlotsPerEpoch.Mul(3)) +
+		remainingSlotsInPeriod
+	// Estimate of a validator entering the back of the queue.
+	response := &ValidatorEstimatedActivationResponse{
+		WaitingEpoch:  0,
+		EligibleEpoch: uint64(slots.ToEpoch(headSlot.Add(baseEligibleSlots))),
+	}
+	if len(q.queue) > 0 {
+		response.WaitingEpoch = (uint64(len(q.queue))+q.churnLimit)/q.churnLimit + uint64(params.BeaconConfig().MaxSeedLookahead)
+	}
 
-	headSlot := st.Slot()
-	epoch := slots.ToEpoch(headSlot)
-	allValidators := st.Validators()
-	lastActiveIdx, activeValCount := 0, uint64(0)
-	activationQ := make([]Validator, 0)
-	waitingEpoch, eligibleEpoch, status := uint64(0), uint64(0), uint64(0)
-	for i, validator := range allValidators {
-		readOnlyVal, err := statenative.NewValidator(validator)
-		if err != nil {
-			handleHTTPError(w, "could not convert validator: "+err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		status, err := helpers.ValidatorStatus(readOnlyVal, epoch)
+	if pubKey != "" {
+		rawPubKey, err := hex.DecodeString(pubKey)
 		if err != nil {
-			handleHTTPError(w, "could not get validator sub status: "+err.Error(), http.StatusBadRequest)
+			handleHTTPError(w, "could not decode public key : "+err.Error(), http.StatusBadRequest)
 			return
 		}
-		if status == ethpb.ValidatorStatus_ACTIVE {
-			lastActiveIdx = i
-			activeValCount++
-			if hex.EncodeToString(validator.GetPublicKey()) == pubKey {
-				response := &ValidatorEstimatedActivationResponse{
-					WaitingEpoch:  uint64(0),
-					EligibleEpoch: uint64(validator.ActivationEligibilityEpoch),
+		if idx, ok := q.st.ValidatorIndexByPubkey(bytesutil.ToBytes48(rawPubKey)); ok {
+			estimate, err := q.estimate(idx)
+			if err != nil {
+				handleHTTPError(w, "could not estimate activation : "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			switch estimate.Status {
+			case activationStatusActivated:
+				response = &ValidatorEstimatedActivationResponse{
+					EligibleEpoch: estimate.ActivationEligibilityEpoch,
 					Status:        3,
 				}
-				network.WriteJson(w, response)
-				return
+			case activationStatusScheduled:
+				response = &ValidatorEstimatedActivationResponse{
+					WaitingEpoch:  estimate.ActivationEpoch - uint64(q.epoch),
+					EligibleEpoch: estimate.ActivationEligibilityEpoch,
+					Status:        2,
+				}
+			case activationStatusQueued:
+				response = &ValidatorEstimatedActivationResponse{
+					WaitingEpoch:  estimate.ActivationEpoch - uint64(q.epoch),
+					EligibleEpoch: estimate.ActivationEligibilityEpoch,
+					Status:        1,
+				}
 			}
-			continue
-		}
-
-		subStatus, err := helpers.ValidatorSubStatus(readOnlyVal, epoch)
-		if err != nil {
-			handleHTTPError(w, "could not get validator sub status: "+err.Error(), http.StatusBadRequest)
-			return
-		}
-		if subStatus == ethpb.ValidatorStatus_PENDING_QUEUED {
-			activationQ = append(activationQ, Validator{
-				Index:                      uint64(i),
-				PublicKey:                  validator.GetPublicKey(),
-				ActivationEligibilityEpoch: uint64(validator.GetActivationEligibilityEpoch()),
-				ActivationEpoch:            uint64(validator.GetActivationEpoch()),
-			})
 		}
 	}
+	network.WriteJson(w, response)
+}
 
-	activationsPerEpoch := uint64(math.Max(float64(params.BeaconConfig().MinPerEpochChurnLimit), float64(activeValCount/params.BeaconConfig().ChurnLimitQuotient)))
-	eth1DataVotesLength := params.BeaconConfig().Eth1DataVotesLength()
-	remainingSlotsInPeriod := eth1DataVotesLength - uint64(headSlot.ModSlot(primitives.Slot(eth1DataVotesLength)))
-	baseEligibleSlots := params.BeaconConfig().Eth1FollowDistance +
-		eth1DataVotesLength/2 +
-		uint64(params.BeaconConfig().SlotsPerEpoch.Mul(3)) +
-		remainingSlotsInPeriod
+// EstimatedActivations returns the activation estimates of a batch of validators, requested by index or public key
+// with a JSON array of ids in the request body. Well-formed ids which do not belong to any validator are skipped.
+//
+// The estimates are served from an index of the activation queue which is only rebuilt when the head changes.
+// The expected activation epoch of a queued validator assumes that the activation churn limit, which is raised by
+// ChurnLimitBias while the active deposit is below the target deposit plan, stays the same until it is dequeued.
+func (bs *Server) EstimatedActivations(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	if r.Body == nil || r.Body == http.NoBody {
+		handleHTTPError(w, "no validator ids in request body", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		handleHTTPError(w, "could not decode request body : "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(ids) == 0 {
+		handleHTTPError(w, "no validator ids in request body", http.StatusBadRequest)
+		return
+	}
 
-	if len(pubKey) == 0 {
-		if len(activationQ) == 0 {
-			waitingEpoch = uint64(0)
-		} else {
-			waitingEpoch = (uint64(len(activationQ))+activationsPerEpoch)/activationsPerEpoch + uint64(params.BeaconConfig().MaxSeedLookahead)
-		}
-		eligibleEpoch = uint64(slots.ToEpoch(headSlot.Add(baseEligibleSlots)))
-	} else {
-		if len(activationQ) == 0 {
-			waitingEpoch = uint64(0)
-			eligibleEpoch = uint64(slots.ToEpoch(headSlot.Add(baseEligibleSlots)))
+	q, err := bs.ActivationQueueCache.get(r.Context(), bs.HeadFetcher)
+	if err != nil {
+		handleHTTPError(w, "could not get activation queue : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	numValidators := uint64(q.st.NumValidators())
+	data := make([]*ValidatorActivationEstimate, 0, len(ids))
+	for _, id := range ids {
+		var idx primitives.ValidatorIndex
+		if pubKey := strings.TrimPrefix(id, "0x"); is96CharHex(pubKey) {
+			rawPubKey, err := hex.DecodeString(pubKey)
+			if err != nil {
+				handleHTTPError(w, "could not decode public key : "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			var ok bool
+			if idx, ok = q.st.ValidatorIndexByPubkey(bytesutil.ToBytes48(rawPubKey)); !ok {
+				continue
+			}
 		} else {
-			for _, val := range activationQ {
-				if pubKey == hex.EncodeToString(val.PublicKey) {
-					eligibleEpoch = val.ActivationEligibilityEpoch
-					if val.ActivationEpoch < uint64(params.BeaconConfig().FarFutureEpoch) {
-						waitingEpoch = val.ActivationEpoch - uint64(slots.ToEpoch(headSlot))
-						status = 2
-					} else {
-						pendingQueueSize := val.Index - uint64(lastActiveIdx)
-						waitingEpoch = uint64((pendingQueueSize+activationsPerEpoch)/activationsPerEpoch + uint64(params.BeaconConfig().MaxSeedLookahead))
-						status = 1
-					}
-					break
-				}
+			index, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				handleHTTPError(w, id+" is not a validator index or public key", http.StatusBadRequest)
+				return
 			}
-			if status == 0 {
-				waitingEpoch = (uint64(len(activationQ))+activationsPerEpoch)/activationsPerEpoch + uint64(params.BeaconConfig().MaxSeedLookahead)
-				eligibleEpoch = uint64(slots.ToEpoch(headSlot.Add(baseEligibleSlots)))
+			if index >= numValidators {
+				continue
 			}
+			idx = primitives.ValidatorIndex(index)
+		}
+		estimate, err := q.estimate(idx)
+		if err != nil {
+			handleHTTPError(w, "could not estimate activation : "+err.Error(), http.StatusInternalServerError)
+			return
 		}
+		data = append(data, estimate)
 	}
 
-	response := &ValidatorEstimatedActivationResponse{
-		WaitingEpoch:  waitingEpoch,
-		EligibleEpoch: eligibleEpoch,
-		Status:        status,
+	network.WriteJson(w, &ValidatorEstimatedActivationsResponse{
+		Epoch:       uint64(q.epoch),
+		ChurnLimit:  q.churnLimit,
+		QueueLength: uint64(len(q.queue)),
+		Data:        data,
+	})
+}
+
+type ValidatorEstimatedExitResponse struct {
+	ExitEpoch         uint64 `json:"exit_epoch"`
+	WithdrawableEpoch uint64 `json:"withdrawable_epoch"`
+	ExitChurnLimit    uint64 `json:"exit_churn_limit"`
+	Estimated         bool   `json:"estimated"`
+}
+
+// EstimatedExit returns the exit epoch and withdrawable epoch of the validator with the given public key.
+// For a validator which has not initiated its exit yet, they are estimated as if it exited right now,
+// by running the exit queue logic of the state transition on top of the head state. The exit churn
+// limit is raised by ChurnLimitBias while the active deposit is above the target deposit plan.
+func (bs *Server) EstimatedExit(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	pubKey := segments[len(segments)-1]
+	if !is96CharHex(pubKey) {
+		handleHTTPError(w, "this is not a proper BLS public key : "+pubKey, http.StatusBadRequest)
+		return
 	}
-	network.WriteJson(w, response)
+	rawPubKey, err := hex.DecodeString(pubKey)
+	if err != nil {
+		handleHTTPError(w, "could not decode public key : "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	st, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		handleHTTPError(w, "could not get head state : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(rawPubKey))
+	if !ok {
+		handleHTTPError(w, "could not find validator with public key : "+pubKey, http.StatusNotFound)
+		return
+	}
+	epoch := slots.ToEpoch(st.Slot())
+	activeValidatorCount, err := corehelpers.ActiveValidatorCount(ctx, st, epoch)
+	if err != nil {
+		handleHTTPError(w, "could not get active validator count : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	activeValidatorDeposit, err := corehelpers.TotalActiveBalance(st)
+	if err != nil {
+		handleHTTPError(w, "could not get total active balance : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	churn, err := corehelpers.ValidatorChurnLimit(activeValidatorCount, activeValidatorDeposit, epoch, true)
+	if err != nil {
+		handleHTTPError(w, "could not get exit churn limit : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	validator, err := st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		handleHTTPError(w, "could not get validator : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if validator.ExitEpoch() != params.BeaconConfig().FarFutureEpoch {
+		network.WriteJson(w, &ValidatorEstimatedExitResponse{
+			ExitEpoch:         uint64(validator.ExitEpoch()),
+			WithdrawableEpoch: uint64(validator.WithdrawableEpoch()),
+			ExitChurnLimit:    churn,
+		})
+		return
+	}
+	if !corehelpers.IsActiveValidatorUsingTrie(validator, epoch) {
+		handleHTTPError(w, "validator is not active and can not exit : "+pubKey, http.StatusBadRequest)
+		return
+	}
+
+	// The head state is a copy, so that the exit can be initiated on it without side effects.
+	st, err = validators.InitiateValidatorExit(ctx, st, idx, false)
+	if err != nil {
+		handleHTTPError(w, "could not initiate validator exit : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	validator, err = st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		handleHTTPError(w, "could not get validator : "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	network.WriteJson(w, &ValidatorEstimatedExitResponse{
+		ExitEpoch:         uint64(validator.ExitEpoch()),
+		WithdrawableEpoch: uint64(validator.WithdrawableEpoch()),
+		ExitChurnLimit:    churn,
+		Estimated:         true,
+	})
 }
 
 func handleHTTPError(w http.ResponseWriter, message string, code int) {
@@ -3,10 +3,16 @@ package beacon
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	corehelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	rpchelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
@@ -15,6 +21,7 @@ import (
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
 	"github.com/prysmaticlabs/prysm/v4/proto/migration"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -977,3 +984,181 @@ func TestListCommittees(t *testing.T) {
 		assert.Equal(t, true, resp.Finalized)
 	})
 }
+
+func TestEstimatedExit(t *testing.T) {
+	newServer := func(t *testing.T) (*Server, state.BeaconState) {
+		st, _ := util.DeterministicGenesisState(t, 64)
+		require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch*10))
+		return &Server{HeadFetcher: &chainMock.ChainService{State: st}}, st
+	}
+	estimatedExit := func(t *testing.T, s *Server, pubKey []byte) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", "/chronos/validator/estimated_exit/"+hex.EncodeToString(pubKey), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EstimatedExit(writer, request)
+		return writer
+	}
+
+	t.Run("exited validator", func(t *testing.T) {
+		s, st := newServer(t)
+		val, err := st.ValidatorAtIndex(3)
+		require.NoError(t, err)
+		val.ExitEpoch = 7
+		val.WithdrawableEpoch = 263
+		require.NoError(t, st.UpdateValidatorAtIndex(3, val))
+
+		writer := estimatedExit(t, s, val.PublicKey)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorEstimatedExitResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, uint64(7), resp.ExitEpoch)
+		assert.Equal(t, uint64(263), resp.WithdrawableEpoch)
+		assert.Equal(t, false, resp.Estimated)
+	})
+	t.Run("full exit queue", func(t *testing.T) {
+		s, st := newServer(t)
+		activeBalance, err := corehelpers.TotalActiveBalance(st)
+		require.NoError(t, err)
+		churn, err := corehelpers.ValidatorChurnLimit(64, activeBalance, 10, true)
+		require.NoError(t, err)
+		queueEpoch := corehelpers.ActivationExitEpoch(10)
+		for i := primitives.ValidatorIndex(0); i < primitives.ValidatorIndex(churn); i++ {
+			val, err := st.ValidatorAtIndex(i)
+			require.NoError(t, err)
+			val.ExitEpoch = queueEpoch
+			require.NoError(t, st.UpdateValidatorAtIndex(i, val))
+		}
+
+		pubKey := st.PubkeyAtIndex(40)
+		writer := estimatedExit(t, s, pubKey[:])
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorEstimatedExitResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, uint64(queueEpoch+1), resp.ExitEpoch)
+		assert.Equal(t, uint64(queueEpoch+1+params.BeaconConfig().MinValidatorWithdrawabilityDelay), resp.WithdrawableEpoch)
+		assert.Equal(t, churn, resp.ExitChurnLimit)
+		assert.Equal(t, true, resp.Estimated)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		s, _ := newServer(t)
+		writer := estimatedExit(t, s, []byte{'a'})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer = estimatedExit(t, s, make([]byte, 48))
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestEstimatedActivation(t *testing.T) {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	newServer := func(t *testing.T) (*Server, state.BeaconState) {
+		st, _ := util.DeterministicGenesisState(t, 64)
+		require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch*10))
+		// Validator 59 is scheduled for activation, 60 to 62 are queued and 63 is not eligible yet.
+		updates := map[primitives.ValidatorIndex][2]primitives.Epoch{
+			59: {2, 12},
+			60: {5, farFutureEpoch},
+			61: {5, farFutureEpoch},
+			62: {3, farFutureEpoch},
+			63: {farFutureEpoch, farFutureEpoch},
+		}
+		for idx, epochs := range updates {
+			val, err := st.ValidatorAtIndex(idx)
+			require.NoError(t, err)
+			val.ActivationEligibilityEpoch = epochs[0]
+			val.ActivationEpoch = epochs[1]
+			require.NoError(t, st.UpdateValidatorAtIndex(idx, val))
+		}
+		return &Server{
+			HeadFetcher:          &chainMock.ChainService{State: st},
+			ActivationQueueCache: NewActivationQueueCache(),
+		}, st
+	}
+	estimatedActivations := func(t *testing.T, s *Server, ids []string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(ids)
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "/chronos/validator/estimated_activation", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EstimatedActivations(writer, request)
+		return writer
+	}
+
+	t.Run("batch", func(t *testing.T) {
+		s, st := newServer(t)
+		activeBalance, err := corehelpers.TotalActiveBalance(st)
+		require.NoError(t, err)
+		churn, err := corehelpers.ValidatorChurnLimit(59, activeBalance, 10, false)
+		require.NoError(t, err)
+		pubKey := st.PubkeyAtIndex(60)
+
+		writer := estimatedActivations(t, s, []string{"0", "59", hexutil.Encode(pubKey[:]), "61", "62", "63", "1000"})
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorEstimatedActivationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, uint64(10), resp.Epoch)
+		assert.Equal(t, churn, resp.ChurnLimit)
+		assert.Equal(t, uint64(3), resp.QueueLength)
+		require.Equal(t, 6, len(resp.Data))
+
+		assert.Equal(t, activationStatusActivated, resp.Data[0].Status)
+		assert.Equal(t, activationStatusScheduled, resp.Data[1].Status)
+		assert.Equal(t, uint64(12), resp.Data[1].ActivationEpoch)
+		expected := uint64(corehelpers.ActivationExitEpoch(10))
+		for i, position := range []uint64{2, 3, 1} {
+			estimate := resp.Data[i+2]
+			assert.Equal(t, uint64(60+i), estimate.Index)
+			assert.Equal(t, activationStatusQueued, estimate.Status)
+			assert.Equal(t, position, estimate.QueuePosition)
+			assert.Equal(t, expected, estimate.ActivationEpoch)
+		}
+		assert.Equal(t, hexutil.Encode(pubKey[:]), resp.Data[2].Pubkey)
+		assert.Equal(t, activationStatusPending, resp.Data[5].Status)
+		assert.Equal(t, uint64(farFutureEpoch), resp.Data[5].ActivationEpoch)
+	})
+	t.Run("rebuilt on head change", func(t *testing.T) {
+		s, st := newServer(t)
+		writer := estimatedActivations(t, s, []string{"63"})
+		require.Equal(t, http.StatusOK, writer.Code)
+
+		val, err := st.ValidatorAtIndex(63)
+		require.NoError(t, err)
+		val.ActivationEligibilityEpoch = 1
+		require.NoError(t, st.UpdateValidatorAtIndex(63, val))
+		writer = estimatedActivations(t, s, []string{"63"})
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorEstimatedActivationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, uint64(3), resp.QueueLength)
+
+		s.HeadFetcher.(*chainMock.ChainService).Root = bytesutil.PadTo([]byte{'a'}, 32)
+		writer = estimatedActivations(t, s, []string{"63"})
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &ValidatorEstimatedActivationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, uint64(4), resp.QueueLength)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, activationStatusQueued, resp.Data[0].Status)
+		assert.Equal(t, uint64(1), resp.Data[0].QueuePosition)
+	})
+	t.Run("single public key", func(t *testing.T) {
+		s, st := newServer(t)
+		pubKey := st.PubkeyAtIndex(61)
+		request := httptest.NewRequest("GET", "/chronos/validator/estimated_activation/"+hex.EncodeToString(pubKey[:]), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EstimatedActivation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorEstimatedActivationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, uint64(1), resp.Status)
+		assert.Equal(t, uint64(5), resp.EligibleEpoch)
+		assert.Equal(t, uint64(corehelpers.ActivationExitEpoch(10)-10), resp.WaitingEpoch)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		s, _ := newServer(t)
+		writer := estimatedActivations(t, s, []string{})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		writer = estimatedActivations(t, s, []string{"foo"})
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
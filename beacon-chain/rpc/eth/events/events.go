@@ -44,6 +44,10 @@ const (
 	BLSToExecutionChangeTopic = "bls_to_execution_change"
 	// PayloadAttributesTopic represents a new payload attributes for execution payload building event topic.
 	PayloadAttributesTopic = "payload_attributes"
+	// BailOutTopic represents a new bail out included in an imported block event topic.
+	BailOutTopic = "bail_out"
+	// TokenomicsTopic represents a new epoch transition tokenomics event topic.
+	TokenomicsTopic = "tokenomics"
 )
 
 const chanBuffer = 1000
@@ -58,6 +62,8 @@ var casesHandled = map[string]bool{
 	SyncCommitteeContributionTopic: true,
 	BLSToExecutionChangeTopic:      true,
 	PayloadAttributesTopic:         true,
+	BailOutTopic:                   true,
+	TokenomicsTopic:                true,
 }
 
 // StreamEvents allows requesting all events from a set of topics defined in the Ethereum consensus API standard.
@@ -99,7 +105,7 @@ func (s *Server) StreamEvents(
 	for {
 		select {
 		case event := <-blockChan:
-			if err := handleBlockEvents(stream, requestedTopics, event); err != nil {
+			if err := s.handleBlockEvents(stream, requestedTopics, event); err != nil {
 				return status.Errorf(codes.Internal, "Could not handle block event: %v", err)
 			}
 		case event := <-opsChan:
@@ -118,35 +124,75 @@ func (s *Server) StreamEvents(
 	}
 }
 
-func handleBlockEvents(
+func (s *Server) handleBlockEvents(
 	stream ethpbservice.Events_StreamEventsServer, requestedTopics map[string]bool, event *feed.Event,
 ) error {
 	switch event.Type {
 	case blockfeed.ReceivedBlock:
-		if _, ok := requestedTopics[BlockTopic]; !ok {
-			return nil
-		}
 		blkData, ok := event.Data.(*blockfeed.ReceivedBlockData)
 		if !ok {
 			return nil
 		}
-		v1Data, err := migration.BlockIfaceToV1BlockHeader(blkData.SignedBlock)
-		if err != nil {
-			return err
+		if _, ok := requestedTopics[BlockTopic]; ok {
+			v1Data, err := migration.BlockIfaceToV1BlockHeader(blkData.SignedBlock)
+			if err != nil {
+				return err
+			}
+			item, err := v1Data.Message.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not hash tree root block")
+			}
+			eventBlock := &ethpb.EventBlock{
+				Slot:                v1Data.Message.Slot,
+				Block:               item[:],
+				ExecutionOptimistic: blkData.IsOptimistic,
+			}
+			if err := streamData(stream, BlockTopic, eventBlock); err != nil {
+				return err
+			}
 		}
-		item, err := v1Data.Message.HashTreeRoot()
+		if _, ok := requestedTopics[BailOutTopic]; ok {
+			return s.streamBailOuts(stream, blkData)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// streamBailOuts sends one event for each bail out included in the received block.
+func (s *Server) streamBailOuts(stream ethpbservice.Events_StreamEventsServer, blkData *blockfeed.ReceivedBlockData) error {
+	blk := blkData.SignedBlock.Block()
+	if blk.Version() == version.Phase0 {
+		return nil
+	}
+	bailOuts, err := blk.Body().BailOuts()
+	if err != nil {
+		return errors.Wrap(err, "could not get bail outs")
+	}
+	if len(bailOuts) == 0 {
+		return nil
+	}
+	root, err := blk.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash tree root block")
+	}
+	for _, bailOut := range bailOuts {
+		pubkey, err := s.HeadFetcher.HeadValidatorIndexToPublicKey(s.Ctx, bailOut.ValidatorIndex)
 		if err != nil {
-			return errors.Wrap(err, "could not hash tree root block")
+			return errors.Wrapf(err, "could not get public key of validator %d", bailOut.ValidatorIndex)
 		}
-		eventBlock := &ethpb.EventBlock{
-			Slot:                v1Data.Message.Slot,
-			Block:               item[:],
+		if err := streamData(stream, BailOutTopic, &ethpb.EventBailOut{
+			ValidatorIndex:      bailOut.ValidatorIndex,
+			Pubkey:              pubkey[:],
+			Slot:                blk.Slot(),
+			Block:               root[:],
 			ExecutionOptimistic: blkData.IsOptimistic,
+		}); err != nil {
+			return err
 		}
-		return streamData(stream, BlockTopic, eventBlock)
-	default:
-		return nil
 	}
+	return nil
 }
 
 func handleBlockOperationEvents(
@@ -254,6 +300,15 @@ func (s *Server) handleStateEvents(
 			return nil
 		}
 		return streamData(stream, ChainReorgTopic, reorg)
+	case statefeed.Tokenomics:
+		if _, ok := requestedTopics[TokenomicsTopic]; !ok {
+			return nil
+		}
+		tokenomics, ok := event.Data.(*ethpb.EventTokenomics)
+		if !ok {
+			return nil
+		}
+		return streamData(stream, TokenomicsTopic, tokenomics)
 	default:
 		return nil
 	}
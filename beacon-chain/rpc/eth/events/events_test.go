@@ -98,6 +98,53 @@ func TestStreamEvents_BlockEvents(t *testing.T) {
 			feed: srv.BlockNotifier.BlockFeed(),
 		})
 	})
+	t.Run(BailOutTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+		pubkey := [fieldparams.BLSPubkeyLength]byte{'a'}
+		srv.HeadFetcher = &mockChain.ChainService{PublicKey: pubkey}
+
+		blk := util.HydrateSignedBeaconBlockAltair(&eth.SignedBeaconBlockAltair{
+			Block: &eth.BeaconBlockAltair{
+				Slot: 8,
+				Body: &eth.BeaconBlockBodyAltair{
+					BailOuts: []*eth.BailOut{{ValidatorIndex: 3}},
+				},
+			},
+		})
+		wantedBlockRoot, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		genericResponse, err := anypb.New(&ethpb.EventBailOut{
+			ValidatorIndex:      3,
+			Pubkey:              pubkey[:],
+			Slot:                8,
+			Block:               wantedBlockRoot[:],
+			ExecutionOptimistic: true,
+		})
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: BailOutTopic,
+			Data:  genericResponse,
+		}
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{BailOutTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: blockfeed.ReceivedBlock,
+				Data: &blockfeed.ReceivedBlockData{
+					SignedBlock:  wsb,
+					IsOptimistic: true,
+				},
+			},
+			feed: srv.BlockNotifier.BlockFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_OperationsEvents(t *testing.T) {
@@ -588,6 +635,40 @@ func TestStreamEvents_StateEvents(t *testing.T) {
 			feed: srv.StateNotifier.StateFeed(),
 		})
 	})
+	t.Run(TokenomicsTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		wantedTokenomics := &ethpb.EventTokenomics{
+			Epoch:                  8,
+			Block:                  make([]byte, 32),
+			RewardAdjustmentFactor: 1000,
+			PreviousEpochReserve:   2000,
+			CurrentEpochReserve:    1500,
+			EpochIssuance:          300,
+			EpochFeedbackBoost:     500,
+		}
+		genericResponse, err := anypb.New(wantedTokenomics)
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: TokenomicsTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{TokenomicsTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.Tokenomics,
+				Data: wantedTokenomics,
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_CommaSeparatedTopics(t *testing.T) {
@@ -0,0 +1,117 @@
+package forkchoice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/dot"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+)
+
+// shortRootLength is the number of hex characters of a root shown in node labels, after the 0x prefix.
+const shortRootLength = 8
+
+// NewForkChoiceGraph converts a fork choice dump to its API representation, marking the proposer boosted node.
+func NewForkChoiceGraph(dump *v1.ForkChoiceDump) *ForkChoiceGraph {
+	boostRoot := hexutil.Encode(dump.ProposerBoostRoot)
+	nodes := make([]*ForkChoiceGraphNode, len(dump.ForkChoiceNodes))
+	for i, n := range dump.ForkChoiceNodes {
+		root := hexutil.Encode(n.BlockRoot)
+		nodes[i] = &ForkChoiceGraphNode{
+			Slot:                     strconv.FormatUint(uint64(n.Slot), 10),
+			BlockRoot:                root,
+			ParentRoot:               hexutil.Encode(n.ParentRoot),
+			Weight:                   strconv.FormatUint(n.Weight, 10),
+			Balance:                  strconv.FormatUint(n.Balance, 10),
+			ExecutionOptimistic:      n.ExecutionOptimistic,
+			JustifiedEpoch:           strconv.FormatUint(uint64(n.JustifiedEpoch), 10),
+			FinalizedEpoch:           strconv.FormatUint(uint64(n.FinalizedEpoch), 10),
+			UnrealizedJustifiedEpoch: strconv.FormatUint(uint64(n.UnrealizedJustifiedEpoch), 10),
+			UnrealizedFinalizedEpoch: strconv.FormatUint(uint64(n.UnrealizedFinalizedEpoch), 10),
+			ProposerBoost:            root == boostRoot,
+		}
+	}
+	return &ForkChoiceGraph{
+		JustifiedCheckpoint:           checkpoint(dump.JustifiedCheckpoint),
+		FinalizedCheckpoint:           checkpoint(dump.FinalizedCheckpoint),
+		UnrealizedJustifiedCheckpoint: checkpoint(dump.UnrealizedJustifiedCheckpoint),
+		UnrealizedFinalizedCheckpoint: checkpoint(dump.UnrealizedFinalizedCheckpoint),
+		ProposerBoostRoot:             boostRoot,
+		PreviousProposerBoostRoot:     hexutil.Encode(dump.PreviousProposerBoostRoot),
+		HeadRoot:                      hexutil.Encode(dump.HeadRoot),
+		Nodes:                         nodes,
+	}
+}
+
+// DotGraph renders the fork choice tree in the graphviz dot format, with an edge from each node to its parent.
+// The head is filled in blue, the finalized checkpoint in grey, the justified checkpoint has a double border,
+// the proposer boosted node a red border and optimistic nodes a dashed border.
+func DotGraph(g *ForkChoiceGraph) *dot.Graph {
+	graph := dot.NewGraph(dot.Directed)
+	graph.Attr("rankdir", "RL")
+	graph.Attr("labeljust", "l")
+
+	nodes := make(map[string]dot.Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		lines := []string{
+			fmt.Sprintf("slot: %s", n.Slot),
+			fmt.Sprintf("root: %s", shortRoot(n.BlockRoot)),
+			fmt.Sprintf("weight: %s", n.Weight),
+			fmt.Sprintf("balance: %s", n.Balance),
+			fmt.Sprintf("justified: %s (unrealized %s)", n.JustifiedEpoch, n.UnrealizedJustifiedEpoch),
+			fmt.Sprintf("finalized: %s (unrealized %s)", n.FinalizedEpoch, n.UnrealizedFinalizedEpoch),
+		}
+		styles := make([]string, 0, 2)
+		if n.ExecutionOptimistic {
+			lines = append(lines, "optimistic")
+			styles = append(styles, "dashed")
+		}
+		dn := graph.Node(n.BlockRoot).Box()
+		switch {
+		case n.BlockRoot == g.HeadRoot:
+			styles = append(styles, "filled")
+			dn.Attr("fillcolor", "lightblue")
+		case g.FinalizedCheckpoint != nil && n.BlockRoot == g.FinalizedCheckpoint.Root:
+			styles = append(styles, "filled")
+			dn.Attr("fillcolor", "lightgrey")
+		}
+		if g.JustifiedCheckpoint != nil && n.BlockRoot == g.JustifiedCheckpoint.Root {
+			dn.Attr("peripheries", "2")
+		}
+		if n.ProposerBoost {
+			lines = append(lines, "proposer boost")
+			dn.Attr("color", "red")
+			dn.Attr("penwidth", "2")
+		}
+		if len(styles) > 0 {
+			dn.Attr("style", strings.Join(styles, ","))
+		}
+		dn.Label(strings.Join(lines, "\n"))
+		nodes[n.BlockRoot] = dn
+	}
+	for _, n := range g.Nodes {
+		if parent, ok := nodes[n.ParentRoot]; ok {
+			graph.Edge(nodes[n.BlockRoot], parent)
+		}
+	}
+	return graph
+}
+
+func checkpoint(cp *v1.Checkpoint) *Checkpoint {
+	if cp == nil {
+		return nil
+	}
+	return &Checkpoint{
+		Epoch: strconv.FormatUint(uint64(cp.Epoch), 10),
+		Root:  hexutil.Encode(cp.Root),
+	}
+}
+
+func shortRoot(root string) string {
+	if len(root) > 2+shortRootLength {
+		return root[:2+shortRootLength]
+	}
+	return root
+}
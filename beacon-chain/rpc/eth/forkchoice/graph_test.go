@@ -0,0 +1,71 @@
+package forkchoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testDump() *v1.ForkChoiceDump {
+	finalized := bytesutil.PadTo([]byte("finalized"), 32)
+	head := bytesutil.PadTo([]byte("head"), 32)
+	fork := bytesutil.PadTo([]byte("fork"), 32)
+	return &v1.ForkChoiceDump{
+		JustifiedCheckpoint:           &v1.Checkpoint{Epoch: 2, Root: finalized},
+		FinalizedCheckpoint:           &v1.Checkpoint{Epoch: 2, Root: finalized},
+		UnrealizedJustifiedCheckpoint: &v1.Checkpoint{Epoch: 2, Root: finalized},
+		UnrealizedFinalizedCheckpoint: &v1.Checkpoint{Epoch: 2, Root: finalized},
+		ProposerBoostRoot:             fork,
+		PreviousProposerBoostRoot:     make([]byte, 32),
+		HeadRoot:                      head,
+		ForkChoiceNodes: []*v1.ForkChoiceNode{
+			{Slot: 64, BlockRoot: finalized, ParentRoot: make([]byte, 32), JustifiedEpoch: 2, FinalizedEpoch: 2, Weight: 300, Balance: 0},
+			{Slot: 65, BlockRoot: head, ParentRoot: finalized, JustifiedEpoch: 2, FinalizedEpoch: 2, Weight: 200, Balance: 200},
+			{Slot: 66, BlockRoot: fork, ParentRoot: finalized, JustifiedEpoch: 2, FinalizedEpoch: 2, Weight: 100, Balance: 100, ExecutionOptimistic: true},
+		},
+	}
+}
+
+func TestNewForkChoiceGraph(t *testing.T) {
+	dump := testDump()
+	g := NewForkChoiceGraph(dump)
+	assert.Equal(t, "2", g.FinalizedCheckpoint.Epoch)
+	assert.Equal(t, hexutil.Encode(dump.FinalizedCheckpoint.Root), g.FinalizedCheckpoint.Root)
+	assert.Equal(t, hexutil.Encode(dump.HeadRoot), g.HeadRoot)
+	require.Equal(t, 3, len(g.Nodes))
+	assert.Equal(t, "65", g.Nodes[1].Slot)
+	assert.Equal(t, "200", g.Nodes[1].Weight)
+	assert.Equal(t, hexutil.Encode(dump.FinalizedCheckpoint.Root), g.Nodes[1].ParentRoot)
+	assert.Equal(t, false, g.Nodes[1].ProposerBoost)
+	assert.Equal(t, true, g.Nodes[2].ProposerBoost)
+	assert.Equal(t, true, g.Nodes[2].ExecutionOptimistic)
+}
+
+func TestDotGraph(t *testing.T) {
+	dump := testDump()
+	out := DotGraph(NewForkChoiceGraph(dump)).String()
+	finalized := hexutil.Encode(dump.FinalizedCheckpoint.Root)
+	head := hexutil.Encode(dump.HeadRoot)
+	fork := hexutil.Encode(dump.ProposerBoostRoot)
+
+	assert.Equal(t, true, strings.HasPrefix(out, "digraph"))
+	assert.Equal(t, true, strings.Contains(out, `rankdir="RL"`))
+	// Edges point from the child to its parent, the parent of the tree root is not in the graph.
+	assert.Equal(t, 2, strings.Count(out, "->"))
+	assert.Equal(t, true, strings.Contains(out, "slot: 65"))
+	assert.Equal(t, true, strings.Contains(out, "weight: 200"))
+	assert.Equal(t, true, strings.Contains(out, "root: "+head[:10]))
+	assert.Equal(t, true, strings.Contains(out, "optimistic"))
+	assert.Equal(t, true, strings.Contains(out, "proposer boost"))
+	assert.Equal(t, true, strings.Contains(out, `fillcolor="lightblue"`))
+	assert.Equal(t, true, strings.Contains(out, `fillcolor="lightgrey"`))
+	assert.Equal(t, true, strings.Contains(out, `peripheries="2"`))
+	assert.Equal(t, true, strings.Contains(out, `color="red"`))
+	assert.Equal(t, true, strings.Contains(out, "root: "+finalized[:10]))
+	assert.Equal(t, true, strings.Contains(out, "root: "+fork[:10]))
+}
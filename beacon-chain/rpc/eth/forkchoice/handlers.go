@@ -0,0 +1,60 @@
+package forkchoice
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+const (
+	formatJSON = "json"
+	formatDot  = "dot"
+)
+
+// GetForkChoiceGraph exports the fork choice tree with the weight, balance, optimistic status and
+// checkpoints of every node. The tree is returned as JSON by default, or in the graphviz dot format
+// with `format=dot`.
+func (s *Server) GetForkChoiceGraph(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatJSON
+	}
+	if format != formatJSON && format != formatDot {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "format must be one of: json, dot",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	dump, err := s.ForkchoiceFetcher.ForkChoiceDump(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not dump fork choice", http.StatusInternalServerError))
+		return
+	}
+	if dump == nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "fork choice is not initialized",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+	graph := NewForkChoiceGraph(dump)
+	if format == formatJSON {
+		network.WriteJson(w, &GetForkChoiceGraphResponse{Data: graph})
+		return
+	}
+	w.Header().Set("Content-Type", "text/vnd.graphviz")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(DotGraph(graph).String())); err != nil {
+		log.WithError(err).Error("Could not write fork choice graph")
+	}
+}
+
+func handleWrapError(err error, message string, code int) *network.DefaultErrorJson {
+	return &network.DefaultErrorJson{
+		Message: errors.Wrapf(err, message).Error(),
+		Code:    code,
+	}
+}
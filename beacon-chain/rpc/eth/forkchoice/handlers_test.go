@@ -0,0 +1,70 @@
+package forkchoice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testServer(t *testing.T) (*Server, [32]byte) {
+	store := doublylinkedtree.New()
+	fRoot := [32]byte{'a'}
+	require.NoError(t, store.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Epoch: 2, Root: fRoot}))
+	return &Server{ForkchoiceFetcher: &mock.ChainService{ForkChoiceStore: store}}, fRoot
+}
+
+func TestGetForkChoiceGraph(t *testing.T) {
+	t.Run("json by default", func(t *testing.T) {
+		s, fRoot := testServer(t)
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/chronos/debug/fork_choice/graph", nil)
+		writer := httptest.NewRecorder()
+
+		s.GetForkChoiceGraph(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetForkChoiceGraphResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, "2", resp.Data.FinalizedCheckpoint.Epoch)
+		assert.Equal(t, hexutil.Encode(fRoot[:]), resp.Data.FinalizedCheckpoint.Root)
+	})
+	t.Run("dot", func(t *testing.T) {
+		s, _ := testServer(t)
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/chronos/debug/fork_choice/graph?format=dot", nil)
+		writer := httptest.NewRecorder()
+
+		s.GetForkChoiceGraph(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "text/vnd.graphviz", writer.Header().Get("Content-Type"))
+		assert.Equal(t, true, strings.HasPrefix(writer.Body.String(), "digraph"))
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		s, _ := testServer(t)
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/chronos/debug/fork_choice/graph?format=svg", nil)
+		writer := httptest.NewRecorder()
+
+		s.GetForkChoiceGraph(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.StringContains(t, "format must be one of", e.Message)
+	})
+	t.Run("not initialized", func(t *testing.T) {
+		s := &Server{ForkchoiceFetcher: &mock.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/chronos/debug/fork_choice/graph", nil)
+		writer := httptest.NewRecorder()
+
+		s.GetForkChoiceGraph(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
@@ -0,0 +1,5 @@
+package forkchoice
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "rpc/forkchoice")
@@ -0,0 +1,9 @@
+package forkchoice
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+)
+
+type Server struct {
+	ForkchoiceFetcher blockchain.ForkchoiceFetcher
+}
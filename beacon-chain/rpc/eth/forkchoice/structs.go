@@ -0,0 +1,35 @@
+package forkchoice
+
+type GetForkChoiceGraphResponse struct {
+	Data *ForkChoiceGraph `json:"data"`
+}
+
+type ForkChoiceGraph struct {
+	JustifiedCheckpoint           *Checkpoint            `json:"justified_checkpoint"`
+	FinalizedCheckpoint           *Checkpoint            `json:"finalized_checkpoint"`
+	UnrealizedJustifiedCheckpoint *Checkpoint            `json:"unrealized_justified_checkpoint"`
+	UnrealizedFinalizedCheckpoint *Checkpoint            `json:"unrealized_finalized_checkpoint"`
+	ProposerBoostRoot             string                 `json:"proposer_boost_root"`
+	PreviousProposerBoostRoot     string                 `json:"previous_proposer_boost_root"`
+	HeadRoot                      string                 `json:"head_root"`
+	Nodes                         []*ForkChoiceGraphNode `json:"nodes"`
+}
+
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type ForkChoiceGraphNode struct {
+	Slot                     string `json:"slot"`
+	BlockRoot                string `json:"block_root"`
+	ParentRoot               string `json:"parent_root"`
+	Weight                   string `json:"weight"`
+	Balance                  string `json:"balance"`
+	ExecutionOptimistic      bool   `json:"execution_optimistic"`
+	JustifiedEpoch           string `json:"justified_epoch"`
+	FinalizedEpoch           string `json:"finalized_epoch"`
+	UnrealizedJustifiedEpoch string `json:"unrealized_justified_epoch"`
+	UnrealizedFinalizedEpoch string `json:"unrealized_finalized_epoch"`
+	ProposerBoost            bool   `json:"proposer_boost"`
+}
@@ -0,0 +1,117 @@
+package proofs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// maxProofPaths bounds the number of paths a single proof request may ask for.
+const maxProofPaths = 64
+
+// GetStateProof returns a Merkle multiproof of the requested state nodes against the state root.
+// Nodes are requested with the `path` query parameter, which may be repeated or hold a comma
+// separated list, e.g. `current_epoch_reserve`, `bail_out_scores[12]` or
+// `validators[3].effective_balance`. Packed basic elements such as balances and bailout scores
+// resolve to the 32 byte chunk holding them, four uint64 values to a chunk.
+//
+// The proof follows the multiproof format of the consensus specification: helper indices are
+// sorted in descending order and proof[i] is the node at helper_indices[i]. The state root can
+// in turn be checked against a block root with the block header.
+func (s *Server) GetStateProof(w http.ResponseWriter, r *http.Request) {
+	stateId := mux.Vars(r)["state_id"]
+	if stateId == "" {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "state_id is required in URL params",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	var paths []string
+	for _, p := range r.URL.Query()["path"] {
+		paths = append(paths, strings.Split(p, ",")...)
+	}
+	if len(paths) == 0 {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "path is required in query params",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if len(paths) > maxProofPaths {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("at most %d paths may be requested at once", maxProofPaths),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	st, err := s.Stater.State(r.Context(), []byte(stateId))
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+	mp, err := st.Multiproof(r.Context(), paths)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, state.ErrInvalidProofPath) {
+			code = http.StatusBadRequest
+		}
+		network.WriteError(w, handleWrapError(err, "could not compute proof", code))
+		return
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	root, err := helpers.BlockRootAtSlot(st, st.Slot()-1)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get block root", http.StatusInternalServerError))
+		return
+	}
+	var blockRoot = [32]byte(root)
+	isFinalized := s.FinalizationFetcher.IsFinalized(r.Context(), blockRoot)
+
+	leaves := make([]*ProofLeaf, len(mp.Leaves))
+	for i := range mp.Leaves {
+		leaves[i] = &ProofLeaf{
+			Path:             mp.Paths[i],
+			GeneralizedIndex: strconv.FormatUint(mp.Indices[i], 10),
+			Leaf:             hexutil.Encode(mp.Leaves[i][:]),
+		}
+	}
+	helperIndices := make([]string, len(mp.HelperIndices))
+	proof := make([]string, len(mp.Proof))
+	for i := range mp.HelperIndices {
+		helperIndices[i] = strconv.FormatUint(mp.HelperIndices[i], 10)
+		proof[i] = hexutil.Encode(mp.Proof[i][:])
+	}
+
+	network.WriteJson(w, &GetStateProofResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data: &StateProof{
+			StateRoot:     hexutil.Encode(mp.StateRoot[:]),
+			Leaves:        leaves,
+			HelperIndices: helperIndices,
+			Proof:         proof,
+		},
+	})
+}
+
+func handleWrapError(err error, message string, code int) *network.DefaultErrorJson {
+	return &network.DefaultErrorJson{
+		Message: errors.Wrapf(err, message).Error(),
+		Code:    code,
+	}
+}
@@ -0,0 +1,140 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestGetStateProof(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateCapella(t, 16)
+	require.NoError(t, st.SetSlot(5))
+	require.NoError(t, st.SetCurrentEpochReserve(9000000))
+	require.NoError(t, st.SetBailOutScores(make([]uint64, 16)))
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+
+	request := httptest.NewRequest("GET",
+		"/over/v1/beacon/states/{state_id}/proof?path=current_epoch_reserve,bail_out_scores[3]&path=validators[7].exit_epoch", nil)
+	request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetStateProof(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &GetStateProofResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, true, resp.ExecutionOptimistic)
+	assert.Equal(t, false, resp.Finalized)
+
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(stateRoot[:]), resp.Data.StateRoot)
+	require.Equal(t, 3, len(resp.Data.Leaves))
+	assert.Equal(t, "bail_out_scores[3]", resp.Data.Leaves[1].Path)
+	reserve := ssz.Uint64Root(9000000)
+	assert.Equal(t, hexutil.Encode(reserve[:]), resp.Data.Leaves[0].Leaf)
+
+	decode := func(s string) [32]byte {
+		b, err := hexutil.Decode(s)
+		require.NoError(t, err)
+		return bytesutil.ToBytes32(b)
+	}
+	leaves := make([][32]byte, len(resp.Data.Leaves))
+	indices := make([]uint64, len(resp.Data.Leaves))
+	for i, l := range resp.Data.Leaves {
+		leaves[i] = decode(l.Leaf)
+		indices[i], err = strconv.ParseUint(l.GeneralizedIndex, 10, 64)
+		require.NoError(t, err)
+	}
+	proof := make([][32]byte, len(resp.Data.Proof))
+	for i, p := range resp.Data.Proof {
+		proof[i] = decode(p)
+	}
+	assert.Equal(t, true, ssz.VerifyMultiproof(stateRoot, leaves, proof, indices))
+}
+
+func TestGetStateProof_BadRequest(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateCapella(t, 16)
+	require.NoError(t, st.SetSlot(5))
+	mockChainService := &mock.ChainService{}
+
+	testCases := []struct {
+		name         string
+		path         string
+		urlParams    map[string]string
+		errorMessage string
+	}{
+		{
+			name:         "no state_id",
+			path:         "/over/v1/beacon/states/{state_id}/proof?path=slot",
+			urlParams:    map[string]string{},
+			errorMessage: "state_id is required in URL params",
+		},
+		{
+			name:         "no path",
+			path:         "/over/v1/beacon/states/{state_id}/proof",
+			urlParams:    map[string]string{"state_id": "head"},
+			errorMessage: "path is required in query params",
+		},
+		{
+			name:         "too many paths",
+			path:         "/over/v1/beacon/states/{state_id}/proof?path=" + strings.Repeat("slot,", maxProofPaths) + "slot",
+			urlParams:    map[string]string{"state_id": "head"},
+			errorMessage: "at most 64 paths may be requested at once",
+		},
+		{
+			name:         "unknown field",
+			path:         "/over/v1/beacon/states/{state_id}/proof?path=bailout_scores[1]",
+			urlParams:    map[string]string{"state_id": "head"},
+			errorMessage: "unknown field \"bailout_scores\"",
+		},
+		{
+			name:         "index out of range",
+			path:         "/over/v1/beacon/states/{state_id}/proof?path=balances[16]",
+			urlParams:    map[string]string{"state_id": "head"},
+			errorMessage: "index 16 out of range for length 16",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := &Server{
+				FinalizationFetcher:   mockChainService,
+				OptimisticModeFetcher: mockChainService,
+				Stater:                &testutil.MockStater{BeaconState: st},
+			}
+			request := httptest.NewRequest("GET", testCase.path, nil)
+			request = mux.SetURLVars(request, testCase.urlParams)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetStateProof(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.Equal(t, http.StatusBadRequest, e.Code)
+			assert.StringContains(t, testCase.errorMessage, e.Message)
+		})
+	}
+}
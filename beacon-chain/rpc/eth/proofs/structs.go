@@ -0,0 +1,20 @@
+package proofs
+
+type GetStateProofResponse struct {
+	Data                *StateProof `json:"data"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+}
+
+type StateProof struct {
+	StateRoot     string       `json:"state_root"`
+	Leaves        []*ProofLeaf `json:"leaves"`
+	HelperIndices []string     `json:"helper_indices"`
+	Proof         []string     `json:"proof"`
+}
+
+type ProofLeaf struct {
+	Path             string `json:"path"`
+	GeneralizedIndex string `json:"gindex"`
+	Leaf             string `json:"leaf"`
+}
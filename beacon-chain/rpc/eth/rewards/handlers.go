@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -58,6 +59,8 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 	}
 
 	proposerIndex := blk.Block().ProposerIndex()
+	rewardAdjustmentFactor := st.RewardAdjustmentFactor()
+	initReserve := st.CurrentEpochReserve()
 	initBalance, err := st.BalanceAtIndex(proposerIndex)
 	if err != nil {
 		errJson := &network.DefaultErrorJson{
@@ -85,6 +88,8 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 		network.WriteError(w, errJson)
 		return
 	}
+	// Proposer rewards for attestations are the only ones taken out of the reserve while processing attestations.
+	attReserveFunded := initReserve - st.CurrentEpochReserve()
 	st, err = coreblocks.ProcessAttesterSlashings(r.Context(), st, blk.Block().Body().AttesterSlashings(), validators.SlashValidator)
 	if err != nil {
 		errJson := &network.DefaultErrorJson{
@@ -130,6 +135,9 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 		network.WriteError(w, errJson)
 		return
 	}
+	// The reserve funded part of the sync aggregate reward is pro rata of the reserve usage of all rewards of
+	// the epoch, as the reserve usage of the sync aggregate also accounts for the rewards of sync committee members.
+	totalReward, reserveUsage := helpers.TotalRewardWithReserveUsage(st)
 	var syncCommitteeReward uint64
 	_, syncCommitteeReward, err = altair.ProcessSyncAggregate(r.Context(), st, sa)
 	if err != nil {
@@ -160,14 +168,24 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var syncReserveFunded uint64
+	if totalReward > 0 {
+		syncReserveFunded = syncCommitteeReward * reserveUsage / totalReward
+	}
+
+	total := proposerSlashingsBalance - initBalance + syncCommitteeReward
+	issued, reserveFunded := splitReward(total, attReserveFunded+syncReserveFunded)
 	response := &BlockRewardsResponse{
 		Data: BlockRewards{
-			ProposerIndex:     strconv.FormatUint(uint64(proposerIndex), 10),
-			Total:             strconv.FormatUint(proposerSlashingsBalance-initBalance+syncCommitteeReward, 10),
-			Attestations:      strconv.FormatUint(attBalance-initBalance, 10),
-			SyncAggregate:     strconv.FormatUint(syncCommitteeReward, 10),
-			ProposerSlashings: strconv.FormatUint(proposerSlashingsBalance-attSlashingsBalance, 10),
-			AttesterSlashings: strconv.FormatUint(attSlashingsBalance-attBalance, 10),
+			ProposerIndex:          strconv.FormatUint(uint64(proposerIndex), 10),
+			Total:                  strconv.FormatUint(total, 10),
+			Attestations:           strconv.FormatUint(attBalance-initBalance, 10),
+			SyncAggregate:          strconv.FormatUint(syncCommitteeReward, 10),
+			ProposerSlashings:      strconv.FormatUint(proposerSlashingsBalance-attSlashingsBalance, 10),
+			AttesterSlashings:      strconv.FormatUint(attSlashingsBalance-attBalance, 10),
+			Issued:                 strconv.FormatUint(issued, 10),
+			ReserveFunded:          strconv.FormatUint(reserveFunded, 10),
+			RewardAdjustmentFactor: strconv.FormatUint(rewardAdjustmentFactor, 10),
 		},
 		ExecutionOptimistic: optimistic,
 		Finalized:           s.FinalizationFetcher.IsFinalized(r.Context(), blkRoot),
@@ -217,8 +235,9 @@ func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
 
 	resp := &AttestationRewardsResponse{
 		Data: AttestationRewards{
-			IdealRewards: idealRewards,
-			TotalRewards: totalRewards,
+			IdealRewards:           idealRewards,
+			TotalRewards:           totalRewards,
+			RewardAdjustmentFactor: strconv.FormatUint(st.RewardAdjustmentFactor(), 10),
 		},
 		ExecutionOptimistic: optimistic,
 		Finalized:           s.FinalizationFetcher.IsFinalized(r.Context(), blkRoot),
@@ -393,7 +412,7 @@ func idealAttRewards(
 		idealRewards = append(idealRewards, IdealAttestationReward{EffectiveBalance: strconv.FormatUint(effectiveBalance, 10)})
 	}
 
-	deltas, _, err := altair.AttestationsDelta(st, bal, idealVals)
+	deltas, reserveDeltas, err := altair.AttestationsDelta(st, bal, idealVals)
 	if err != nil {
 		errJson := &network.DefaultErrorJson{
 			Message: "Could not get attestations delta: " + err.Error(),
@@ -403,6 +422,9 @@ func idealAttRewards(
 		return nil, false
 	}
 	for i, d := range deltas {
+		issued, reserveFunded := splitReward(d.HeadReward+d.SourceReward+d.TargetReward, reserveDeltas[i])
+		idealRewards[i].Issued = strconv.FormatUint(issued, 10)
+		idealRewards[i].ReserveFunded = strconv.FormatUint(reserveFunded, 10)
 		idealRewards[i].Head = strconv.FormatUint(d.HeadReward, 10)
 		if d.SourcePenalty > 0 {
 			idealRewards[i].Source = fmt.Sprintf("-%s", strconv.FormatUint(d.SourcePenalty, 10))
@@ -429,7 +451,7 @@ func totalAttRewards(
 	for i, v := range valIndices {
 		totalRewards[i] = TotalAttestationReward{ValidatorIndex: strconv.FormatUint(uint64(v), 10)}
 	}
-	deltas, _, err := altair.AttestationsDelta(st, bal, vals)
+	deltas, reserveDeltas, err := altair.AttestationsDelta(st, bal, vals)
 	if err != nil {
 		errJson := &network.DefaultErrorJson{
 			Message: "Could not get attestations delta: " + err.Error(),
@@ -439,6 +461,9 @@ func totalAttRewards(
 		return nil, false
 	}
 	for i, d := range deltas {
+		issued, reserveFunded := splitReward(d.HeadReward+d.SourceReward+d.TargetReward, reserveDeltas[i])
+		totalRewards[i].Issued = strconv.FormatUint(issued, 10)
+		totalRewards[i].ReserveFunded = strconv.FormatUint(reserveFunded, 10)
 		totalRewards[i].Head = strconv.FormatUint(d.HeadReward, 10)
 		if d.SourcePenalty > 0 {
 			totalRewards[i].Source = fmt.Sprintf("-%s", strconv.FormatUint(d.SourcePenalty, 10))
@@ -454,6 +479,15 @@ func totalAttRewards(
 	return totalRewards, true
 }
 
+// splitReward splits a reward into the newly issued part and the part paid out of the reserve.
+// The reserve usage is rounded up when it is computed, so it is capped by the reward.
+func splitReward(reward, reserveUsage uint64) (issued, reserveFunded uint64) {
+	if reserveUsage > reward {
+		return 0, reward
+	}
+	return reward - reserveUsage, reserveUsage
+}
+
 func handleGetBlockError(blk interfaces.ReadOnlySignedBeaconBlock, err error) *network.DefaultErrorJson {
 	if errors.Is(err, lookup.BlockIdParseError{}) {
 		return &network.DefaultErrorJson{
@@ -169,6 +169,38 @@ func TestBlockRewards(t *testing.T) {
 		ReplayerBuilder:       mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st)),
 	}
 
+	t.Run("ok - reserve funded", func(t *testing.T) {
+		// Processing the block mutates the replayed state, so it is done on a copy.
+		boostSt := st.Copy()
+		require.NoError(t, boostSt.SetRewardAdjustmentFactor(10))
+		require.NoError(t, boostSt.SetPreviousEpochReserve(params.BeaconConfig().MaxTokenSupply))
+		require.NoError(t, boostSt.SetCurrentEpochReserve(params.BeaconConfig().MaxTokenSupply))
+		s := &Server{
+			Blocker:               s.Blocker,
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+			ReplayerBuilder:       mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(boostSt)),
+		}
+		url := "http://only.the.slot.number.at.the.end.is.important/2"
+		request := httptest.NewRequest("GET", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.BlockRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &BlockRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		total, err := strconv.ParseUint(resp.Data.Total, 10, 64)
+		require.NoError(t, err)
+		issued, err := strconv.ParseUint(resp.Data.Issued, 10, 64)
+		require.NoError(t, err)
+		reserveFunded, err := strconv.ParseUint(resp.Data.ReserveFunded, 10, 64)
+		require.NoError(t, err)
+		assert.NotEqual(t, uint64(0), reserveFunded)
+		assert.Equal(t, total, issued+reserveFunded)
+		assert.Equal(t, params.BeaconConfig().MaxTokenSupply-reserveFunded, boostSt.CurrentEpochReserve())
+		assert.Equal(t, "10", resp.Data.RewardAdjustmentFactor)
+	})
 	t.Run("ok", func(t *testing.T) {
 		url := "http://only.the.slot.number.at.the.end.is.important/2"
 		request := httptest.NewRequest("GET", url, nil)
@@ -185,6 +217,8 @@ func TestBlockRewards(t *testing.T) {
 		assert.Equal(t, "0", resp.Data.SyncAggregate) // zero reward for sync committee
 		assert.Equal(t, "500000000", resp.Data.AttesterSlashings)
 		assert.Equal(t, "500000000", resp.Data.ProposerSlashings)
+		assert.Equal(t, resp.Data.Total, resp.Data.Issued) // zero feedback boost
+		assert.Equal(t, "0", resp.Data.ReserveFunded)
 		assert.Equal(t, true, resp.ExecutionOptimistic)
 		assert.Equal(t, false, resp.Finalized)
 	})
@@ -325,6 +359,44 @@ func TestAttestationRewards(t *testing.T) {
 		}
 		assert.Equal(t, uint64(209811496744), sum)
 	})
+	t.Run("ok - reserve funded", func(t *testing.T) {
+		boostSt := st.Copy()
+		require.NoError(t, boostSt.SetRewardAdjustmentFactor(10))
+		require.NoError(t, boostSt.SetPreviousEpochReserve(params.BeaconConfig().MaxTokenSupply))
+		s := &Server{
+			Stater: &testutil.MockStater{StatesBySlot: map[primitives.Slot]state.BeaconState{
+				params.BeaconConfig().SlotsPerEpoch*3 - 1: boostSt,
+			}},
+			TimeFetcher:           mockChainService,
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+		}
+		url := "http://only.the.epoch.number.at.the.end.is.important/1"
+		request := httptest.NewRequest("POST", url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "10", resp.Data.RewardAdjustmentFactor)
+		parse := func(v string) uint64 {
+			n, err := strconv.ParseUint(v, 10, 64)
+			require.NoError(t, err)
+			return n
+		}
+		totalReserveFunded := uint64(0)
+		for _, r := range resp.Data.TotalRewards {
+			reserveFunded := parse(r.ReserveFunded)
+			totalReserveFunded += reserveFunded
+			assert.Equal(t, parse(r.Head)+parse(r.Source)+parse(r.Target), parse(r.Issued)+reserveFunded)
+		}
+		assert.NotEqual(t, uint64(0), totalReserveFunded)
+		for _, r := range resp.Data.IdealRewards {
+			assert.Equal(t, parse(r.Head)+parse(r.Source)+parse(r.Target), parse(r.Issued)+parse(r.ReserveFunded))
+		}
+	})
 	t.Run("ok - penalty", func(t *testing.T) {
 		st, err := util.NewBeaconStateCapella()
 		require.NoError(t, err)
@@ -13,6 +13,10 @@ type BlockRewards struct {
 	SyncAggregate     string `json:"sync_aggregate"`
 	ProposerSlashings string `json:"proposer_slashings"`
 	AttesterSlashings string `json:"attester_slashings"`
+	// Issued and ReserveFunded split Total into newly issued tokens and tokens paid out of the reserve.
+	Issued                 string `json:"issued"`
+	ReserveFunded          string `json:"reserve_funded"`
+	RewardAdjustmentFactor string `json:"reward_adjustment_factor"`
 }
 
 type AttestationRewardsResponse struct {
@@ -22,8 +26,9 @@ type AttestationRewardsResponse struct {
 }
 
 type AttestationRewards struct {
-	IdealRewards []IdealAttestationReward `json:"ideal_rewards"`
-	TotalRewards []TotalAttestationReward `json:"total_rewards"`
+	IdealRewards           []IdealAttestationReward `json:"ideal_rewards"`
+	TotalRewards           []TotalAttestationReward `json:"total_rewards"`
+	RewardAdjustmentFactor string                   `json:"reward_adjustment_factor"`
 }
 
 type IdealAttestationReward struct {
@@ -31,6 +36,8 @@ type IdealAttestationReward struct {
 	Head             string `json:"head"`
 	Target           string `json:"target"`
 	Source           string `json:"source"`
+	Issued           string `json:"issued"`
+	ReserveFunded    string `json:"reserve_funded"`
 }
 
 type TotalAttestationReward struct {
@@ -39,4 +46,8 @@ type TotalAttestationReward struct {
 	Target         string `json:"target"`
 	Source         string `json:"source"`
 	InclusionDelay string `json:"inclusion_delay"`
+	// Issued and ReserveFunded split the sum of the head, target and source rewards,
+	// penalties excluded, into newly issued tokens and tokens paid out of the reserve.
+	Issued        string `json:"issued"`
+	ReserveFunded string `json:"reserve_funded"`
 }
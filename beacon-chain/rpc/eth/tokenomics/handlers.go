@@ -0,0 +1,294 @@
+package tokenomics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	mathutil "github.com/prysmaticlabs/prysm/v4/math"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// maxProjectionEpochs bounds the number of epochs a single projection request may simulate.
+const maxProjectionEpochs = 100000
+
+// flow is the parsed form of ProjectionFlow.
+type flow struct {
+	deposit uint64
+	exit    uint64
+}
+
+// GetProjection simulates the tokenomics of the next N epochs starting from the requested state.
+// e.g. RewardAdjustmentFactor, CurrentEpochReserve, EpochIssuance, EpochFeedbackBoost, etc.
+//
+// The request may carry a JSON array of ProjectionFlow in its body, where the i-th element is
+// the hypothetical deposit and exit amount entering the queue during the i-th projected epoch.
+// The projection assumes full participation, so that the whole feedback boost is paid out of
+// the reserve every epoch. The requested state is never mutated.
+func (s *Server) GetProjection(w http.ResponseWriter, r *http.Request) {
+	stateId := r.URL.Query().Get("state_id")
+	if stateId == "" {
+		stateId = "head"
+	}
+	rawEpochs := r.URL.Query().Get("epochs")
+	if rawEpochs == "" {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "epochs is required in query params",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	epochs, err := strconv.ParseUint(rawEpochs, 10, 64)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not parse epochs", http.StatusBadRequest))
+		return
+	}
+	if epochs == 0 || epochs > maxProjectionEpochs {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("epochs must be between 1 and %d", maxProjectionEpochs),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	flows, err := decodeFlows(r)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not decode flows", http.StatusBadRequest))
+		return
+	}
+	if uint64(len(flows)) > epochs {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("got %d flows for a projection of %d epochs", len(flows), epochs),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	st, err := s.Stater.State(r.Context(), []byte(stateId))
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not retrieve state", http.StatusNotFound))
+		return
+	}
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	root, err := helpers.BlockRootAtSlot(st, st.Slot()-1)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get block root", http.StatusInternalServerError))
+		return
+	}
+	var blockRoot = [32]byte(root)
+	isFinalized := s.FinalizationFetcher.IsFinalized(r.Context(), blockRoot)
+
+	projected, err := project(st, epochs, flows)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not project tokenomics", http.StatusBadRequest))
+		return
+	}
+
+	network.WriteJson(w, &GetProjectionResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data: &Projection{
+			StartEpoch: strconv.FormatUint(uint64(time.CurrentEpoch(st)), 10),
+			Epochs:     projected,
+		},
+	})
+}
+
+// project runs the epoch transitions of Over tokenomics for the given number of epochs.
+// It only reads from the given state and carries the simulated values in local variables.
+func project(st state.ReadOnlyBeaconState, epochs uint64, flows []flow) ([]*ProjectionEpoch, error) {
+	minDeposit := params.BeaconConfig().EffectiveBalanceIncrement
+	futureDeposit, err := helpers.TotalBalanceWithQueue(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total balance with queue")
+	}
+	epoch := time.CurrentEpoch(st)
+	factor := st.RewardAdjustmentFactor()
+	previousReserve := st.PreviousEpochReserve()
+	currentReserve := st.CurrentEpochReserve()
+
+	projected := make([]*ProjectionEpoch, 0, epochs)
+	for i := uint64(0); i < epochs; i++ {
+		// Rewards of the ending epoch are paid out of the reserve before the factor is updated,
+		// in the same order as altair.ProcessEpoch.
+		boost := helpers.FeedbackBoost(factor, previousReserve)
+		if currentReserve < boost {
+			currentReserve = 0
+		} else {
+			currentReserve -= boost
+		}
+
+		if i < uint64(len(flows)) {
+			futureDeposit, err = mathutil.Add64(futureDeposit, flows[i].deposit)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not apply deposit of projected epoch %d", epoch+1)
+			}
+			futureDeposit = mathutil.Max(minDeposit, helpers.DecreaseBalanceWithVal(futureDeposit, flows[i].exit))
+		}
+		factor = helpers.NextRewardAdjustmentFactor(factor, futureDeposit, epoch)
+		previousReserve = currentReserve
+		epoch++
+
+		projected = append(projected, &ProjectionEpoch{
+			Epoch:                  strconv.FormatUint(uint64(epoch), 10),
+			RewardAdjustmentFactor: strconv.FormatUint(factor, 10),
+			PreviousEpochReserve:   strconv.FormatUint(previousReserve, 10),
+			CurrentEpochReserve:    strconv.FormatUint(currentReserve, 10),
+			EpochIssuance:          strconv.FormatUint(helpers.EpochIssuance(epoch), 10),
+			EpochFeedbackBoost:     strconv.FormatUint(helpers.FeedbackBoost(factor, previousReserve), 10),
+			TargetDepositPlan:      strconv.FormatUint(helpers.TargetDepositPlan(epoch), 10),
+			MaxBoostYield:          strconv.FormatUint(helpers.MaxBoostYield(epoch), 10),
+			TotalBalanceWithQueue:  strconv.FormatUint(futureDeposit, 10),
+		})
+	}
+	return projected, nil
+}
+
+func decodeFlows(r *http.Request) ([]flow, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	var rawFlows []*ProjectionFlow
+	if err := json.NewDecoder(r.Body).Decode(&rawFlows); err != nil {
+		return nil, err
+	}
+	flows := make([]flow, len(rawFlows))
+	for i, f := range rawFlows {
+		if f == nil {
+			continue
+		}
+		var err error
+		if f.Deposit != "" {
+			if flows[i].deposit, err = strconv.ParseUint(f.Deposit, 10, 64); err != nil {
+				return nil, errors.Wrapf(err, "invalid deposit at index %d", i)
+			}
+		}
+		if f.Exit != "" {
+			if flows[i].exit, err = strconv.ParseUint(f.Exit, 10, 64); err != nil {
+				return nil, errors.Wrapf(err, "invalid exit at index %d", i)
+			}
+		}
+	}
+	return flows, nil
+}
+
+// GetHistory returns the tokenomics recorded at the epoch transitions between the epochs `from` and
+// `to` (inclusive), in ascending order of epoch. The previous_epoch_reserve of a record is the reserve
+// at the start of the epoch before it, which its reserve_usage is measured against. Records of epochs
+// which are not finalized yet follow the head and may change on a reorg. At most `page_size` records
+// are returned at once; the remaining ones are fetched by passing the returned next_page_token as
+// `page_token`. Records are only kept by nodes running with the tokenomics history feature enabled.
+func (s *Server) GetHistory(w http.ResponseWriter, r *http.Request) {
+	from, ok := epochFromQuery(w, r, "from")
+	if !ok {
+		return
+	}
+	to, ok := epochFromQuery(w, r, "to")
+	if !ok {
+		return
+	}
+	if from > to {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("from epoch %d is greater than to epoch %d", from, to),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	pageSize := cmd.Get().MaxRPCPageSize
+	if rawPageSize := r.URL.Query().Get("page_size"); rawPageSize != "" {
+		size, err := strconv.Atoi(rawPageSize)
+		if err != nil || size <= 0 {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: "page_size must be a positive integer",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		if size > cmd.Get().MaxRPCPageSize {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("requested page size %d can not be greater than max size %d", size, cmd.Get().MaxRPCPageSize),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		pageSize = size
+	}
+	if r.URL.Query().Get("page_token") != "" {
+		start, ok := epochFromQuery(w, r, "page_token")
+		if !ok {
+			return
+		}
+		if start < from || start > to {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("page_token %d is out of the requested range", start),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		from = start
+	}
+
+	// Fetch one more record than requested to know whether there is a next page.
+	records, err := s.BeaconDB.TokenomicsRecords(r.Context(), from, to, pageSize+1)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get tokenomics records", http.StatusInternalServerError))
+		return
+	}
+	resp := &GetHistoryResponse{Data: make([]*HistoryEpoch, 0, len(records))}
+	if len(records) > pageSize {
+		resp.NextPageToken = strconv.FormatUint(uint64(records[pageSize].Epoch), 10)
+		records = records[:pageSize]
+	}
+	for _, record := range records {
+		resp.Data = append(resp.Data, &HistoryEpoch{
+			Epoch:                  strconv.FormatUint(uint64(record.Epoch), 10),
+			RewardAdjustmentFactor: strconv.FormatUint(record.RewardAdjustmentFactor, 10),
+			PreviousEpochReserve:   strconv.FormatUint(record.PreviousEpochReserve, 10),
+			CurrentEpochReserve:    strconv.FormatUint(record.CurrentEpochReserve, 10),
+			EpochIssuance:          strconv.FormatUint(record.EpochIssuance, 10),
+			EpochFeedbackBoost:     strconv.FormatUint(record.EpochFeedbackBoost, 10),
+			ReserveUsage:           strconv.FormatUint(record.ReserveUsage, 10),
+			TotalActiveBalance:     strconv.FormatUint(record.TotalActiveBalance, 10),
+			TargetDepositPlan:      strconv.FormatUint(record.TargetDepositPlan, 10),
+		})
+	}
+	network.WriteJson(w, resp)
+}
+
+// epochFromQuery parses the required epoch query parameter of the given name.
+// It writes an error to the response and returns false if the parameter is missing or invalid.
+func epochFromQuery(w http.ResponseWriter, r *http.Request, name string) (primitives.Epoch, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: name + " is required in query params",
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	epoch, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not parse "+name, http.StatusBadRequest))
+		return 0, false
+	}
+	return primitives.Epoch(epoch), true
+}
+
+func handleWrapError(err error, message string, code int) *network.DefaultErrorJson {
+	return &network.DefaultErrorJson{
+		Message: errors.Wrapf(err, message).Error(),
+		Code:    code,
+	}
+}
@@ -0,0 +1,235 @@
+package tokenomics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestGetProjection_BadRequest(t *testing.T) {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(primitives.Slot(5000)))
+	mockChainService := &mock.ChainService{Optimistic: true}
+
+	testCases := []struct {
+		name         string
+		path         string
+		body         string
+		errorMessage string
+	}{
+		{
+			name:         "no epochs",
+			path:         "/over/v1/tokenomics/projection",
+			errorMessage: "epochs is required in query params",
+		},
+		{
+			name:         "invalid epochs",
+			path:         "/over/v1/tokenomics/projection?epochs=foo",
+			errorMessage: "could not parse epochs",
+		},
+		{
+			name:         "too many epochs",
+			path:         "/over/v1/tokenomics/projection?epochs=100001",
+			errorMessage: "epochs must be between 1 and 100000",
+		},
+		{
+			name:         "more flows than epochs",
+			path:         "/over/v1/tokenomics/projection?epochs=1",
+			body:         `[{"deposit":"1"},{"exit":"1"}]`,
+			errorMessage: "got 2 flows for a projection of 1 epochs",
+		},
+		{
+			name:         "invalid flow",
+			path:         "/over/v1/tokenomics/projection?epochs=1",
+			body:         `[{"deposit":"-1"}]`,
+			errorMessage: "invalid deposit at index 0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := &Server{
+				FinalizationFetcher:   mockChainService,
+				OptimisticModeFetcher: mockChainService,
+				Stater:                &testutil.MockStater{BeaconState: st},
+			}
+			var request *http.Request
+			if testCase.body == "" {
+				request = httptest.NewRequest("GET", testCase.path, nil)
+			} else {
+				request = httptest.NewRequest("POST", testCase.path, strings.NewReader(testCase.body))
+			}
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetProjection(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.Equal(t, http.StatusBadRequest, e.Code)
+			assert.StringContains(t, testCase.errorMessage, e.Message)
+		})
+	}
+}
+
+func TestGetProjection(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch*10))
+	require.NoError(t, st.SetRewardAdjustmentFactor(params.BeaconConfig().RewardFeedbackPrecision/1000))
+	require.NoError(t, st.SetPreviousEpochReserve(10000000*1e9))
+	require.NoError(t, st.SetCurrentEpochReserve(9000000*1e9))
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		FinalizationFetcher:   mockChainService,
+		OptimisticModeFetcher: mockChainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+
+	t.Run("without flows", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/over/v1/tokenomics/projection?state_id=head&epochs=3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetProjection(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetProjectionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, "10", resp.Data.StartEpoch)
+		require.Equal(t, 3, len(resp.Data.Epochs))
+
+		// The first projected epoch must match the state transition helpers.
+		wantFactor, err := helpers.CalculateRewardAdjustmentFactor(st)
+		require.NoError(t, err)
+		wantReserve := st.CurrentEpochReserve() - helpers.EpochFeedbackBoost(st)
+		first := resp.Data.Epochs[0]
+		assert.Equal(t, "11", first.Epoch)
+		assert.Equal(t, strconv.FormatUint(wantFactor, 10), first.RewardAdjustmentFactor)
+		assert.Equal(t, strconv.FormatUint(wantReserve, 10), first.CurrentEpochReserve)
+		assert.Equal(t, strconv.FormatUint(wantReserve, 10), first.PreviousEpochReserve)
+		assert.Equal(t, strconv.FormatUint(helpers.EpochIssuance(11), 10), first.EpochIssuance)
+		assert.Equal(t, strconv.FormatUint(helpers.FeedbackBoost(wantFactor, wantReserve), 10), first.EpochFeedbackBoost)
+		assert.Equal(t, strconv.FormatUint(helpers.TargetDepositPlan(11), 10), first.TargetDepositPlan)
+
+		// The requested state is left untouched.
+		assert.Equal(t, params.BeaconConfig().RewardFeedbackPrecision/1000, st.RewardAdjustmentFactor())
+		assert.Equal(t, uint64(9000000*1e9), st.CurrentEpochReserve())
+	})
+	t.Run("with flows", func(t *testing.T) {
+		// A deposit far above the target deposit plan must lower the factor.
+		body := `[{"deposit":"` + strconv.FormatUint(helpers.TargetDepositPlan(11)*2, 10) + `"}]`
+		request := httptest.NewRequest("POST", "/over/v1/tokenomics/projection?epochs=1", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetProjection(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetProjectionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data.Epochs))
+		factor, err := strconv.ParseUint(resp.Data.Epochs[0].RewardAdjustmentFactor, 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, true, factor < st.RewardAdjustmentFactor())
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	for epoch := primitives.Epoch(1); epoch <= 5; epoch++ {
+		require.NoError(t, beaconDB.SaveTokenomicsRecord(ctx, &dbtypes.TokenomicsRecord{
+			Epoch:                  epoch,
+			RewardAdjustmentFactor: uint64(epoch) * 10,
+			PreviousEpochReserve:   uint64(epoch) * 1000,
+			ReserveUsage:           uint64(epoch) * 100,
+		}))
+	}
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("single page", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/over/v1/tokenomics/history?from=2&to=4", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetHistory(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, "2", resp.Data[0].Epoch)
+		assert.Equal(t, "20", resp.Data[0].RewardAdjustmentFactor)
+		assert.Equal(t, "2000", resp.Data[0].PreviousEpochReserve)
+		assert.Equal(t, "200", resp.Data[0].ReserveUsage)
+		assert.Equal(t, "4", resp.Data[2].Epoch)
+		assert.Equal(t, "", resp.NextPageToken)
+	})
+	t.Run("paginated", func(t *testing.T) {
+		var epochs []string
+		pageToken := ""
+		for i := 0; i < 3; i++ {
+			path := "/over/v1/tokenomics/history?from=0&to=10&page_size=2"
+			if pageToken != "" {
+				path += "&page_token=" + pageToken
+			}
+			request := httptest.NewRequest("GET", path, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetHistory(writer, request)
+			assert.Equal(t, http.StatusOK, writer.Code)
+			resp := &GetHistoryResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			for _, e := range resp.Data {
+				epochs = append(epochs, e.Epoch)
+			}
+			pageToken = resp.NextPageToken
+		}
+		assert.DeepEqual(t, []string{"1", "2", "3", "4", "5"}, epochs)
+		assert.Equal(t, "", pageToken)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		testCases := []struct {
+			path         string
+			errorMessage string
+		}{
+			{path: "/over/v1/tokenomics/history?to=1", errorMessage: "from is required in query params"},
+			{path: "/over/v1/tokenomics/history?from=1", errorMessage: "to is required in query params"},
+			{path: "/over/v1/tokenomics/history?from=foo&to=1", errorMessage: "could not parse from"},
+			{path: "/over/v1/tokenomics/history?from=2&to=1", errorMessage: "from epoch 2 is greater than to epoch 1"},
+			{path: "/over/v1/tokenomics/history?from=1&to=2&page_size=0", errorMessage: "page_size must be a positive integer"},
+			{path: "/over/v1/tokenomics/history?from=1&to=2&page_size=100000", errorMessage: "can not be greater than max size"},
+			{path: "/over/v1/tokenomics/history?from=1&to=2&page_token=3", errorMessage: "page_token 3 is out of the requested range"},
+		}
+		for _, testCase := range testCases {
+			request := httptest.NewRequest("GET", testCase.path, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetHistory(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, testCase.errorMessage, e.Message)
+		}
+	})
+}
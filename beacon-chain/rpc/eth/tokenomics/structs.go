@@ -0,0 +1,49 @@
+package tokenomics
+
+type GetProjectionResponse struct {
+	Data                *Projection `json:"data"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+}
+
+type Projection struct {
+	StartEpoch string             `json:"start_epoch"`
+	Epochs     []*ProjectionEpoch `json:"epochs"`
+}
+
+type ProjectionEpoch struct {
+	Epoch                  string `json:"epoch"`
+	RewardAdjustmentFactor string `json:"reward_adjustment_factor"`
+	PreviousEpochReserve   string `json:"previous_epoch_reserve"`
+	CurrentEpochReserve    string `json:"current_epoch_reserve"`
+	EpochIssuance          string `json:"epoch_issuance"`
+	EpochFeedbackBoost     string `json:"epoch_feedback_boost"`
+	TargetDepositPlan      string `json:"target_deposit_plan"`
+	MaxBoostYield          string `json:"max_boost_yield"`
+	TotalBalanceWithQueue  string `json:"total_balance_with_queue"`
+}
+
+// ProjectionFlow is a hypothetical amount of deposits and exits (in Gwei)
+// entering the queue during a single projected epoch.
+type ProjectionFlow struct {
+	Deposit string `json:"deposit"`
+	Exit    string `json:"exit"`
+}
+
+type GetHistoryResponse struct {
+	Data []*HistoryEpoch `json:"data"`
+	// NextPageToken is the epoch to resume the query from, empty if there are no more records.
+	NextPageToken string `json:"next_page_token"`
+}
+
+type HistoryEpoch struct {
+	Epoch                  string `json:"epoch"`
+	RewardAdjustmentFactor string `json:"reward_adjustment_factor"`
+	PreviousEpochReserve   string `json:"previous_epoch_reserve"`
+	CurrentEpochReserve    string `json:"current_epoch_reserve"`
+	EpochIssuance          string `json:"epoch_issuance"`
+	EpochFeedbackBoost     string `json:"epoch_feedback_boost"`
+	ReserveUsage           string `json:"reserve_usage"`
+	TotalActiveBalance     string `json:"total_active_balance"`
+	TargetDepositPlan      string `json:"target_deposit_plan"`
+}
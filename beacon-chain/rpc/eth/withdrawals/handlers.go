@@ -0,0 +1,258 @@
+package withdrawals
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// GetNextWithdrawal predicts the next withdrawal of the validator given by index or hex encoded
+// public key, by simulating the withdrawal sweep forward from the head state. By default a payload
+// is expected at every slot, and the `skip_rate` query parameter allows to account for the
+// expected fraction of slots without a block.
+func (s *Server) GetNextWithdrawal(w http.ResponseWriter, r *http.Request) {
+	rawId := mux.Vars(r)["id"]
+	if rawId == "" {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "id is required in URL params",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	skipRate := float64(0)
+	if rawSkipRate := r.URL.Query().Get("skip_rate"); rawSkipRate != "" {
+		var err error
+		skipRate, err = strconv.ParseFloat(rawSkipRate, 64)
+		if err != nil {
+			network.WriteError(w, handleWrapError(err, "could not decode skip_rate", http.StatusBadRequest))
+			return
+		}
+		if skipRate < 0 || skipRate >= 1 {
+			network.WriteError(w, handleWrapError(errInvalidSkipRate, "could not decode skip_rate", http.StatusBadRequest))
+			return
+		}
+	}
+
+	headState, err := s.HeadFetcher.HeadStateReadOnly(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get head state", http.StatusInternalServerError))
+		return
+	}
+	if headState.Version() < version.Capella {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "withdrawals are not supported before Capella",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	idx, err := validatorIndex(headState, rawId)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, errUnknownValidator) {
+			code = http.StatusNotFound
+		}
+		network.WriteError(w, handleWrapError(err, "could not decode validator id", code))
+		return
+	}
+
+	// Payloads can only be expected after both the head and the wall clock slot.
+	baseSlot := headState.Slot()
+	if currentSlot := s.TimeFetcher.CurrentSlot(); currentSlot > baseSlot {
+		baseSlot = currentSlot
+	}
+	prediction, err := predictWithdrawal(headState, idx, baseSlot, skipRate)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, errUnknownValidator) || errors.Is(err, errNoWithdrawal) || errors.Is(err, errBeyondHorizon) {
+			code = http.StatusNotFound
+		}
+		network.WriteError(w, handleWrapError(err, "could not predict withdrawal", code))
+		return
+	}
+	val, err := headState.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get validator", http.StatusInternalServerError))
+		return
+	}
+
+	// Get metadata for response
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get optimistic mode info", http.StatusInternalServerError))
+		return
+	}
+	headRoot, err := s.HeadFetcher.HeadRoot(r.Context())
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get head root", http.StatusInternalServerError))
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(r.Context(), bytesutil.ToBytes32(headRoot))
+
+	network.WriteJson(w, &GetNextWithdrawalResponse{
+		Data: &NextWithdrawal{
+			ValidatorIndex:   strconv.FormatUint(uint64(idx), 10),
+			WithdrawalIndex:  strconv.FormatUint(prediction.withdrawalIndex, 10),
+			Address:          hexutil.Encode(val.WithdrawalCredentials()[state_native.ETH1AddressOffset:]),
+			Amount:           strconv.FormatUint(prediction.amount, 10),
+			IsFullWithdrawal: prediction.full,
+			Slot:             strconv.FormatUint(uint64(prediction.slot), 10),
+			Epoch:            strconv.FormatUint(uint64(slots.ToEpoch(prediction.slot)), 10),
+			PayloadsAhead:    strconv.FormatUint(prediction.payloads, 10),
+			SkipRate:         strconv.FormatFloat(skipRate, 'f', -1, 64),
+		},
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	})
+}
+
+// validatorIndex decodes a validator index or hex encoded public key into a validator index.
+func validatorIndex(st state.ReadOnlyBeaconState, rawId string) (primitives.ValidatorIndex, error) {
+	if strings.HasPrefix(rawId, "0x") {
+		pubkey, err := hexutil.Decode(rawId)
+		if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+			return 0, fmt.Errorf("%s is not a validator index or pubkey", rawId)
+		}
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+		if !ok {
+			return 0, errUnknownValidator
+		}
+		return idx, nil
+	}
+	index, err := strconv.ParseUint(rawId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a validator index or pubkey", rawId)
+	}
+	return primitives.ValidatorIndex(index), nil
+}
+
+// GetWithdrawals returns the withdrawals executed on the canonical chain, optionally filtered by
+// execution `address`, `validator` index and `from_slot`/`to_slot` range (inclusive), in ascending
+// order of withdrawal index. At most `page_size` withdrawals are returned at once; the remaining ones
+// are fetched by passing the returned next_page_token as `page_token`. Withdrawals are only indexed
+// by nodes running with the withdrawals history feature enabled.
+func (s *Server) GetWithdrawals(w http.ResponseWriter, r *http.Request) {
+	f := &dbtypes.WithdrawalsFilter{ToSlot: math.MaxUint64}
+	if rawAddress := r.URL.Query().Get("address"); rawAddress != "" {
+		if !common.IsHexAddress(rawAddress) {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("%s is not a valid execution address", rawAddress),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		address := common.HexToAddress(rawAddress)
+		f.Address = &address
+	}
+	if r.URL.Query().Get("validator") != "" {
+		index, ok := uintFromQuery(w, r, "validator")
+		if !ok {
+			return
+		}
+		validator := primitives.ValidatorIndex(index)
+		f.ValidatorIndex = &validator
+	}
+	if r.URL.Query().Get("from_slot") != "" {
+		slot, ok := uintFromQuery(w, r, "from_slot")
+		if !ok {
+			return
+		}
+		f.FromSlot = primitives.Slot(slot)
+	}
+	if r.URL.Query().Get("to_slot") != "" {
+		slot, ok := uintFromQuery(w, r, "to_slot")
+		if !ok {
+			return
+		}
+		f.ToSlot = primitives.Slot(slot)
+	}
+	if f.FromSlot > f.ToSlot {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("from_slot %d is greater than to_slot %d", f.FromSlot, f.ToSlot),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	pageSize := cmd.Get().MaxRPCPageSize
+	if rawPageSize := r.URL.Query().Get("page_size"); rawPageSize != "" {
+		size, err := strconv.Atoi(rawPageSize)
+		if err != nil || size <= 0 {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: "page_size must be a positive integer",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		if size > cmd.Get().MaxRPCPageSize {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("requested page size %d can not be greater than max size %d", size, cmd.Get().MaxRPCPageSize),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		pageSize = size
+	}
+	if r.URL.Query().Get("page_token") != "" {
+		start, ok := uintFromQuery(w, r, "page_token")
+		if !ok {
+			return
+		}
+		f.StartIndex = start
+	}
+
+	// Fetch one more record than requested to know whether there is a next page.
+	records, err := s.BeaconDB.WithdrawalRecords(r.Context(), f, pageSize+1)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not get withdrawal records", http.StatusInternalServerError))
+		return
+	}
+	resp := &GetWithdrawalsResponse{Data: make([]*Withdrawal, 0, len(records))}
+	if len(records) > pageSize {
+		resp.NextPageToken = strconv.FormatUint(records[pageSize].Index, 10)
+		records = records[:pageSize]
+	}
+	for _, record := range records {
+		resp.Data = append(resp.Data, &Withdrawal{
+			Index:          strconv.FormatUint(record.Index, 10),
+			ValidatorIndex: strconv.FormatUint(uint64(record.ValidatorIndex), 10),
+			Address:        hexutil.Encode(record.Address.Bytes()),
+			Amount:         strconv.FormatUint(record.Amount, 10),
+			Slot:           strconv.FormatUint(uint64(record.Slot), 10),
+		})
+	}
+	network.WriteJson(w, resp)
+}
+
+// uintFromQuery parses the unsigned integer query parameter of the given name.
+// It writes an error to the response and returns false if the parameter is invalid.
+func uintFromQuery(w http.ResponseWriter, r *http.Request, name string) (uint64, bool) {
+	v, err := strconv.ParseUint(r.URL.Query().Get(name), 10, 64)
+	if err != nil {
+		network.WriteError(w, handleWrapError(err, "could not parse "+name, http.StatusBadRequest))
+		return 0, false
+	}
+	return v, true
+}
+
+func handleWrapError(err error, message string, code int) *network.DefaultErrorJson {
+	return &network.DefaultErrorJson{
+		Message: errors.Wrapf(err, message).Error(),
+		Code:    code,
+	}
+}
@@ -0,0 +1,297 @@
+package withdrawals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// sweepTestState returns a state of 8 validators with ETH1 withdrawal credentials, where validators
+// 0, 2, 5 and 6 have an excess balance and validator 3 is fully withdrawable. With two withdrawals
+// per payload and a sweep of four validators, the first payloads withdraw from validators 0 and 2,
+// then 3 and 5, then 6.
+func sweepTestState(t *testing.T) state.BeaconState {
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxWithdrawalsPerPayload = 2
+	cfg.MaxValidatorsPerWithdrawalsSweep = 4
+	params.OverrideBeaconConfig(cfg)
+
+	st, _ := util.DeterministicGenesisStateCapella(t, 8)
+	balances := make([]uint64, 8)
+	for i := range balances {
+		val, err := st.ValidatorAtIndex(primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		val.WithdrawalCredentials = make([]byte, 32)
+		val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+		val.WithdrawalCredentials[31] = byte(i)
+		val.EffectiveBalance = params.BeaconConfig().MaxEffectiveBalance
+		if i == 3 {
+			val.WithdrawableEpoch = 0
+		}
+		require.NoError(t, st.UpdateValidatorAtIndex(primitives.ValidatorIndex(i), val))
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	for _, i := range []int{0, 2, 5, 6} {
+		balances[i] += uint64(i+1) * 1000
+	}
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetNextWithdrawalIndex(10))
+	return st
+}
+
+func TestPredictWithdrawal(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	st := sweepTestState(t)
+
+	t.Run("partial withdrawal", func(t *testing.T) {
+		prediction, err := predictWithdrawal(st, 5, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), prediction.payloads)
+		assert.Equal(t, primitives.Slot(2), prediction.slot)
+		assert.Equal(t, uint64(13), prediction.withdrawalIndex)
+		assert.Equal(t, uint64(6000), prediction.amount)
+		assert.Equal(t, false, prediction.full)
+	})
+	t.Run("full withdrawal", func(t *testing.T) {
+		prediction, err := predictWithdrawal(st, 3, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), prediction.payloads)
+		assert.Equal(t, uint64(12), prediction.withdrawalIndex)
+		assert.Equal(t, params.BeaconConfig().MaxEffectiveBalance, prediction.amount)
+		assert.Equal(t, true, prediction.full)
+	})
+	t.Run("sweep skips validators", func(t *testing.T) {
+		prediction, err := predictWithdrawal(st, 6, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), prediction.payloads)
+		assert.Equal(t, uint64(14), prediction.withdrawalIndex)
+	})
+	t.Run("skip rate", func(t *testing.T) {
+		prediction, err := predictWithdrawal(st, 5, 100, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), prediction.payloads)
+		assert.Equal(t, primitives.Slot(104), prediction.slot)
+	})
+	t.Run("future full withdrawal", func(t *testing.T) {
+		st := st.Copy()
+		val, err := st.ValidatorAtIndex(7)
+		require.NoError(t, err)
+		val.WithdrawableEpoch = 2
+		require.NoError(t, st.UpdateValidatorAtIndex(7, val))
+		// Past the third payload the sweep starts alternatively at validators 2 and 6, and it
+		// first reaches validator 7 once withdrawable at the 65th payload.
+		prediction, err := predictWithdrawal(st, 7, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(65), prediction.payloads)
+		assert.Equal(t, primitives.Slot(65), prediction.slot)
+		assert.Equal(t, uint64(15), prediction.withdrawalIndex)
+		assert.Equal(t, true, prediction.full)
+	})
+	t.Run("no withdrawal", func(t *testing.T) {
+		_, err := predictWithdrawal(st, 7, 0, 0)
+		require.ErrorIs(t, err, errNoWithdrawal)
+	})
+	t.Run("beyond horizon", func(t *testing.T) {
+		st := st.Copy()
+		val, err := st.ValidatorAtIndex(7)
+		require.NoError(t, err)
+		val.WithdrawableEpoch = maxPredictionEpochs + 1
+		require.NoError(t, st.UpdateValidatorAtIndex(7, val))
+		_, err = predictWithdrawal(st, 7, 0, 0)
+		require.ErrorIs(t, err, errBeyondHorizon)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		_, err := predictWithdrawal(st, 8, 0, 0)
+		require.ErrorIs(t, err, errUnknownValidator)
+	})
+	t.Run("invalid skip rate", func(t *testing.T) {
+		_, err := predictWithdrawal(st, 5, 0, 1)
+		require.ErrorIs(t, err, errInvalidSkipRate)
+	})
+}
+
+func TestGetNextWithdrawal(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	st := sweepTestState(t)
+	slot := primitives.Slot(0)
+	root := bytes.Repeat([]byte{'a'}, 32)
+	chainService := &mock.ChainService{
+		State:          st,
+		Root:           root,
+		Slot:           &slot,
+		Optimistic:     true,
+		FinalizedRoots: map[[32]byte]bool{},
+	}
+	s := &Server{
+		FinalizationFetcher:   chainService,
+		OptimisticModeFetcher: chainService,
+		HeadFetcher:           chainService,
+		TimeFetcher:           chainService,
+	}
+
+	getNextWithdrawal := func(id, query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", "/over/v1/validators/{id}/next_withdrawal"+query, nil)
+		request = mux.SetURLVars(request, map[string]string{"id": id})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetNextWithdrawal(writer, request)
+		return writer
+	}
+
+	t.Run("by index", func(t *testing.T) {
+		writer := getNextWithdrawal("5", "")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetNextWithdrawalResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.Finalized)
+		assert.Equal(t, "5", resp.Data.ValidatorIndex)
+		assert.Equal(t, "13", resp.Data.WithdrawalIndex)
+		assert.Equal(t, "0x0000000000000000000000000000000000000005", resp.Data.Address)
+		assert.Equal(t, "6000", resp.Data.Amount)
+		assert.Equal(t, false, resp.Data.IsFullWithdrawal)
+		assert.Equal(t, "2", resp.Data.Slot)
+		assert.Equal(t, "0", resp.Data.Epoch)
+		assert.Equal(t, "2", resp.Data.PayloadsAhead)
+		assert.Equal(t, "0", resp.Data.SkipRate)
+	})
+	t.Run("by pubkey with skip rate", func(t *testing.T) {
+		pubkey := st.PubkeyAtIndex(3)
+		writer := getNextWithdrawal(hexutil.Encode(pubkey[:]), "?skip_rate=0.2")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetNextWithdrawalResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "3", resp.Data.ValidatorIndex)
+		assert.Equal(t, true, resp.Data.IsFullWithdrawal)
+		assert.Equal(t, strconv.FormatUint(params.BeaconConfig().MaxEffectiveBalance, 10), resp.Data.Amount)
+		assert.Equal(t, "3", resp.Data.Slot)
+		assert.Equal(t, "0.2", resp.Data.SkipRate)
+	})
+	t.Run("invalid skip rate", func(t *testing.T) {
+		writer := getNextWithdrawal("5", "?skip_rate=1")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "skip rate must be in [0, 1)", e.Message)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		writer := getNextWithdrawal("foo", "")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		writer := getNextWithdrawal("8", "")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("no withdrawal", func(t *testing.T) {
+		writer := getNextWithdrawal("7", "")
+		require.Equal(t, http.StatusNotFound, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "no withdrawal is expected for validator", e.Message)
+	})
+}
+
+func TestGetWithdrawals(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	addr1 := common.HexToAddress("0x01")
+	addr2 := common.HexToAddress("0x02")
+	require.NoError(t, beaconDB.SaveWithdrawalRecords(ctx, []*dbtypes.WithdrawalRecord{
+		{Index: 0, ValidatorIndex: 1, Address: addr1, Amount: 100, Slot: 10},
+		{Index: 1, ValidatorIndex: 2, Address: addr2, Amount: 200, Slot: 10},
+		{Index: 2, ValidatorIndex: 3, Address: addr1, Amount: 300, Slot: 11},
+		{Index: 3, ValidatorIndex: 1, Address: addr1, Amount: 400, Slot: 12},
+		{Index: 4, ValidatorIndex: 2, Address: addr2, Amount: 500, Slot: 12},
+	}))
+	s := &Server{BeaconDB: beaconDB}
+
+	getWithdrawals := func(query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", "/over/v1/withdrawals"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetWithdrawals(writer, request)
+		return writer
+	}
+
+	t.Run("filtered", func(t *testing.T) {
+		writer := getWithdrawals("?address=" + addr1.Hex() + "&from_slot=11")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &Withdrawal{
+			Index:          "2",
+			ValidatorIndex: "3",
+			Address:        "0x0000000000000000000000000000000000000001",
+			Amount:         "300",
+			Slot:           "11",
+		}, resp.Data[0])
+		assert.Equal(t, "3", resp.Data[1].Index)
+		assert.Equal(t, "", resp.NextPageToken)
+
+		writer = getWithdrawals("?validator=2&to_slot=11")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &GetWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+	})
+	t.Run("paginated", func(t *testing.T) {
+		var indices []string
+		pageToken := ""
+		for i := 0; i < 3; i++ {
+			query := "?page_size=2"
+			if pageToken != "" {
+				query += "&page_token=" + pageToken
+			}
+			writer := getWithdrawals(query)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &GetWithdrawalsResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			for _, w := range resp.Data {
+				indices = append(indices, w.Index)
+			}
+			pageToken = resp.NextPageToken
+		}
+		assert.DeepEqual(t, []string{"0", "1", "2", "3", "4"}, indices)
+		assert.Equal(t, "", pageToken)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		testCases := []struct {
+			query        string
+			errorMessage string
+		}{
+			{query: "?address=foo", errorMessage: "foo is not a valid execution address"},
+			{query: "?validator=foo", errorMessage: "could not parse validator"},
+			{query: "?from_slot=2&to_slot=1", errorMessage: "from_slot 2 is greater than to_slot 1"},
+			{query: "?page_size=0", errorMessage: "page_size must be a positive integer"},
+			{query: "?page_size=100000", errorMessage: "can not be greater than max size"},
+			{query: "?page_token=foo", errorMessage: "could not parse page_token"},
+		}
+		for _, testCase := range testCases {
+			writer := getWithdrawals(testCase.query)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, testCase.errorMessage, e.Message)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package withdrawals
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+)
+
+type Server struct {
+	FinalizationFetcher   blockchain.FinalizationFetcher
+	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+	HeadFetcher           blockchain.HeadFetcher
+	TimeFetcher           blockchain.TimeFetcher
+	BeaconDB              db.ReadOnlyDatabase
+}
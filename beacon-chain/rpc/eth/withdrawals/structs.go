@@ -0,0 +1,33 @@
+package withdrawals
+
+type GetNextWithdrawalResponse struct {
+	Data                *NextWithdrawal `json:"data"`
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
+}
+
+type NextWithdrawal struct {
+	ValidatorIndex   string `json:"validator_index"`
+	WithdrawalIndex  string `json:"withdrawal_index"`
+	Address          string `json:"address"`
+	Amount           string `json:"amount"`
+	IsFullWithdrawal bool   `json:"is_full_withdrawal"`
+	Slot             string `json:"slot"`
+	Epoch            string `json:"epoch"`
+	PayloadsAhead    string `json:"payloads_ahead"`
+	SkipRate         string `json:"skip_rate"`
+}
+
+type GetWithdrawalsResponse struct {
+	Data []*Withdrawal `json:"data"`
+	// NextPageToken is the withdrawal index to resume the query from, empty if there are no more records.
+	NextPageToken string `json:"next_page_token"`
+}
+
+type Withdrawal struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validator_index"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+	Slot           string `json:"slot"`
+}
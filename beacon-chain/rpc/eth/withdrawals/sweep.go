@@ -0,0 +1,135 @@
+package withdrawals
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	mathutil "github.com/prysmaticlabs/prysm/v4/math"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// maxPredictionEpochs bounds how far in the future a validator may become fully withdrawable
+// for its withdrawal to be predicted.
+const maxPredictionEpochs = primitives.Epoch(512)
+
+var (
+	errNoWithdrawal     = errors.New("no withdrawal is expected for validator")
+	errBeyondHorizon    = errors.New("withdrawal is expected beyond the prediction horizon")
+	errInvalidSkipRate  = errors.New("skip rate must be in [0, 1)")
+	errUnknownValidator = errors.New("unknown validator")
+)
+
+// sweepPrediction is the withdrawal of a validator expected by simulating the withdrawal sweep.
+type sweepPrediction struct {
+	withdrawalIndex uint64
+	amount          uint64
+	full            bool
+	payloads        uint64
+	slot            primitives.Slot
+}
+
+// predictWithdrawal simulates the withdrawal sweep forward from the given state, one payload at a
+// time, until the validator at the given index is withdrawn. Payloads are expected right after
+// baseSlot, at a rate given by the skip rate, and balances are assumed not to change other than
+// through the simulated withdrawals.
+func predictWithdrawal(
+	st state.ReadOnlyBeaconState,
+	target primitives.ValidatorIndex,
+	baseSlot primitives.Slot,
+	skipRate float64,
+) (*sweepPrediction, error) {
+	if skipRate < 0 || skipRate >= 1 || math.IsNaN(skipRate) {
+		return nil, errInvalidSkipRate
+	}
+	numValidators := uint64(st.NumValidators())
+	if uint64(target) >= numValidators {
+		return nil, errUnknownValidator
+	}
+	// Balances are a copy, so that they can be updated as withdrawals are simulated.
+	balances := st.Balances()
+	if uint64(len(balances)) != numValidators {
+		return nil, errors.New("balances and validators length mismatch")
+	}
+
+	cfg := params.BeaconConfig()
+	// Withdrawal eligibility only depends on the epoch and the balance once the validator registry
+	// has been read, as withdrawal credentials are not expected to change during the simulation.
+	withdrawableEpochs := make([]primitives.Epoch, numValidators)
+	partiallyWithdrawable := make([]bool, numValidators)
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		creds := val.WithdrawalCredentials()
+		if len(creds) == 0 || creds[0] != cfg.ETH1AddressWithdrawalPrefixByte {
+			withdrawableEpochs[idx] = cfg.FarFutureEpoch
+			return nil
+		}
+		withdrawableEpochs[idx] = val.WithdrawableEpoch()
+		partiallyWithdrawable[idx] = val.EffectiveBalance() == cfg.MaxEffectiveBalance
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	isPartial := partiallyWithdrawable[target] && balances[target] > cfg.MaxEffectiveBalance
+	isFull := balances[target] > 0 && withdrawableEpochs[target] != cfg.FarFutureEpoch
+	if !isPartial && !isFull {
+		return nil, errNoWithdrawal
+	}
+	if !isPartial && withdrawableEpochs[target] > slots.ToEpoch(baseSlot)+maxPredictionEpochs {
+		return nil, errBeyondHorizon
+	}
+
+	validatorIndex, err := st.NextWithdrawalValidatorIndex()
+	if err != nil {
+		return nil, err
+	}
+	withdrawalIndex, err := st.NextWithdrawalIndex()
+	if err != nil {
+		return nil, err
+	}
+	bound := mathutil.Min(numValidators, cfg.MaxValidatorsPerWithdrawalsSweep)
+	// Once the target is withdrawable, the sweep reaches it within a full pass over the registry.
+	maxPayloads := uint64(maxPredictionEpochs)*uint64(cfg.SlotsPerEpoch) + numValidators + 1
+	for payloads := uint64(1); payloads <= maxPayloads; payloads++ {
+		slot := baseSlot + primitives.Slot(math.Ceil(float64(payloads)/(1-skipRate)))
+		epoch := slots.ToEpoch(slot)
+		count := uint64(0)
+		idx := validatorIndex
+		for i := uint64(0); i < bound; i++ {
+			balance := balances[idx]
+			var amount uint64
+			full := balance > 0 && withdrawableEpochs[idx] <= epoch
+			if full {
+				amount = balance
+			} else if partiallyWithdrawable[idx] && balance > cfg.MaxEffectiveBalance {
+				amount = balance - cfg.MaxEffectiveBalance
+			}
+			if amount > 0 {
+				if idx == target {
+					return &sweepPrediction{
+						withdrawalIndex: withdrawalIndex,
+						amount:          amount,
+						full:            full,
+						payloads:        payloads,
+						slot:            slot,
+					}, nil
+				}
+				balances[idx] -= amount
+				withdrawalIndex++
+				count++
+			}
+			if count == cfg.MaxWithdrawalsPerPayload {
+				break
+			}
+			idx = primitives.ValidatorIndex((uint64(idx) + 1) % numValidators)
+		}
+		if count == cfg.MaxWithdrawalsPerPayload {
+			validatorIndex = primitives.ValidatorIndex((uint64(idx) + 1) % numValidators)
+		} else {
+			validatorIndex = primitives.ValidatorIndex((uint64(validatorIndex) + cfg.MaxValidatorsPerWithdrawalsSweep) % numValidators)
+		}
+	}
+	return nil, errBeyondHorizon
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -13,16 +14,26 @@ import (
 	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/network"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
+// maxReplayedEpochRewards is the number of epochs missing from the tokenomics history which
+// GetEpochReward replays blocks for in a single request.
+const maxReplayedEpochRewards = 4
+
+var errTooManyReplayedEpochs = errors.New("too many epochs to replay, enable the tokenomics history to query them")
+
 // ListTrustedPeer retrieves data about the node's trusted peers.
 func (s *Server) ListTrustedPeer(w http.ResponseWriter, r *http.Request) {
 	peerStatus := s.PeersFetcher.Peers()
@@ -228,61 +239,222 @@ func (s *Server) ListPeerDetailInfo(w http.ResponseWriter, r *http.Request) {
 	network.WriteJson(w, res)
 }
 
-// GetEpochReward returns the epoch reward for the given epoch.
+// GetEpochReward returns the reward of the epoch given in the URL path, or of every epoch between
+// the `from` and `to` query params (inclusive) when no epoch is given in the path.
+//
+// Epochs are served from the tokenomics history index when it has a record of them, and the head
+// epoch from the head state. Blocks are only replayed for the other epochs, and at most
+// maxReplayedEpochRewards of them per request, as replaying is expensive.
 func (s *Server) GetEpochReward(w http.ResponseWriter, r *http.Request) {
-	var requestedEpoch primitives.Epoch
 	segments := strings.Split(r.URL.Path, "/")
 	epoch := segments[len(segments)-1]
 	curEpoch := slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot())
-	if epoch == "latest" {
-		requestedEpoch = curEpoch
-	} else {
-		uintEpoch, err := strconv.ParseUint(epoch, 10, 64)
-		if err != nil {
+
+	var from, to primitives.Epoch
+	switch epoch {
+	case "epoch_reward":
+		var ok bool
+		if from, ok = epochFromQuery(w, r, "from", curEpoch); !ok {
+			return
+		}
+		if to, ok = epochFromQuery(w, r, "to", curEpoch); !ok {
+			return
+		}
+		if from > to {
 			errJson := &network.DefaultErrorJson{
-				Message: errors.Wrapf(err, "parse int error").Error(),
+				Message: fmt.Sprintf("from epoch %d is greater than to epoch %d", from, to),
 				Code:    http.StatusBadRequest,
 			}
 			network.WriteError(w, errJson)
 			return
 		}
-
-		if uintEpoch > uint64(curEpoch) {
+		if uint64(to-from) >= uint64(cmd.Get().MaxRPCPageSize) {
 			errJson := &network.DefaultErrorJson{
-				Message: errors.Wrapf(err, "Cannot retrieve information for an future epoch, current epoch %d, requesting %d", curEpoch, uintEpoch).Error(),
+				Message: fmt.Sprintf("requested %d epochs, can not be more than %d", to-from+1, cmd.Get().MaxRPCPageSize),
 				Code:    http.StatusBadRequest,
 			}
 			network.WriteError(w, errJson)
 			return
 		}
-		requestedEpoch = primitives.Epoch(uintEpoch)
+	case "latest":
+		from, to = curEpoch, curEpoch
+	default:
+		uintEpoch, ok := parseEpoch(w, "epoch", epoch, curEpoch)
+		if !ok {
+			return
+		}
+		from, to = uintEpoch, uintEpoch
 	}
 
-	var reqState state.BeaconState
-	var slot primitives.Slot
-	var err error
-	slot, err = slots.EpochStart(requestedEpoch)
-	if err != nil {
+	rewards, err := s.epochRewards(r.Context(), from, to)
+	if errors.Is(err, errTooManyReplayedEpochs) {
 		errJson := &network.DefaultErrorJson{
-			Message: errors.Wrapf(err, "Could not get start slot of requested epoch").Error(),
-			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+			Code:    http.StatusNotFound,
 		}
 		network.WriteError(w, errJson)
 		return
 	}
-	ctx := context.Background()
-	reqState, err = s.ReplayerBuilder.ReplayerForSlot(slot).ReplayBlocks(ctx)
 	if err != nil {
 		errJson := &network.DefaultErrorJson{
-			Message: errors.Wrapf(err, "error replaying blocks for state at slot").Error(),
-			Code:    http.StatusBadRequest,
+			Message: errors.Wrapf(err, "Could not get epoch rewards").Error(),
+			Code:    http.StatusInternalServerError,
 		}
 		network.WriteError(w, errJson)
 		return
 	}
+	if epoch == "epoch_reward" {
+		network.WriteJson(w, &EpochRewardsResponse{Data: rewards})
+		return
+	}
+	network.WriteJson(w, rewards[0])
+}
 
-	reward, _ := helpers.TotalRewardWithReserveUsage(reqState)
+// epochRewards returns the rewards of the epochs between from and to (inclusive).
+func (s *Server) epochRewards(ctx context.Context, from, to primitives.Epoch) ([]*EpochReward, error) {
+	// The record of the epoch after `to` holds the reserve usage of `to`.
+	records, err := s.BeaconDB.TokenomicsRecords(ctx, from, to+1, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get tokenomics records")
+	}
+	recordsByEpoch := make(map[primitives.Epoch]*dbtypes.TokenomicsRecord, len(records))
+	for _, record := range records {
+		recordsByEpoch[record.Epoch] = record
+	}
+	headState, err := s.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	headEpoch := slots.ToEpoch(headState.Slot())
 
-	response := &EpochReward{Reward: strconv.FormatUint(reward, 10)}
-	network.WriteJson(w, response)
+	replays := 0
+	for epoch := from; epoch <= to; epoch++ {
+		if _, ok := recordsByEpoch[epoch]; !ok && epoch != headEpoch {
+			replays++
+		}
+	}
+	if replays > maxReplayedEpochRewards {
+		return nil, errors.Wrapf(errTooManyReplayedEpochs, "%d of the requested epochs are not in the tokenomics history", replays)
+	}
+
+	rewards := make([]*EpochReward, 0, to-from+1)
+	// reserveUsages holds the reserve usages known from replayed epoch transitions.
+	reserveUsages := make(map[primitives.Epoch]uint64)
+	for epoch := from; epoch <= to; epoch++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var issuance, boost uint64
+		if record, ok := recordsByEpoch[epoch]; ok {
+			issuance, boost = record.EpochIssuance, record.EpochFeedbackBoost
+		} else {
+			st := headState
+			if epoch != headEpoch {
+				preState, postState, err := s.replayEpochTransition(ctx, epoch)
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not get state of epoch %d", epoch)
+				}
+				if preState != nil {
+					reserveUsages[epoch-1] = helpers.EpochReserveUsage(preState, postState)
+				}
+				st = postState
+			}
+			var total uint64
+			total, boost = helpers.TotalRewardWithReserveUsage(st)
+			issuance = total - boost
+		}
+		rewards = append(rewards, newEpochReward(epoch, issuance, boost))
+	}
+	for i, reward := range rewards {
+		epoch := from + primitives.Epoch(i)
+		if next, ok := recordsByEpoch[epoch+1]; ok {
+			reward.ReserveUsage = strconv.FormatUint(next.ReserveUsage, 10)
+		} else if usage, ok := reserveUsages[epoch]; ok {
+			reward.ReserveUsage = strconv.FormatUint(usage, 10)
+		}
+	}
+	return rewards, nil
+}
+
+// replayEpochTransition replays the canonical chain up to the given epoch. It returns the state at
+// the last slot of the previous epoch and the state right after the epoch transition, whose reward
+// adjustment factor and reserves are those of the epoch start. The state of the previous epoch is
+// nil for the genesis epoch.
+func (s *Server) replayEpochTransition(ctx context.Context, epoch primitives.Epoch) (state.ReadOnlyBeaconState, state.ReadOnlyBeaconState, error) {
+	if epoch == 0 {
+		st, err := s.ReplayerBuilder.ReplayerForSlot(0).ReplayBlocks(ctx)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not replay blocks for state at slot")
+		}
+		return nil, st, nil
+	}
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get start slot of epoch")
+	}
+	preState, err := s.ReplayerBuilder.ReplayerForSlot(slot - 1).ReplayBlocks(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not replay blocks for state at slot")
+	}
+	postState, err := transition.ProcessSlots(ctx, preState.Copy(), slot)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not process epoch transition")
+	}
+	return preState, postState, nil
+}
+
+// newEpochReward breaks the reward of an epoch down into the shares of proposers, sync committees
+// and attesters, the latter being the remainder.
+func newEpochReward(epoch primitives.Epoch, issuance, boost uint64) *EpochReward {
+	cfg := params.BeaconConfig()
+	reward := issuance + boost
+	proposerShare := reward * cfg.ProposerWeight / cfg.WeightDenominator
+	syncShare := reward * cfg.SyncRewardWeight / cfg.WeightDenominator
+	return &EpochReward{
+		Epoch:         strconv.FormatUint(uint64(epoch), 10),
+		Reward:        strconv.FormatUint(reward, 10),
+		BaseIssuance:  strconv.FormatUint(issuance, 10),
+		FeedbackBoost: strconv.FormatUint(boost, 10),
+		ProposerShare: strconv.FormatUint(proposerShare, 10),
+		SyncShare:     strconv.FormatUint(syncShare, 10),
+		AttesterShare: strconv.FormatUint(reward-proposerShare-syncShare, 10),
+	}
+}
+
+// epochFromQuery parses the required epoch query parameter of the given name.
+// It writes an error to the response and returns false if the parameter is missing or invalid.
+func epochFromQuery(w http.ResponseWriter, r *http.Request, name string, curEpoch primitives.Epoch) (primitives.Epoch, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		errJson := &network.DefaultErrorJson{
+			Message: name + " is required in query params",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return 0, false
+	}
+	return parseEpoch(w, name, raw, curEpoch)
+}
+
+// parseEpoch parses a requested epoch, which can not be in the future.
+// It writes an error to the response and returns false if the epoch is invalid.
+func parseEpoch(w http.ResponseWriter, name, raw string, curEpoch primitives.Epoch) (primitives.Epoch, bool) {
+	uintEpoch, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not parse %s", name).Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return 0, false
+	}
+	if uintEpoch > uint64(curEpoch) {
+		errJson := &network.DefaultErrorJson{
+			Message: fmt.Sprintf("Cannot retrieve information for an future epoch, current epoch %d, requesting %d", curEpoch, uintEpoch),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return 0, false
+	}
+	return primitives.Epoch(uintEpoch), true
 }
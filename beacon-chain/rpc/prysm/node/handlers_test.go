@@ -17,14 +17,18 @@ import (
 	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
 	ma "github.com/multiformats/go-multiaddr"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	dbtypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
 	mockp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	mockstategen "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/network"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -327,7 +331,9 @@ func TestGetEpochReward(t *testing.T) {
 	require.NoError(t, beaconDB.SaveState(ctx, headState, gRoot))
 
 	s := &Server{
+		BeaconDB:           beaconDB,
 		GenesisTimeFetcher: &mock.ChainService{},
+		HeadFetcher:        &mock.ChainService{State: headState},
 		ReplayerBuilder:    mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(headState)),
 	}
 
@@ -340,6 +346,168 @@ func TestGetEpochReward(t *testing.T) {
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
 }
 
+func TestGetEpochReward_Breakdown(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	headState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch*3+1))
+	require.NoError(t, headState.SetRewardAdjustmentFactor(10))
+	require.NoError(t, headState.SetPreviousEpochReserve(params.BeaconConfig().MaxTokenSupply))
+	for epoch := primitives.Epoch(1); epoch <= 2; epoch++ {
+		require.NoError(t, beaconDB.SaveTokenomicsRecord(ctx, &dbtypes.TokenomicsRecord{
+			Epoch:              epoch,
+			EpochIssuance:      6400 * uint64(epoch),
+			EpochFeedbackBoost: 640,
+			ReserveUsage:       100 * uint64(epoch),
+		}))
+	}
+	genesisState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, genesisState.SetPreviousEpochReserve(params.BeaconConfig().MaxTokenSupply))
+	replayerBuilder := mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(genesisState))
+	slot := params.BeaconConfig().SlotsPerEpoch*3 + 1
+	s := &Server{
+		BeaconDB:           beaconDB,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &slot},
+		HeadFetcher:        &mock.ChainService{State: headState},
+		ReplayerBuilder:    replayerBuilder,
+	}
+	getEpochReward := func(t *testing.T, url string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", "http://anything.is.fine/chronos/states/"+url, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetEpochReward(writer, request)
+		return writer
+	}
+
+	t.Run("recorded epoch", func(t *testing.T) {
+		writer := getEpochReward(t, "epoch_reward/1")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &EpochReward{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, &EpochReward{
+			Epoch:         "1",
+			Reward:        "7040",
+			BaseIssuance:  "6400",
+			FeedbackBoost: "640",
+			ReserveUsage:  "200",
+			ProposerShare: "880",
+			SyncShare:     "0",
+			AttesterShare: "6160",
+		}, resp)
+	})
+	t.Run("range", func(t *testing.T) {
+		writer := getEpochReward(t, "epoch_reward?from=0&to=3")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &EpochRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+
+		// Epochs 0 and 3 are not recorded and are served from the replayed and head states.
+		for i, st := range []state.BeaconState{genesisState, headState} {
+			epoch := i * 3
+			total, boost := helpers.TotalRewardWithReserveUsage(st)
+			reward := resp.Data[epoch]
+			assert.Equal(t, strconv.Itoa(epoch), reward.Epoch)
+			assert.Equal(t, strconv.FormatUint(total, 10), reward.Reward)
+			assert.Equal(t, strconv.FormatUint(boost, 10), reward.FeedbackBoost)
+		}
+		assert.NotEqual(t, resp.Data[0].FeedbackBoost, resp.Data[3].FeedbackBoost)
+		assert.Equal(t, "12800", resp.Data[2].BaseIssuance)
+		// The reserve usage of an epoch is taken from the record of the next epoch.
+		assert.Equal(t, "100", resp.Data[0].ReserveUsage)
+		assert.Equal(t, "200", resp.Data[1].ReserveUsage)
+		assert.Equal(t, "", resp.Data[2].ReserveUsage)
+		assert.Equal(t, "", resp.Data[3].ReserveUsage)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		for _, url := range []string{"epoch_reward/4", "epoch_reward/foo", "epoch_reward?from=2&to=1", "epoch_reward?from=1", "epoch_reward?from=0&to=4"} {
+			writer := getEpochReward(t, url)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, url)
+		}
+	})
+	t.Run("too many epochs to replay", func(t *testing.T) {
+		slot := params.BeaconConfig().SlotsPerEpoch * 10
+		s := &Server{
+			BeaconDB:           beaconDB,
+			GenesisTimeFetcher: &mock.ChainService{Slot: &slot},
+			HeadFetcher:        &mock.ChainService{State: headState},
+			ReplayerBuilder:    replayerBuilder,
+		}
+		request := httptest.NewRequest("GET", "http://anything.is.fine/chronos/states/epoch_reward?from=4&to=9", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetEpochReward(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("canceled request", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		request := httptest.NewRequest("GET", "http://anything.is.fine/chronos/states/epoch_reward/0", nil).WithContext(cancelCtx)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetEpochReward(writer, request)
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	})
+}
+
+func TestGetEpochReward_ReplayedReserveUsage(t *testing.T) {
+	ctx := context.Background()
+	genesisState, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, genesisState.SetPreviousEpochReserve(params.BeaconConfig().MaxTokenSupply/2))
+	require.NoError(t, genesisState.SetCurrentEpochReserve(params.BeaconConfig().MaxTokenSupply/2-1000))
+	lastState, err := transition.ProcessSlots(ctx, genesisState.Copy(), params.BeaconConfig().SlotsPerEpoch-1)
+	require.NoError(t, err)
+	replayerBuilder := mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(genesisState), mockstategen.WithMockState(lastState))
+	headState := genesisState.Copy()
+	require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch*2))
+	slot := headState.Slot()
+	s := &Server{
+		BeaconDB:           dbTest.SetupDB(t),
+		GenesisTimeFetcher: &mock.ChainService{Slot: &slot},
+		HeadFetcher:        &mock.ChainService{State: headState},
+		ReplayerBuilder:    replayerBuilder,
+	}
+
+	request := httptest.NewRequest("GET", "http://anything.is.fine/chronos/states/epoch_reward?from=0&to=1", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetEpochReward(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &EpochRewardsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+
+	// The reserve usage of epoch 0 is known from the replayed transition to epoch 1.
+	postState, err := transition.ProcessSlots(ctx, lastState.Copy(), params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatUint(helpers.EpochReserveUsage(lastState, postState), 10), resp.Data[0].ReserveUsage)
+	assert.NotEqual(t, "0", resp.Data[0].ReserveUsage)
+	assert.Equal(t, "", resp.Data[1].ReserveUsage)
+}
+
+func TestNewEpochReward_SharesAddUpToReward(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	for _, syncWeight := range []uint64{0, 2} {
+		cfg := params.BeaconConfig().Copy()
+		cfg.SyncRewardWeight = syncWeight
+		params.OverrideBeaconConfig(cfg)
+
+		for _, reward := range []uint64{0, 7040, 1234567} {
+			r := newEpochReward(1, reward-reward/10, reward/10)
+			proposer, err := strconv.ParseUint(r.ProposerShare, 10, 64)
+			require.NoError(t, err)
+			sync, err := strconv.ParseUint(r.SyncShare, 10, 64)
+			require.NoError(t, err)
+			attester, err := strconv.ParseUint(r.AttesterShare, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, reward, proposer+sync+attester)
+			assert.Equal(t, reward*syncWeight/cfg.WeightDenominator, sync)
+		}
+	}
+}
+
 func setupValidators(t testing.TB, _ db.Database, count int) ([]*ethpb.Validator, []uint64, state.BeaconState) {
 	balances := make([]uint64, count)
 	validators := make([]*ethpb.Validator, 0, count)
@@ -34,5 +34,19 @@ type PeerDetailInfoResponse struct {
 }
 
 type EpochReward struct {
-	Reward string `json:"reward"`
+	Epoch         string `json:"epoch"`
+	Reward        string `json:"reward"`
+	BaseIssuance  string `json:"base_issuance"`
+	FeedbackBoost string `json:"feedback_boost"`
+	// ReserveUsage is only known once the state right after the transition to the next epoch is available.
+	ReserveUsage  string `json:"reserve_usage,omitempty"`
+	ProposerShare string `json:"proposer_share"`
+	SyncShare     string `json:"sync_share"`
+	// AttesterShare is the remainder of the reward, paid for timely source, target and head votes
+	// including the light layer reward, so that the three shares add up to the reward.
+	AttesterShare string `json:"attester_share"`
+}
+
+type EpochRewardsResponse struct {
+	Data []*EpochReward `json:"data"`
 }
@@ -30,14 +30,19 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/bailouts"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
 	rpcBuilder "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/events"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/forkchoice"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/proofs"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/reserves"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/tokenomics"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/withdrawals"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
 	nodeprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/node"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/beacon"
@@ -242,6 +247,45 @@ func (s *Service) Start() {
 	}
 	s.cfg.Router.HandleFunc("/over/v1/beacon/states/{state_id}/reserves", reservesServer.GetReserves)
 
+	proofsServer := &proofs.Server{
+		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		Stater:                stater,
+	}
+	s.cfg.Router.HandleFunc("/over/v1/beacon/states/{state_id}/proof", proofsServer.GetStateProof).Methods("GET")
+
+	bailoutsServer := &bailouts.Server{
+		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		Stater:                stater,
+		HeadFetcher:           s.cfg.HeadFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
+		BailoutPool:           s.cfg.BailoutPool,
+		ExitPool:              s.cfg.ExitPool,
+	}
+	s.cfg.Router.HandleFunc("/over/v1/beacon/states/{state_id}/bailout_scores", bailoutsServer.GetBailOutScores).Methods("GET")
+	s.cfg.Router.HandleFunc("/over/v1/beacon/pool/bail_outs", bailoutsServer.GetPoolBailOuts).Methods("GET")
+	s.cfg.Router.HandleFunc("/over/v1/beacon/pool/bail_outs/inclusion", bailoutsServer.GetBailOutsForInclusion).Methods("GET")
+
+	tokenomicsServer := &tokenomics.Server{
+		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		Stater:                stater,
+		BeaconDB:              s.cfg.BeaconDB,
+	}
+	s.cfg.Router.HandleFunc("/over/v1/tokenomics/projection", tokenomicsServer.GetProjection).Methods("GET", "POST")
+	s.cfg.Router.HandleFunc("/over/v1/tokenomics/history", tokenomicsServer.GetHistory).Methods("GET")
+
+	withdrawalsServer := &withdrawals.Server{
+		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		HeadFetcher:           s.cfg.HeadFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
+		BeaconDB:              s.cfg.BeaconDB,
+	}
+	s.cfg.Router.HandleFunc("/over/v1/validators/{id}/next_withdrawal", withdrawalsServer.GetNextWithdrawal).Methods("GET")
+	s.cfg.Router.HandleFunc("/over/v1/withdrawals", withdrawalsServer.GetWithdrawals).Methods("GET")
+
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
 		AttestationCache:       cache.NewAttestationCache(),
@@ -342,6 +386,7 @@ func (s *Service) Start() {
 	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", nodeServerPrysm.AddTrustedPeer).Methods("POST")
 	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers/{peer_id}", nodeServerPrysm.RemoveTrustedPeer).Methods("Delete")
 	s.cfg.Router.HandleFunc("/chronos/debug/peers/detail/{ip}", nodeServerPrysm.ListPeerDetailInfo).Methods("GET")
+	s.cfg.Router.HandleFunc("/chronos/states/epoch_reward", nodeServerPrysm.GetEpochReward).Methods("GET")
 	s.cfg.Router.HandleFunc("/chronos/states/epoch_reward/{epoch}", nodeServerPrysm.GetEpochReward).Methods("GET")
 
 	beaconChainServer := &beaconv1alpha1.Server{
@@ -391,6 +436,7 @@ func (s *Service) Start() {
 		BLSChangesPool:                s.cfg.BLSChangesPool,
 		FinalizationFetcher:           s.cfg.FinalizationFetcher,
 		ForkchoiceFetcher:             s.cfg.ForkchoiceFetcher,
+		ActivationQueueCache:          beacon.NewActivationQueueCache(),
 	}
 	httpServer := &httpserver.Server{
 		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
@@ -400,7 +446,9 @@ func (s *Service) Start() {
 	s.cfg.Router.HandleFunc("/prysm/validators/performance", httpServer.GetValidatorPerformance)
 	s.cfg.Router.HandleFunc("/eth/v2/beacon/blocks", beaconChainServerV1.PublishBlockV2)
 	s.cfg.Router.HandleFunc("/eth/v2/beacon/blinded_blocks", beaconChainServerV1.PublishBlindedBlockV2)
+	s.cfg.Router.HandleFunc("/chronos/validator/estimated_activation", beaconChainServerV1.EstimatedActivations).Methods("POST")
 	s.cfg.Router.HandleFunc("/chronos/validator/estimated_activation/{pub_key}", beaconChainServerV1.EstimatedActivation)
+	s.cfg.Router.HandleFunc("/chronos/validator/estimated_exit/{pub_key}", beaconChainServerV1.EstimatedExit).Methods("GET")
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
 	ethpbv1alpha1.RegisterHealthServer(s.grpcServer, nodeServer)
@@ -437,6 +485,11 @@ func (s *Service) Start() {
 		}
 		ethpbv1alpha1.RegisterDebugServer(s.grpcServer, debugServer)
 		ethpbservice.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
+
+		forkChoiceServer := &forkchoice.Server{
+			ForkchoiceFetcher: s.cfg.ForkchoiceFetcher,
+		}
+		s.cfg.Router.HandleFunc("/chronos/debug/fork_choice/graph", forkChoiceServer.GetForkChoiceGraph).Methods("GET")
 	}
 	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
 	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
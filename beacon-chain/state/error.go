@@ -6,4 +6,7 @@ var (
 	// ErrNilValidatorsInState returns when accessing validators in the state while the state has a
 	// nil slice for the validators field.
 	ErrNilValidatorsInState = errors.New("state has nil validator slice")
+	// ErrInvalidProofPath returns when a proof is requested for a path which does not resolve to a
+	// node of the state.
+	ErrInvalidProofPath = errors.New("invalid proof path")
 )
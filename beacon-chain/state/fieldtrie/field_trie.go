@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
 	pmath "github.com/prysmaticlabs/prysm/v4/math"
 )
 
@@ -237,6 +238,32 @@ func (f *FieldTrie) TrieRoot() ([32]byte, error) {
 	}
 }
 
+// Depth returns the depth of the trie, not counting the length mix in of variable size fields.
+func (f *FieldTrie) Depth() int {
+	if f.Empty() {
+		return 0
+	}
+	return len(f.fieldLayers) - 1
+}
+
+// NodeAt returns the node at the given position of the given layer of the trie, layer zero
+// holding the leaves. Nodes past the last element of a variable size trie are zero hashes.
+func (f *FieldTrie) NodeAt(layer int, index uint64) ([32]byte, error) {
+	if f.Empty() {
+		return [32]byte{}, ErrEmptyFieldTrie
+	}
+	if layer < 0 || layer >= len(f.fieldLayers) {
+		return [32]byte{}, errors.Errorf("layer %d out of range for trie of depth %d", layer, len(f.fieldLayers)-1)
+	}
+	if index >= uint64(len(f.fieldLayers[layer])) {
+		if f.dataType == types.BasicArray {
+			return [32]byte{}, errors.Errorf("index %d out of range for layer %d", index, layer)
+		}
+		return trie.ZeroHashes[layer], nil
+	}
+	return *f.fieldLayers[layer][index], nil
+}
+
 // FieldReference returns the underlying field reference
 // object for the trie.
 func (f *FieldTrie) FieldReference() *stateutil.Reference {
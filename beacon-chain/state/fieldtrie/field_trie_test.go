@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	zerohash "github.com/prysmaticlabs/prysm/v4/container/trie"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -161,3 +162,33 @@ func FuzzFieldTrie(f *testing.F) {
 		}
 	})
 }
+
+func TestFieldTrie_NodeAt(t *testing.T) {
+	newState, _ := util.DeterministicGenesisState(t, 3)
+	trie, err := fieldtrie.NewFieldTrie(types.FieldIndex(12), types.CompositeArray, newState.Validators(), params.BeaconConfig().ValidatorRegistryLimit)
+	require.NoError(t, err)
+	assert.Equal(t, 40, trie.Depth())
+
+	v, err := newState.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	vRoot, err := stateutil.ValidatorRootWithHasher(v)
+	require.NoError(t, err)
+	leaf, err := trie.NodeAt(0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, vRoot, leaf)
+
+	// Nodes past the last validator are zero hashes.
+	leaf, err = trie.NodeAt(0, 3)
+	require.NoError(t, err)
+	assert.Equal(t, [32]byte{}, leaf)
+	node, err := trie.NodeAt(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, zerohash.ZeroHashes[1], node)
+
+	_, err = trie.NodeAt(41, 0)
+	require.ErrorContains(t, "out of range", err)
+	empty, err := fieldtrie.NewFieldTrie(types.FieldIndex(5), types.BasicArray, nil, 8234)
+	require.NoError(t, err)
+	_, err = empty.NodeAt(0, 0)
+	require.ErrorIs(t, err, fieldtrie.ErrEmptyFieldTrie)
+}
@@ -37,6 +37,7 @@ type StateProver interface {
 	FinalizedRootProof(ctx context.Context) ([][]byte, error)
 	CurrentSyncCommitteeProof(ctx context.Context) ([][]byte, error)
 	NextSyncCommitteeProof(ctx context.Context) ([][]byte, error)
+	Multiproof(ctx context.Context, paths []string) (*Multiproof, error)
 }
 
 // ReadOnlyBeaconState defines a struct which only has read access to beacon state methods.
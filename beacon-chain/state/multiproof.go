@@ -0,0 +1,14 @@
+package state
+
+// Multiproof is a Merkle multiproof of a set of beacon state nodes against the state root.
+// Leaves[i] is the node at the generalized index Indices[i], which Paths[i] resolves to, and
+// Proof[i] is the node at the generalized index HelperIndices[i]. Helper indices are sorted in
+// descending order, as in the consensus specification of multiproofs.
+type Multiproof struct {
+	StateRoot     [32]byte
+	Paths         []string
+	Indices       []uint64
+	Leaves        [][32]byte
+	HelperIndices []uint64
+	Proof         [][32]byte
+}
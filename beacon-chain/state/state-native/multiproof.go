@@ -0,0 +1,559 @@
+package state_native
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/fieldtrie"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// proofFieldNames are the names of the beacon state fields in the consensus specification.
+var proofFieldNames = map[types.FieldIndex]string{
+	types.GenesisTime:                         "genesis_time",
+	types.GenesisValidatorsRoot:               "genesis_validators_root",
+	types.Slot:                                "slot",
+	types.Fork:                                "fork",
+	types.LatestBlockHeader:                   "latest_block_header",
+	types.BlockRoots:                          "block_roots",
+	types.StateRoots:                          "state_roots",
+	types.HistoricalRoots:                     "historical_roots",
+	types.RewardAdjustmentFactor:              "reward_adjustment_factor",
+	types.Eth1Data:                            "eth1_data",
+	types.Eth1DataVotes:                       "eth1_data_votes",
+	types.Eth1DepositIndex:                    "eth1_deposit_index",
+	types.Validators:                          "validators",
+	types.Balances:                            "balances",
+	types.PreviousEpochReserve:                "previous_epoch_reserve",
+	types.CurrentEpochReserve:                 "current_epoch_reserve",
+	types.RandaoMixes:                         "randao_mixes",
+	types.Slashings:                           "slashings",
+	types.PreviousEpochAttestations:           "previous_epoch_attestations",
+	types.CurrentEpochAttestations:            "current_epoch_attestations",
+	types.PreviousEpochParticipationBits:      "previous_epoch_participation",
+	types.CurrentEpochParticipationBits:       "current_epoch_participation",
+	types.JustificationBits:                   "justification_bits",
+	types.PreviousJustifiedCheckpoint:         "previous_justified_checkpoint",
+	types.CurrentJustifiedCheckpoint:          "current_justified_checkpoint",
+	types.FinalizedCheckpoint:                 "finalized_checkpoint",
+	types.InactivityScores:                    "inactivity_scores",
+	types.CurrentSyncCommittee:                "current_sync_committee",
+	types.NextSyncCommittee:                   "next_sync_committee",
+	types.BailOutScores:                       "bail_out_scores",
+	types.LatestExecutionPayloadHeader:        "latest_execution_payload_header",
+	types.LatestExecutionPayloadHeaderCapella: "latest_execution_payload_header",
+	types.NextWithdrawalIndex:                 "next_withdrawal_index",
+	types.NextWithdrawalValidatorIndex:        "next_withdrawal_validator_index",
+	types.HistoricalSummaries:                 "historical_summaries",
+}
+
+var (
+	validatorFieldNames = []string{"pubkey", "withdrawal_credentials", "effective_balance", "slashed",
+		"activation_eligibility_epoch", "activation_epoch", "exit_epoch", "withdrawable_epoch"}
+	forkFieldNames              = []string{"previous_version", "current_version", "epoch"}
+	beaconBlockHeaderFieldNames = []string{"slot", "proposer_index", "parent_root", "state_root", "body_root"}
+	eth1DataFieldNames          = []string{"deposit_root", "deposit_count", "block_hash"}
+	checkpointFieldNames        = []string{"epoch", "root"}
+)
+
+// proofTree is a view of a Merkle tree of the beacon state, which proof nodes are read from and
+// proof paths are resolved against.
+type proofTree struct {
+	// depth is the depth of the tree, its leaves being the roots of its children.
+	depth uint64
+	// nodeAt returns the node at the given position of the given layer, layer zero holding the leaves.
+	nodeAt func(layer, index uint64) ([32]byte, error)
+	// child returns the tree rooted at the given leaf, or nil if the leaf is a basic chunk.
+	child    func(index uint64) (*proofTree, error)
+	children map[uint64]*proofTree
+	// fields are the field names of a container.
+	fields []string
+	// list is set on the length mix in tree of a list, whose left child holds the elements.
+	list bool
+	// length and perChunk are the number of elements of a sequence, and how many of them are
+	// packed in a leaf.
+	length   uint64
+	perChunk uint64
+}
+
+// Multiproof returns a Merkle multiproof of the nodes the given paths resolve to against the
+// state root. A path is a dot separated list of field names, each one optionally followed by
+// indices in brackets, e.g. `finalized_checkpoint.root`, `balances[7]` or
+// `validators[3].effective_balance`. Packed basic elements such as balances resolve to the
+// chunk holding them.
+func (b *BeaconState) Multiproof(ctx context.Context, paths []string) (*state.Multiproof, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(paths) == 0 {
+		return nil, errors.Wrap(state.ErrInvalidProofPath, "no path provided")
+	}
+	if err := b.initializeMerkleLayers(ctx); err != nil {
+		return nil, err
+	}
+	if err := b.recomputeDirtyFields(ctx); err != nil {
+		return nil, err
+	}
+	root := b.stateProofTree()
+
+	indices := make([]uint64, len(paths))
+	for i, p := range paths {
+		index, err := resolveProofPath(root, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve path %q", p)
+		}
+		for j := 0; j < i; j++ {
+			if ssz.IsGeneralizedIndexAncestor(indices[j], index) || ssz.IsGeneralizedIndexAncestor(index, indices[j]) {
+				return nil, errors.Wrapf(state.ErrInvalidProofPath, "paths %q and %q overlap", paths[j], p)
+			}
+		}
+		indices[i] = index
+	}
+	leaves := make([][32]byte, len(indices))
+	for i, index := range indices {
+		leaf, err := root.node(index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get node of path %q", paths[i])
+		}
+		leaves[i] = leaf
+	}
+	helperIndices := ssz.MultiproofHelperIndices(indices)
+	proof := make([][32]byte, len(helperIndices))
+	for i, index := range helperIndices {
+		node, err := root.node(index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get node at generalized index %d", index)
+		}
+		proof[i] = node
+	}
+	return &state.Multiproof{
+		StateRoot:     bytesutil.ToBytes32(b.merkleLayers[len(b.merkleLayers)-1][0]),
+		Paths:         paths,
+		Indices:       indices,
+		Leaves:        leaves,
+		HelperIndices: helperIndices,
+		Proof:         proof,
+	}, nil
+}
+
+// node returns the node at the given generalized index of the tree.
+func (t *proofTree) node(index uint64) ([32]byte, error) {
+	if index == 0 {
+		return [32]byte{}, errors.New("generalized index zero is invalid")
+	}
+	depth := ssz.GeneralizedIndexDepth(index)
+	if depth <= t.depth {
+		return t.nodeAt(t.depth-depth, index^1<<depth)
+	}
+	shift := depth - t.depth
+	leaf := index>>shift ^ 1<<t.depth
+	sub, err := t.childAt(leaf)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if sub == nil {
+		return [32]byte{}, errors.Errorf("no node at generalized index %d below a basic leaf", index)
+	}
+	return sub.node(index&(1<<shift-1) | 1<<shift)
+}
+
+// childAt returns the tree rooted at the given leaf. Children are cached, as proofs of
+// neighbouring nodes descend into the same subtrees many times.
+func (t *proofTree) childAt(index uint64) (*proofTree, error) {
+	if t.child == nil {
+		return nil, nil
+	}
+	if c, ok := t.children[index]; ok {
+		return c, nil
+	}
+	c, err := t.child(index)
+	if err != nil {
+		return nil, err
+	}
+	if t.children == nil {
+		t.children = make(map[uint64]*proofTree)
+	}
+	t.children[index] = c
+	return c, nil
+}
+
+type proofPathElem struct {
+	name  string
+	index uint64
+}
+
+// parseProofPath splits a path such as `validators[3].effective_balance` into field names and indices.
+func parseProofPath(path string) ([]proofPathElem, error) {
+	if path == "" {
+		return nil, errors.Wrap(state.ErrInvalidProofPath, "empty path")
+	}
+	var elems []proofPathElem
+	for _, part := range strings.Split(path, ".") {
+		name := part
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name = part[:i]
+		}
+		if name == "" {
+			return nil, errors.Wrapf(state.ErrInvalidProofPath, "missing field name in %q", part)
+		}
+		elems = append(elems, proofPathElem{name: name})
+		rest := part[len(name):]
+		for rest != "" {
+			end := strings.IndexByte(rest, ']')
+			if rest[0] != '[' || end < 0 {
+				return nil, errors.Wrapf(state.ErrInvalidProofPath, "malformed index in %q", part)
+			}
+			index, err := strconv.ParseUint(rest[1:end], 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(state.ErrInvalidProofPath, "malformed index in %q", part)
+			}
+			elems = append(elems, proofPathElem{index: index})
+			rest = rest[end+1:]
+		}
+	}
+	return elems, nil
+}
+
+// resolveProofPath returns the generalized index of the node the given path resolves to in the tree.
+func resolveProofPath(root *proofTree, path string) (uint64, error) {
+	elems, err := parseProofPath(path)
+	if err != nil {
+		return 0, err
+	}
+	index := uint64(1)
+	t := root
+	for _, e := range elems {
+		if t == nil {
+			return 0, errors.Wrap(state.ErrInvalidProofPath, "cannot descend into a basic value")
+		}
+		var leaf uint64
+		if e.name != "" {
+			found := false
+			for i, f := range t.fields {
+				if f == e.name {
+					leaf, found = uint64(i), true
+					break
+				}
+			}
+			if !found {
+				return 0, errors.Wrapf(state.ErrInvalidProofPath, "unknown field %q", e.name)
+			}
+		} else {
+			if t.list {
+				if index, err = ssz.ConcatGeneralizedIndices(index, 2); err != nil {
+					return 0, err
+				}
+				if t, err = t.childAt(0); err != nil {
+					return 0, err
+				}
+			}
+			if t.perChunk == 0 {
+				return 0, errors.Wrap(state.ErrInvalidProofPath, "cannot index a value which is not a list or vector")
+			}
+			if e.index >= t.length {
+				return 0, errors.Wrapf(state.ErrInvalidProofPath, "index %d out of range for length %d", e.index, t.length)
+			}
+			leaf = e.index / t.perChunk
+		}
+		if index, err = ssz.ConcatGeneralizedIndices(index, 1<<t.depth|leaf); err != nil {
+			return 0, err
+		}
+		if t, err = t.childAt(leaf); err != nil {
+			return 0, err
+		}
+	}
+	return index, nil
+}
+
+// stateProofTree returns the proof tree of the state, backed by its Merkle layers.
+//
+// WARNING: Caller must acquire the mutex and recompute the dirty fields before using.
+func (b *BeaconState) stateProofTree() *proofTree {
+	var fields []types.FieldIndex
+	switch b.version {
+	case version.Phase0:
+		fields = phase0Fields
+	case version.Altair:
+		fields = altairFields
+	case version.Bellatrix:
+		fields = bellatrixFields
+	case version.Capella:
+		fields = capellaFields
+	}
+	names := make([]string, len(fields))
+	for _, f := range fields {
+		names[f.RealPosition()] = proofFieldNames[f]
+	}
+	depth := uint64(len(b.merkleLayers) - 1)
+	return &proofTree{
+		depth: depth,
+		nodeAt: func(layer, index uint64) ([32]byte, error) {
+			if index >= uint64(len(b.merkleLayers[layer])) {
+				return [32]byte{}, errors.Errorf("no state node at layer %d and index %d", layer, index)
+			}
+			return bytesutil.ToBytes32(b.merkleLayers[layer][index]), nil
+		},
+		child: func(index uint64) (*proofTree, error) {
+			if index >= uint64(len(fields)) {
+				return nil, nil
+			}
+			return b.fieldProofTree(fields[index])
+		},
+		fields: names,
+	}
+}
+
+// fieldProofTree returns the proof tree of the given state field, or nil for basic fields and
+// fields which cannot be descended into.
+func (b *BeaconState) fieldProofTree(field types.FieldIndex) (*proofTree, error) {
+	switch field {
+	case types.Fork:
+		return containerProofTree(forkFieldNames, [][32]byte{
+			bytesutil.ToBytes32(b.fork.PreviousVersion),
+			bytesutil.ToBytes32(b.fork.CurrentVersion),
+			ssz.Uint64Root(uint64(b.fork.Epoch)),
+		}, nil), nil
+	case types.LatestBlockHeader:
+		return containerProofTree(beaconBlockHeaderFieldNames, [][32]byte{
+			ssz.Uint64Root(uint64(b.latestBlockHeader.Slot)),
+			ssz.Uint64Root(uint64(b.latestBlockHeader.ProposerIndex)),
+			bytesutil.ToBytes32(b.latestBlockHeader.ParentRoot),
+			bytesutil.ToBytes32(b.latestBlockHeader.StateRoot),
+			bytesutil.ToBytes32(b.latestBlockHeader.BodyRoot),
+		}, nil), nil
+	case types.Eth1Data:
+		return eth1DataProofTree(b.eth1Data), nil
+	case types.PreviousJustifiedCheckpoint:
+		return checkpointProofTree(b.previousJustifiedCheckpoint), nil
+	case types.CurrentJustifiedCheckpoint:
+		return checkpointProofTree(b.currentJustifiedCheckpoint), nil
+	case types.FinalizedCheckpoint:
+		return checkpointProofTree(b.finalizedCheckpoint), nil
+	case types.BlockRoots, types.StateRoots, types.RandaoMixes:
+		return b.fieldTrieProofTree(field, 1, nil)
+	case types.Validators:
+		validators := b.validators
+		data, err := b.fieldTrieProofTree(field, 1, func(index uint64) (*proofTree, error) {
+			if index >= uint64(len(validators)) {
+				return nil, nil
+			}
+			return validatorProofTree(validators[index])
+		})
+		if err != nil {
+			return nil, err
+		}
+		return listProofTree(data), nil
+	case types.Balances:
+		data, err := b.fieldTrieProofTree(field, 4, nil)
+		if err != nil {
+			return nil, err
+		}
+		return listProofTree(data), nil
+	case types.Eth1DataVotes:
+		votes := b.eth1DataVotes
+		data, err := b.fieldTrieProofTree(field, 1, func(index uint64) (*proofTree, error) {
+			if index >= uint64(len(votes)) {
+				return nil, nil
+			}
+			return eth1DataProofTree(votes[index]), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return listProofTree(data), nil
+	case types.HistoricalRoots:
+		chunks := make([][32]byte, len(b.historicalRoots))
+		copy(chunks, b.historicalRoots)
+		return listProofTree(chunksProofTree(chunks, fieldparams.HistoricalRootsLength, uint64(len(chunks)), 1)), nil
+	case types.Slashings:
+		chunks, err := stateutil.PackUint64IntoChunks(b.slashings)
+		if err != nil {
+			return nil, err
+		}
+		return chunksProofTree(chunks, uint64(len(chunks)), uint64(len(b.slashings)), 4), nil
+	case types.InactivityScores:
+		return uint64ListProofTree(b.inactivityScores)
+	case types.BailOutScores:
+		return uint64ListProofTree(b.bailoutScores)
+	case types.PreviousEpochParticipationBits:
+		return participationProofTree(b.previousEpochParticipation)
+	case types.CurrentEpochParticipationBits:
+		return participationProofTree(b.currentEpochParticipation)
+	default:
+		return nil, nil
+	}
+}
+
+// fieldTrieProofTree returns the proof tree of the elements of a field backed by a field trie.
+// The trie of the state is used if it is up to date, otherwise a new one is built.
+//
+// WARNING: Caller must acquire the mutex and recompute the dirty fields before using.
+func (b *BeaconState) fieldTrieProofTree(field types.FieldIndex, perChunk uint64, child func(uint64) (*proofTree, error)) (*proofTree, error) {
+	var elements interface{}
+	var length, limit uint64
+	switch field {
+	case types.BlockRoots:
+		elements, length, limit = b.blockRoots, fieldparams.BlockRootsLength, fieldparams.BlockRootsLength
+	case types.StateRoots:
+		elements, length, limit = b.stateRoots, fieldparams.StateRootsLength, fieldparams.StateRootsLength
+	case types.RandaoMixes:
+		elements, length, limit = b.randaoMixes, fieldparams.RandaoMixesLength, fieldparams.RandaoMixesLength
+	case types.Validators:
+		elements, length, limit = b.validators, uint64(len(b.validators)), fieldparams.ValidatorRegistryLimit
+	case types.Balances:
+		elements, length, limit = b.balances, uint64(len(b.balances)), stateutil.ValidatorLimitForBalancesChunks()
+	case types.Eth1DataVotes:
+		elements, length, limit = b.eth1DataVotes, uint64(len(b.eth1DataVotes)), params.BeaconConfig().Eth1DataVotesLength()
+	default:
+		return nil, errors.Errorf("field %s has no field trie", field.String())
+	}
+
+	fTrie := b.stateFieldLeaves[field]
+	if b.rebuildTrie[field] || fTrie.Empty() {
+		var err error
+		fTrie, err = fieldtrie.NewFieldTrie(field, fieldMap[field], elements, limit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not build field trie of %s", field.String())
+		}
+	}
+	depth := uint64(ssz.Depth(limit))
+	if uint64(fTrie.Depth()) != depth {
+		return nil, errors.Errorf("field trie of %s has depth %d, expected %d", field.String(), fTrie.Depth(), depth)
+	}
+	return &proofTree{
+		depth: depth,
+		nodeAt: func(layer, index uint64) ([32]byte, error) {
+			fTrie.RLock()
+			defer fTrie.RUnlock()
+			return fTrie.NodeAt(int(layer), index)
+		},
+		child:    child,
+		length:   length,
+		perChunk: perChunk,
+	}, nil
+}
+
+// listProofTree returns the proof tree of a list, which mixes the length of the list in the root
+// of the given tree of its elements.
+func listProofTree(data *proofTree) *proofTree {
+	lengthRoot := ssz.Uint64Root(data.length)
+	return &proofTree{
+		depth: 1,
+		nodeAt: func(layer, index uint64) ([32]byte, error) {
+			dataRoot, err := data.nodeAt(data.depth, 0)
+			if err != nil {
+				return [32]byte{}, err
+			}
+			switch {
+			case layer == 1 && index == 0:
+				return hash.Hash(append(dataRoot[:], lengthRoot[:]...)), nil
+			case layer == 0 && index == 0:
+				return dataRoot, nil
+			case layer == 0 && index == 1:
+				return lengthRoot, nil
+			default:
+				return [32]byte{}, errors.Errorf("no list node at layer %d and index %d", layer, index)
+			}
+		},
+		child: func(index uint64) (*proofTree, error) {
+			if index == 0 {
+				return data, nil
+			}
+			return nil, nil
+		},
+		list: true,
+	}
+}
+
+// chunksProofTree returns the proof tree of the given chunks, padded with zero chunks up to limit.
+func chunksProofTree(chunks [][32]byte, limit, length, perChunk uint64) *proofTree {
+	layers := stateutil.ReturnTrieLayerVariable(chunks, limit)
+	return &proofTree{
+		depth: uint64(len(layers) - 1),
+		nodeAt: func(layer, index uint64) ([32]byte, error) {
+			if layer >= uint64(len(layers)) {
+				return [32]byte{}, errors.Errorf("no node at layer %d", layer)
+			}
+			if index >= uint64(len(layers[layer])) {
+				return trie.ZeroHashes[layer], nil
+			}
+			return *layers[layer][index], nil
+		},
+		length:   length,
+		perChunk: perChunk,
+	}
+}
+
+// containerProofTree returns the proof tree of a container with the given field roots.
+func containerProofTree(names []string, roots [][32]byte, child func(uint64) (*proofTree, error)) *proofTree {
+	t := chunksProofTree(roots, uint64(len(roots)), 0, 0)
+	t.fields = names
+	t.child = child
+	return t
+}
+
+func uint64ListProofTree(values []uint64) (*proofTree, error) {
+	chunks, err := stateutil.PackUint64IntoChunks(values)
+	if err != nil {
+		return nil, err
+	}
+	return listProofTree(chunksProofTree(chunks, stateutil.ValidatorLimitForBalancesChunks(), uint64(len(values)), 4)), nil
+}
+
+func participationProofTree(bits []byte) (*proofTree, error) {
+	chunks, err := ssz.PackByChunk([][]byte{bits})
+	if err != nil {
+		return nil, err
+	}
+	limit := (uint64(fieldparams.ValidatorRegistryLimit) + 31) / 32
+	return listProofTree(chunksProofTree(chunks, limit, uint64(len(bits)), 32)), nil
+}
+
+func validatorProofTree(v *ethpb.Validator) (*proofTree, error) {
+	roots, err := stateutil.ValidatorFieldRoots(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) != len(validatorFieldNames) {
+		return nil, fmt.Errorf("got %d validator field roots, expected %d", len(roots), len(validatorFieldNames))
+	}
+	pubkey := bytesutil.ToBytes48(v.PublicKey)
+	return containerProofTree(validatorFieldNames, roots, func(index uint64) (*proofTree, error) {
+		if index != 0 {
+			return nil, nil
+		}
+		// The 48 byte public key is merkleized as a vector of two chunks.
+		return chunksProofTree([][32]byte{bytesutil.ToBytes32(pubkey[:32]), bytesutil.ToBytes32(pubkey[32:])}, 2, 0, 0), nil
+	}), nil
+}
+
+func eth1DataProofTree(d *ethpb.Eth1Data) *proofTree {
+	count := make([]byte, 32)
+	binary.LittleEndian.PutUint64(count, d.DepositCount)
+	return containerProofTree(eth1DataFieldNames, [][32]byte{
+		bytesutil.ToBytes32(d.DepositRoot),
+		bytesutil.ToBytes32(count),
+		bytesutil.ToBytes32(d.BlockHash),
+	}, nil)
+}
+
+func checkpointProofTree(c *ethpb.Checkpoint) *proofTree {
+	return containerProofTree(checkpointFieldNames, [][32]byte{
+		ssz.Uint64Root(uint64(c.Epoch)),
+		bytesutil.ToBytes32(c.Root),
+	}, nil)
+}
@@ -0,0 +1,112 @@
+package state_native_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestBeaconState_Multiproof(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, st.SetCurrentEpochReserve(123456789))
+	require.NoError(t, st.SetRewardAdjustmentFactor(42))
+	scores := make([]uint64, 64)
+	for i := range scores {
+		scores[i] = uint64(i) * 1000
+	}
+	require.NoError(t, st.SetBailOutScores(scores))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 3, Root: make([]byte, 32)}))
+
+	paths := []string{
+		"current_epoch_reserve",
+		"reward_adjustment_factor",
+		"balances[5]",
+		"validators[3]",
+		"validators[4].effective_balance",
+		"validators[9].pubkey",
+		"bail_out_scores[6]",
+		"inactivity_scores[1]",
+		"slashings[3]",
+		"current_epoch_participation[5]",
+		"block_roots[2]",
+		"finalized_checkpoint.root",
+		"eth1_data.deposit_count",
+		"latest_block_header.slot",
+	}
+	verify := func(t *testing.T, st state.BeaconState) *state.Multiproof {
+		mp, err := st.Multiproof(ctx, paths)
+		require.NoError(t, err)
+		root, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, root, mp.StateRoot)
+		assert.Equal(t, true, ssz.VerifyMultiproof(root, mp.Leaves, mp.Proof, mp.Indices))
+		assert.DeepEqual(t, ssz.MultiproofHelperIndices(mp.Indices), mp.HelperIndices)
+		return mp
+	}
+
+	t.Run("fresh state", func(t *testing.T) {
+		mp := verify(t, st)
+		assert.Equal(t, ssz.Uint64Root(123456789), mp.Leaves[0])
+		assert.Equal(t, ssz.Uint64Root(42), mp.Leaves[1])
+		assert.Equal(t, statenative.FinalizedRootGeneralizedIndex(), mp.Indices[11])
+
+		v, err := st.ValidatorAtIndexReadOnly(4)
+		require.NoError(t, err)
+		assert.Equal(t, ssz.Uint64Root(v.EffectiveBalance()), mp.Leaves[4])
+		// Bail out scores are packed four to a chunk, the score of validator 6 being the third one.
+		chunk, score := mp.Leaves[6], ssz.Uint64Root(6000)
+		assert.DeepEqual(t, score[:8], chunk[16:24])
+	})
+	t.Run("dirty fields", func(t *testing.T) {
+		_, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		require.NoError(t, st.UpdateBalancesAtIndex(5, 1))
+		v, err := st.ValidatorAtIndex(4)
+		require.NoError(t, err)
+		v.EffectiveBalance = 1
+		require.NoError(t, st.UpdateValidatorAtIndex(4, v))
+		require.NoError(t, st.SetCurrentEpochReserve(7))
+		mp := verify(t, st)
+		assert.Equal(t, ssz.Uint64Root(7), mp.Leaves[0])
+		assert.Equal(t, ssz.Uint64Root(1), mp.Leaves[4])
+	})
+	t.Run("copied state", func(t *testing.T) {
+		cp := st.Copy()
+		require.NoError(t, cp.UpdateBalancesAtIndex(5, 2))
+		verify(t, cp)
+		verify(t, st)
+	})
+}
+
+func TestBeaconState_Multiproof_InvalidPaths(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateCapella(t, 8)
+	for _, tt := range []struct {
+		name  string
+		paths []string
+		err   string
+	}{
+		{name: "no path", err: "no path provided"},
+		{name: "empty path", paths: []string{""}, err: "empty path"},
+		{name: "unknown field", paths: []string{"reserves"}, err: "unknown field \"reserves\""},
+		{name: "index out of range", paths: []string{"balances[8]"}, err: "index 8 out of range for length 8"},
+		{name: "malformed index", paths: []string{"balances[x]"}, err: "malformed index"},
+		{name: "index of a container", paths: []string{"fork[0]"}, err: "cannot index"},
+		{name: "field of a basic value", paths: []string{"slot.epoch"}, err: "cannot descend into a basic value"},
+		{name: "overlapping paths", paths: []string{"validators[1]", "validators[1].slashed"}, err: "overlap"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.Multiproof(ctx, tt.paths)
+			require.ErrorIs(t, err, state.ErrInvalidProofPath)
+			assert.ErrorContains(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_blocks_total",
+		Help: "The number of blocks backfilled before the origin checkpoint.",
+	})
+	backfillBatchesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_batches_total",
+		Help: "The number of block batches requested by the backfill service.",
+	})
+	backfillBatchFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backfill_batch_failures_total",
+		Help: "The number of block batches that could not be fetched or failed verification.",
+	})
+	backfillRemainingSlots = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backfill_remaining_slots",
+		Help: "The number of slots between genesis and the lowest backfilled block.",
+	})
+)
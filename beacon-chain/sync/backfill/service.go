@@ -0,0 +1,300 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	prysmsync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+const defaultBatchSize = 64
+
+var errGapInconsistent = errors.New("backfill reached slot 0 without reaching the genesis block")
+
+// Config to set up the backfill service.
+type Config struct {
+	DB                  db.HeadAccessDatabase
+	P2P                 p2p.P2P
+	ClockWaiter         startup.ClockWaiter
+	Status              *Status
+	InitialSyncComplete chan struct{}
+	// BatchSize is the number of slots requested from a peer at once.
+	BatchSize uint64
+	// BlocksPerSecond limits the rate at which blocks are requested from peers.
+	BlocksPerSecond uint64
+}
+
+type fetchFunc func(ctx context.Context, pid peer.ID, req *pb.BeaconBlocksByRangeRequest) ([]interfaces.ReadOnlySignedBeaconBlock, error)
+
+// Service downloads the blocks missing between genesis and the origin checkpoint of a node initialized
+// via checkpoint sync. Blocks are requested backwards from the lowest block in the database, so that each
+// block can be checked against the parent root of its child before being saved.
+type Service struct {
+	cfg      *Config
+	ctx      context.Context
+	cancel   context.CancelFunc
+	clock    *startup.Clock
+	fetch    fetchFunc
+	verifier *verifier
+	// low is the lowest slot requested so far, blocks being requested from the slots just below it.
+	low primitives.Slot
+	// child is the root of the lowest verified block and expected the root of its parent.
+	child       [32]byte
+	expected    [32]byte
+	genesisRoot [32]byte
+}
+
+// NewService configures the backfill service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	s := &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	s.fetch = func(ctx context.Context, pid peer.ID, req *pb.BeaconBlocksByRangeRequest) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+		return prysmsync.SendBeaconBlocksByRangeRequest(ctx, s.clock, s.cfg.P2P, pid, req, nil)
+	}
+	return s
+}
+
+// Start the backfill service. It returns right away if there is no gap to backfill.
+func (s *Service) Start() {
+	if s.cfg.Status.Complete() {
+		log.Debug("No gap to backfill, exiting backfill service")
+		return
+	}
+	clock, err := s.cfg.ClockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Backfill service failed to receive startup event")
+		return
+	}
+	s.clock = clock
+	select {
+	case <-s.cfg.InitialSyncComplete:
+	case <-s.ctx.Done():
+		return
+	}
+	if err := s.initialize(s.ctx); err != nil {
+		log.WithError(err).Error("Could not initialize backfill service")
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"lowestSlot": s.cfg.Status.StartGap(),
+		"originSlot": s.cfg.Status.EndGap(),
+	}).Info("Starting backfill of blocks before the origin checkpoint")
+	if err := s.run(s.ctx); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Backfill failed")
+		return
+	}
+	log.Info("Backfill complete")
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// initialize resumes backfilling from the lowest block in the database, and builds the verifier from the
+// validator registry of the origin checkpoint state.
+func (s *Service) initialize(ctx context.Context) error {
+	originRoot, err := s.cfg.DB.OriginCheckpointBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin checkpoint block root")
+	}
+	originState, err := s.cfg.DB.State(ctx, originRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not get origin checkpoint state of block root=%#x", originRoot)
+	}
+	s.verifier, err = newVerifier(originState)
+	if err != nil {
+		return err
+	}
+	s.genesisRoot, err = s.cfg.DB.GenesisBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block root")
+	}
+	s.child, err = s.cfg.DB.BackfillBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get backfill block root")
+	}
+	blk, err := s.cfg.DB.Block(ctx, s.child)
+	if err != nil {
+		return errors.Wrapf(err, "could not get backfill block of root=%#x", s.child)
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		return err
+	}
+	s.expected = blk.Block().ParentRoot()
+	s.low = blk.Block().Slot()
+	backfillRemainingSlots.Set(float64(s.low))
+	return nil
+}
+
+// run requests batches of blocks from peers with a finalized checkpoint at or above the origin checkpoint,
+// until the genesis block or the earliest slot of the retained history is reached.
+func (s *Service) run(ctx context.Context) error {
+	peerIdx := 0
+	for s.expected != s.genesisRoot {
+		if s.cfg.Status.Complete() {
+			// Blocks below the retained history are not needed, and would be pruned again.
+			log.WithField("earliestSlot", s.cfg.Status.EarliestSlot()).Info("Backfill reached the pruned history")
+			return nil
+		}
+		_, pids := s.cfg.P2P.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, slots.ToEpoch(s.cfg.Status.EndGap()))
+		if len(pids) == 0 {
+			log.Debug("No suitable peers to backfill from, waiting")
+			if err := wait(ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second); err != nil {
+				return err
+			}
+			continue
+		}
+		pid := pids[peerIdx%len(pids)]
+		peerIdx++
+
+		start := time.Now()
+		count, err := s.step(ctx, pid)
+		if err != nil {
+			if errors.Is(err, errGapInconsistent) || ctx.Err() != nil {
+				return err
+			}
+			backfillBatchFailuresTotal.Inc()
+			log.WithError(err).WithField("peer", pid).Debug("Could not backfill batch")
+		}
+		if err := wait(ctx, s.rateLimit(count)-time.Since(start)); err != nil {
+			return err
+		}
+	}
+	return s.finish(ctx)
+}
+
+// step requests the batch of slots just below the lowest requested slot from the given peer. The blocks are
+// saved if they extend the verified chain down from the origin checkpoint and carry valid proposer signatures.
+func (s *Service) step(ctx context.Context, pid peer.ID) (uint64, error) {
+	if s.low <= 1 {
+		if s.cfg.Status.StartGap() <= 1 {
+			return 0, errors.Wrapf(errGapInconsistent, "expected parent root=%#x", s.expected)
+		}
+		// Peers returned no blocks down to slot 1, at least one of them withheld the parent block.
+		s.low = s.cfg.Status.StartGap()
+		return 0, errors.Errorf("parent block of root=%#x was not found, requesting the gap again", s.expected)
+	}
+	startSlot := primitives.Slot(1)
+	if s.low > primitives.Slot(s.cfg.BatchSize)+1 {
+		startSlot = s.low - primitives.Slot(s.cfg.BatchSize)
+	}
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: startSlot,
+		Count:     uint64(s.low - startSlot),
+		Step:      1,
+	}
+	backfillBatchesTotal.Inc()
+	blks, err := s.fetch(ctx, pid, req)
+	if err != nil {
+		return req.Count, errors.Wrapf(err, "could not request blocks by range from slot %d", startSlot)
+	}
+	if len(blks) == 0 {
+		// The slots may all be empty. If the peer withheld blocks instead, the parent root of the next
+		// block received will not match and the batch will be requested again.
+		s.low = startSlot
+		return req.Count, nil
+	}
+	roots, err := s.verifier.verify(blks, s.expected)
+	if err != nil {
+		s.cfg.P2P.Peers().Scorers().BadResponsesScorer().Increment(pid)
+		// Request again the slots below the lowest verified block.
+		s.low = s.cfg.Status.StartGap()
+		return req.Count, err
+	}
+	if err := s.cfg.DB.SaveBlocks(ctx, blks); err != nil {
+		return req.Count, errors.Wrap(err, "could not save backfilled blocks")
+	}
+	if err := s.cfg.DB.SaveBackfillFinalizedBlockRoots(ctx, blks, s.child); err != nil {
+		return req.Count, errors.Wrap(err, "could not save backfilled finalized block roots")
+	}
+	lowest := blks[0].Block()
+	if err := s.cfg.Status.Advance(ctx, lowest.Slot(), roots[0]); err != nil {
+		return req.Count, errors.Wrap(err, "could not advance backfill status")
+	}
+	s.low = lowest.Slot()
+	s.child = roots[0]
+	s.expected = lowest.ParentRoot()
+
+	backfillBlocksTotal.Add(float64(len(blks)))
+	backfillRemainingSlots.Set(float64(s.low))
+	log.WithFields(logrus.Fields{
+		"peer":   pid,
+		"blocks": len(blks),
+		"slot":   s.low,
+	}).Debug("Backfilled batch of blocks")
+	return req.Count, nil
+}
+
+// finish links the genesis block to the lowest backfilled block and marks the gap as closed.
+func (s *Service) finish(ctx context.Context) error {
+	genesis, err := s.cfg.DB.Block(ctx, s.genesisRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block")
+	}
+	if err := blocks.BeaconBlockIsNil(genesis); err != nil {
+		return err
+	}
+	if err := s.cfg.DB.SaveBackfillFinalizedBlockRoots(ctx, []interfaces.ReadOnlySignedBeaconBlock{genesis}, s.child); err != nil {
+		return errors.Wrap(err, "could not save genesis finalized block root")
+	}
+	if err := s.cfg.Status.Advance(ctx, 0, s.genesisRoot); err != nil {
+		return errors.Wrap(err, "could not advance backfill status to genesis")
+	}
+	backfillRemainingSlots.Set(0)
+	return nil
+}
+
+// rateLimit returns the minimum duration between requests for the given number of slots to stay within
+// the configured blocks per second.
+func (s *Service) rateLimit(count uint64) time.Duration {
+	if s.cfg.BlocksPerSecond == 0 {
+		return 0
+	}
+	return time.Duration(count) * time.Second / time.Duration(s.cfg.BlocksPerSecond)
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
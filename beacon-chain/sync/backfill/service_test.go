@@ -0,0 +1,100 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// peerBlocks serves the blocks of the given chain that fall in the requested range.
+func peerBlocks(chain []interfaces.ReadOnlySignedBeaconBlock) fetchFunc {
+	return func(_ context.Context, _ peer.ID, req *pb.BeaconBlocksByRangeRequest) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+		var blks []interfaces.ReadOnlySignedBeaconBlock
+		for _, b := range chain {
+			if b.Block().Slot() >= req.StartSlot && b.Block().Slot() < req.StartSlot+primitives.Slot(req.Count) {
+				blks = append(blks, b)
+			}
+		}
+		return blks, nil
+	}
+}
+
+func TestService_Backfill(t *testing.T) {
+	ctx := context.Background()
+	st, keys := util.DeterministicGenesisState(t, 16)
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	genesisRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	chain, roots := signedChain(t, st, keys, genesisRoot, 1, 2, 3, 5, 6, 9, 10, 14)
+	origin, originRoot := chain[len(chain)-1], roots[len(roots)-1]
+	gap := chain[:len(chain)-1]
+
+	d := dbtest.SetupDB(t)
+	require.NoError(t, d.SaveBlock(ctx, genesis))
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, d.SaveBlock(ctx, origin))
+	require.NoError(t, d.SaveState(ctx, st, originRoot))
+	require.NoError(t, d.(*kv.Store).SaveOriginCheckpointBlockRoot(ctx, originRoot))
+	require.NoError(t, d.SaveBackfillBlockRoot(ctx, originRoot))
+	status := NewStatus(d)
+	require.NoError(t, status.Reload(ctx))
+	require.Equal(t, false, status.Complete())
+
+	p2p := p2ptest.NewTestP2P(t)
+	s := NewService(ctx, &Config{DB: d, P2P: p2p, Status: status, BatchSize: 3})
+	require.NoError(t, s.initialize(ctx))
+	require.Equal(t, primitives.Slot(14), s.low)
+
+	// A peer answering with blocks of another chain is penalized, and the slots are requested again.
+	forked, _ := signedChain(t, st, keys, [32]byte{'a'}, 12, 13)
+	s.fetch = peerBlocks(forked)
+	_, err = s.step(ctx, "bad")
+	require.ErrorIs(t, err, errUnexpectedRoot)
+	count, err := p2p.Peers().Scorers().BadResponsesScorer().Count("bad")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, primitives.Slot(14), s.low)
+	assert.Equal(t, primitives.Slot(14), status.StartGap())
+
+	s.fetch = peerBlocks(gap)
+	for s.expected != s.genesisRoot {
+		_, err := s.step(ctx, "good")
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.finish(ctx))
+
+	assert.Equal(t, true, status.Complete())
+	bfRoot, err := d.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, genesisRoot, bfRoot)
+	for i, r := range roots[:len(roots)-1] {
+		assert.Equal(t, true, d.HasBlock(ctx, r), "block %d was not saved", i)
+		assert.Equal(t, true, d.IsFinalizedBlock(ctx, r), "block %d is not in the finalized index", i)
+	}
+	assert.Equal(t, true, d.IsFinalizedBlock(ctx, genesisRoot))
+	child, err := d.FinalizedChildBlock(ctx, genesisRoot)
+	require.NoError(t, err)
+	childRoot, err := child.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, roots[0], childRoot)
+}
+
+func TestService_RateLimit(t *testing.T) {
+	s := NewService(context.Background(), &Config{BlocksPerSecond: 32})
+	assert.Equal(t, defaultBatchSize, int(s.cfg.BatchSize))
+	assert.Equal(t, int64(2e9), int64(s.rateLimit(64)))
+	s.cfg.BlocksPerSecond = 0
+	assert.Equal(t, int64(0), int64(s.rateLimit(64)))
+}
@@ -2,6 +2,7 @@ package backfill
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
@@ -26,18 +27,25 @@ type Status struct {
 	start       primitives.Slot
 	end         primitives.Slot
 	store       BackfillDB
+	earliest    primitives.Slot
 	genesisSync bool
+	lock        sync.RWMutex
 }
 
 // SlotCovered uses StartGap() and EndGap() to determine if the given slot is covered by the current chain history.
 // If the slot is <= StartGap(), or >= EndGap(), the result is true.
-// If the slot is between StartGap() and EndGap(), the result is false.
+// If the slot is between StartGap() and EndGap(), or below a pruned EarliestSlot(), the result is false.
 func (s *Status) SlotCovered(sl primitives.Slot) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if sl < s.earliest {
+		return false
+	}
 	// short circuit if the node was synced from genesis
 	if s.genesisSync {
 		return true
 	}
-	if s.StartGap() < sl && sl < s.EndGap() {
+	if s.start < sl && sl < s.end {
 		return false
 	}
 	return true
@@ -45,29 +53,60 @@ func (s *Status) SlotCovered(sl primitives.Slot) bool {
 
 // StartGap returns the slot at the beginning of the range that needs to be backfilled.
 func (s *Status) StartGap() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.start
 }
 
 // EndGap returns the slot at the end of the range that needs to be backfilled.
 func (s *Status) EndGap() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.end
 }
 
+// EarliestSlot returns the slot below which history was pruned from the database, 0 if it never was.
+func (s *Status) EarliestSlot() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.earliest
+}
+
+// Complete returns true if there is no gap left to backfill, either because the node was synced from
+// genesis or because backfilling reached the genesis block or the pruned history.
+func (s *Status) Complete() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.genesisSync || s.start <= s.earliest
+}
+
 var ErrAdvancePastOrigin = errors.New("cannot advance backfill Status beyond the origin checkpoint slot")
 
 // Advance advances the backfill position to the given slot & root.
 // It updates the backfill block root entry in the database,
 // and also updates the Status value's copy of the backfill position slot.
 func (s *Status) Advance(ctx context.Context, upTo primitives.Slot, root [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	if upTo > s.end {
 		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.end)
 	}
+	if err := s.store.SaveBackfillBlockRoot(ctx, root); err != nil {
+		return err
+	}
 	s.start = upTo
-	return s.store.SaveBackfillBlockRoot(ctx, root)
+	return nil
 }
 
 // Reload queries the database for backfill status, initializing the internal data and validating the database state.
 func (s *Status) Reload(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	earliest, err := s.store.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error retrieving earliest available slot")
+	}
+	s.earliest = earliest
 	cpRoot, err := s.store.OriginCheckpointBlockRoot(ctx)
 	if err != nil {
 		// mark genesis sync and short circuit further lookups
@@ -81,6 +120,11 @@ func (s *Status) Reload(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "error retrieving block for origin checkpoint root=%#x", cpRoot)
 	}
+	if cpBlock == nil && s.earliest > 0 {
+		// the origin checkpoint block was pruned along with the whole gap
+		s.start, s.end = s.earliest, s.earliest
+		return nil
+	}
 	if err := blocks.BeaconBlockIsNil(cpBlock); err != nil {
 		return err
 	}
@@ -119,4 +163,5 @@ type BackfillDB interface {
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
 	Block(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
 }
@@ -23,6 +23,7 @@ type mockBackfillDB struct {
 	originCheckpointBlockRoot func(ctx context.Context) ([32]byte, error)
 	backfillBlockRoot         func(ctx context.Context) ([32]byte, error)
 	block                     func(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
+	earliestAvailableSlot     func(ctx context.Context) (primitives.Slot, error)
 }
 
 var _ BackfillDB = &mockBackfillDB{}
@@ -62,6 +63,14 @@ func (db *mockBackfillDB) Block(ctx context.Context, blockRoot [32]byte) (interf
 	return nil, errEmptyMockDBMethod
 }
 
+// EarliestAvailableSlot defaults to a db that was never pruned.
+func (db *mockBackfillDB) EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error) {
+	if db.earliestAvailableSlot != nil {
+		return db.earliestAvailableSlot(ctx)
+	}
+	return 0, nil
+}
+
 func TestSlotCovered(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -105,6 +114,18 @@ func TestSlotCovered(t *testing.T) {
 			slot:   100,
 			result: true,
 		},
+		{
+			name:   "below pruned history false",
+			status: &Status{genesisSync: true, earliest: 64},
+			slot:   63,
+			result: false,
+		},
+		{
+			name:   "equal pruned history true",
+			status: &Status{genesisSync: true, earliest: 64},
+			slot:   64,
+			result: true,
+		},
 	}
 	for _, c := range cases {
 		result := c.status.SlotCovered(c.slot)
@@ -336,6 +357,20 @@ func TestReload(t *testing.T) {
 			err:      derp,
 			expected: &Status{genesisSync: false, start: backfillSlot, end: originSlot},
 		},
+		{
+			name: "origin block pruned",
+			db: &mockBackfillDB{
+				genesisBlockRoot:          goodBlockRoot(params.BeaconConfig().ZeroHash),
+				originCheckpointBlockRoot: goodBlockRoot(originRoot),
+				block: func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+					return nil, nil
+				},
+				earliestAvailableSlot: func(ctx context.Context) (primitives.Slot, error) {
+					return originSlot + 1, nil
+				},
+			},
+			expected: &Status{genesisSync: false, start: originSlot + 1, end: originSlot + 1},
+		},
 	}
 
 	for _, c := range cases {
@@ -356,3 +391,10 @@ func TestReload(t *testing.T) {
 		require.Equal(t, c.expected.end, s.end)
 	}
 }
+
+func TestComplete(t *testing.T) {
+	require.Equal(t, true, (&Status{genesisSync: true, start: 10, end: 20}).Complete())
+	require.Equal(t, false, (&Status{start: 10, end: 20}).Complete())
+	require.Equal(t, true, (&Status{start: 0, end: 20}).Complete())
+	require.Equal(t, true, (&Status{start: 10, end: 20, earliest: 12}).Complete())
+}
@@ -0,0 +1,101 @@
+package backfill
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+var (
+	errUnexpectedRoot   = errors.New("block root does not match the parent root of its child")
+	errUnknownProposer  = errors.New("proposer index is not in the validator registry")
+	errInvalidSignature = errors.New("invalid proposer signature in batch")
+)
+
+// verifier checks backfilled blocks against the chain they extend down to genesis. Validator public
+// keys never change and the registry only grows, so that the registry of the origin checkpoint state
+// holds the keys of the proposers of all the blocks before it.
+type verifier struct {
+	keys     [][fieldparams.BLSPubkeyLength]byte
+	schedule forks.OrderedSchedule
+	gvr      []byte
+}
+
+func newVerifier(st state.ReadOnlyBeaconState) (*verifier, error) {
+	if st == nil || st.IsNil() {
+		return nil, errors.New("nil origin state")
+	}
+	keys := make([][fieldparams.BLSPubkeyLength]byte, st.NumValidators())
+	for i := range keys {
+		keys[i] = st.PubkeyAtIndex(primitives.ValidatorIndex(i))
+	}
+	return &verifier{
+		keys:     keys,
+		schedule: forks.NewOrderedSchedule(params.BeaconConfig()),
+		gvr:      st.GenesisValidatorsRoot(),
+	}, nil
+}
+
+// verify checks that the given blocks, ordered by ascending slot, form a chain whose last block has
+// the expected root, and batch verifies their proposer signatures. It returns the roots of the blocks.
+func (v *verifier) verify(blks []interfaces.ReadOnlySignedBeaconBlock, expected [32]byte) ([][32]byte, error) {
+	roots := make([][32]byte, len(blks))
+	for i := len(blks) - 1; i >= 0; i-- {
+		if err := blocks.BeaconBlockIsNil(blks[i]); err != nil {
+			return nil, err
+		}
+		root, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if root != expected {
+			return nil, errors.Wrapf(errUnexpectedRoot, "slot=%d, root=%#x, expected=%#x", blks[i].Block().Slot(), root, expected)
+		}
+		roots[i] = root
+		expected = blks[i].Block().ParentRoot()
+	}
+
+	set := bls.NewSet()
+	for i, blk := range blks {
+		proposer := blk.Block().ProposerIndex()
+		if uint64(proposer) >= uint64(len(v.keys)) {
+			return nil, errors.Wrapf(errUnknownProposer, "slot=%d, proposer=%d", blk.Block().Slot(), proposer)
+		}
+		version, err := v.schedule.VersionForEpoch(slots.ToEpoch(blk.Block().Slot()))
+		if err != nil {
+			return nil, err
+		}
+		domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, version[:], v.gvr)
+		if err != nil {
+			return nil, err
+		}
+		sig := blk.Signature()
+		root := roots[i]
+		blkSet, err := signing.BlockSignatureBatch(v.keys[proposer][:], sig[:], domain, func() ([32]byte, error) {
+			return root, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		set.Join(blkSet)
+	}
+	if len(set.Signatures) == 0 {
+		return roots, nil
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not verify proposer signatures")
+	}
+	if !verified {
+		return nil, errInvalidSignature
+	}
+	return roots, nil
+}
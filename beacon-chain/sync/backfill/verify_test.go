@@ -0,0 +1,82 @@
+package backfill
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// signedChain returns blocks at the given ascending slots, each one the child of the previous one and the
+// first one the child of parent, signed by their proposer.
+func signedChain(t *testing.T, st state.ReadOnlyBeaconState, keys []bls.SecretKey, parent [32]byte, sl ...primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte) {
+	schedule := forks.NewOrderedSchedule(params.BeaconConfig())
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, len(sl))
+	roots := make([][32]byte, len(sl))
+	for i, slot := range sl {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex = primitives.ValidatorIndex(uint64(slot) % uint64(len(keys)))
+		b.Block.ParentRoot = parent[:]
+		version, err := schedule.VersionForEpoch(slots.ToEpoch(slot))
+		require.NoError(t, err)
+		domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, version[:], st.GenesisValidatorsRoot())
+		require.NoError(t, err)
+		sr, err := signing.ComputeSigningRoot(b.Block, domain)
+		require.NoError(t, err)
+		b.Signature = keys[b.Block.ProposerIndex].Sign(sr[:]).Marshal()
+		blks[i], err = blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		roots[i], err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		parent = roots[i]
+	}
+	return blks, roots
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	st, keys := util.DeterministicGenesisState(t, 16)
+	v, err := newVerifier(st)
+	require.NoError(t, err)
+	parent := [32]byte{'a'}
+	blks, roots := signedChain(t, st, keys, parent, 3, 4, 7, 8)
+
+	t.Run("valid chain", func(t *testing.T) {
+		got, err := v.verify(blks, roots[3])
+		require.NoError(t, err)
+		require.DeepEqual(t, roots, got)
+	})
+	t.Run("unexpected root", func(t *testing.T) {
+		_, err := v.verify(blks, [32]byte{'b'})
+		require.ErrorIs(t, err, errUnexpectedRoot)
+	})
+	t.Run("broken link", func(t *testing.T) {
+		broken := []interfaces.ReadOnlySignedBeaconBlock{blks[0], blks[2], blks[3]}
+		_, err := v.verify(broken, roots[3])
+		require.ErrorIs(t, err, errUnexpectedRoot)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		// Swap the keys of the proposers of slots 3 and 4.
+		swapped := *v
+		swapped.keys = append(swapped.keys[:0:0], v.keys...)
+		swapped.keys[3], swapped.keys[4] = swapped.keys[4], swapped.keys[3]
+		_, err := swapped.verify(blks, roots[3])
+		require.ErrorIs(t, err, errInvalidSignature)
+	})
+	t.Run("unknown proposer", func(t *testing.T) {
+		small, _ := util.DeterministicGenesisState(t, 4)
+		sv, err := newVerifier(small)
+		require.NoError(t, err)
+		_, err = sv.verify(blks, roots[3])
+		require.ErrorIs(t, err, errUnknownProposer)
+	})
+}
@@ -37,6 +37,17 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		tracing.AnnotateError(span, err)
 		return err
 	}
+	// Blocks below the earliest available slot were pruned, an empty response would claim the slots are empty.
+	earliest, err := s.cfg.beaconDB.EarliestAvailableSlot(ctx)
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	if rp.start < earliest {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return errors.Wrapf(p2ptypes.ErrResourceUnavailable, "start slot %d is below earliest available slot %d", rp.start, earliest)
+	}
 
 	blockLimiter, err := s.rateLimiter.topicCollector(string(stream.Protocol()))
 	if err != nil {
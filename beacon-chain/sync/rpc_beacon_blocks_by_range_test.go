@@ -410,6 +410,53 @@ func TestRPCBeaconBlocksByRange_ReturnsGenesisBlock(t *testing.T) {
 	}
 }
 
+func TestRPCBeaconBlocksByRange_PrunedHistory(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	d := db.SetupDB(t)
+	ctx := context.Background()
+
+	// Save blocks at slots 1 to 20 and a state at slot 10, then prune the blocks below it.
+	var prevRoot [32]byte
+	for i := primitives.Slot(1); i <= 20; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i
+		copy(blk.Block.ParentRoot, prevRoot[:])
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, d, blk)
+		if i == 10 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(i))
+			require.NoError(t, d.SaveState(ctx, st, root))
+		}
+		prevRoot = root
+	}
+	_, err := d.PruneHistory(ctx, 10, 100)
+	require.NoError(t, err)
+
+	clock := startup.NewClock(time.Unix(0, 0), [32]byte{})
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, clock: clock, chain: &chainMock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCBlocksByRangeTopicV1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+	stream1, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+
+	req := &ethpb.BeaconBlocksByRangeRequest{StartSlot: 5, Step: 1, Count: 10}
+	err = r.beaconBlocksByRangeRPCHandler(ctx, req, stream1)
+	require.ErrorIs(t, err, p2ptypes.ErrResourceUnavailable)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
 func TestRPCBeaconBlocksByRange_RPCHandlerRateLimitOverflow(t *testing.T) {
 	d := db.SetupDB(t)
 	saveBlocks := func(req *ethpb.BeaconBlocksByRangeRequest) {
@@ -152,6 +152,13 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// HistoryRetentionEpochs specifies the number of epochs of blocks and states kept in the beaconDB.
+	HistoryRetentionEpochs = &cli.Uint64Flag{
+		Name:  "history-retention-epochs",
+		Usage: "The number of epochs of blocks and archived states kept in the beaconDB, older finalized history being pruned. " +
+			"0 keeps all history, other values must be at least MIN_EPOCHS_FOR_BLOCK_REQUESTS.",
+		Value: 0,
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -164,6 +171,18 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 2,
 	}
+	// BackfillBatchSize specifies the number of slots of blocks requested at once by the backfill service.
+	BackfillBatchSize = &cli.Uint64Flag{
+		Name:  "backfill-batch-size",
+		Usage: "The number of slots of blocks requested at once when backfilling the blocks before the checkpoint sync origin.",
+		Value: 64,
+	}
+	// BackfillBlocksPerSecond specifies the maximum rate of blocks requested by the backfill service.
+	BackfillBlocksPerSecond = &cli.Uint64Flag{
+		Name:  "backfill-blocks-per-second",
+		Usage: "The maximum number of slots of blocks requested per second when backfilling the blocks before the checkpoint sync origin.",
+		Value: 32,
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
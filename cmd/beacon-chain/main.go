@@ -54,10 +54,13 @@ var appFlags = []cli.Flag{
 	flags.SetGCPercent,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.BackfillBatchSize,
+	flags.BackfillBlocksPerSecond,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.HistoryRetentionEpochs,
 	flags.EnableDebugRPCEndpoints,
 	flags.EnableOverNodeRPCEndpoints,
 	flags.SubscribeToAllSubnets,
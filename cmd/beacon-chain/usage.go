@@ -110,8 +110,11 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ExecutionJWTSecretFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.HistoryRetentionEpochs,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.BackfillBatchSize,
+			flags.BackfillBlocksPerSecond,
 			flags.EnableDebugRPCEndpoints,
 			flags.EnableOverNodeRPCEndpoints,
 			flags.SubscribeToAllSubnets,
@@ -0,0 +1,756 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var auditSupplyFlags = struct {
+	Path            string
+	ChainConfigFile string
+	ToEpoch         uint64
+}{}
+
+var auditSupplyCmd = &cli.Command{
+	Name:  "audit-supply",
+	Usage: "replays the finalized chain and checks the OVER supply ledger at every epoch boundary",
+	Description: `Replays every canonical block from genesis up to the finalized checkpoint and, at each epoch
+boundary, compares the tokens held by the chain (validator balances, the current epoch reserve and
+cumulative withdrawals) with the tokens it may hold (genesis supply, cumulative deposits and cumulative
+epoch issuance, minus slashing burns).
+
+Held tokens fall short of the expected amount by the "unpaid" tokens: the attestation and sync committee
+penalties, which are computed from the replayed states and burned, and the rewards of missed duties,
+which are never paid out. The books do not balance when the chain holds more than it may, when the
+reserve grows, when the reserve has paid out more than the cumulative feedback boost allows, or when
+the unpaid tokens of an epoch are not explained by its penalties and by rewards it could have paid
+out. The first such epoch is reported together with a per-component diff against the previous
+boundary.
+
+The database must contain the genesis state and every canonical block, so checkpoint synced nodes
+cannot be audited.`,
+	Action: func(cliCtx *cli.Context) error {
+		if err := auditSupplyAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not audit supply")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &auditSupplyFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "The path to a YAML file with chain config values, defaults to the mainnet config",
+			Destination: &auditSupplyFlags.ChainConfigFile,
+		},
+		&cli.Uint64Flag{
+			Name:        "to-epoch",
+			Usage:       "last epoch boundary to audit, defaults to the finalized epoch",
+			Destination: &auditSupplyFlags.ToEpoch,
+		},
+	},
+}
+
+func auditSupplyAction(cliCtx *cli.Context) error {
+	flags := auditSupplyFlags
+	if flags.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(flags.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+	ctx := cliCtx.Context
+	store, err := kv.NewKVStore(ctx, flags.Path)
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon db")
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon db")
+		}
+	}()
+
+	genesis, err := store.GenesisState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis state")
+	}
+	if genesis == nil || genesis.IsNil() {
+		return errors.New("genesis state not found in db, checkpoint synced nodes cannot be audited")
+	}
+	finalized, err := store.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	toEpoch := finalized.Epoch
+	if flags.ToEpoch != 0 {
+		if primitives.Epoch(flags.ToEpoch) > toEpoch {
+			return fmt.Errorf("epoch %d is not finalized, latest finalized epoch is %d", flags.ToEpoch, toEpoch)
+		}
+		toEpoch = primitives.Epoch(flags.ToEpoch)
+	}
+	refs, err := canonicalBlockRefs(ctx, store, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return err
+	}
+
+	a, err := newSupplyAuditor(genesis)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"genesisSupply": a.ledger.GenesisSupply,
+		"blocks":        len(refs),
+		"toEpoch":       toEpoch,
+	}).Info("Auditing supply")
+	failure, err := a.run(ctx, refs, store.Block, toEpoch)
+	if err != nil {
+		return err
+	}
+	if failure != nil {
+		fmt.Print(failure.String())
+		return fmt.Errorf("supply books do not balance at epoch %d", failure.Epoch)
+	}
+	missed, err := a.ledger.missedRewards()
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"epoch":         a.ledger.Epoch,
+		"balances":      a.ledger.Balances,
+		"reserve":       a.ledger.Reserve,
+		"withdrawals":   a.ledger.Withdrawals,
+		"deposits":      a.ledger.Deposits,
+		"issuance":      a.ledger.Issuance,
+		"slashingBurns": a.ledger.SlashingBurns,
+		"penalties":     a.ledger.Penalties,
+		"missedRewards": missed,
+	}).Info("Supply books balance at every audited epoch")
+	return nil
+}
+
+// blockRef identifies a canonical block without holding it in memory.
+type blockRef struct {
+	root [32]byte
+	slot primitives.Slot
+}
+
+// blockLoader loads a block from the database.
+type blockLoader func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
+
+// canonicalBlockRefs walks parent roots from the given root back to the genesis block and
+// returns the roots and slots of the blocks on the way in ascending slot order, without the
+// genesis block. Only one block is held in memory at a time.
+func canonicalBlockRefs(ctx context.Context, store *kv.Store, root [32]byte) ([]blockRef, error) {
+	genesisRoot, err := store.GenesisBlockRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis block root")
+	}
+	refs := make([]blockRef, 0)
+	for root != genesisRoot {
+		blk, err := loadBlock(ctx, store.Block, root)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, blockRef{root: root, slot: blk.Block().Slot()})
+		root = blk.Block().ParentRoot()
+	}
+	for i, j := 0, len(refs)-1; i < j; i, j = i+1, j-1 {
+		refs[i], refs[j] = refs[j], refs[i]
+	}
+	return refs, nil
+}
+
+// loadBlock loads a canonical block, which must be in the database.
+func loadBlock(ctx context.Context, load blockLoader, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	blk, err := load(ctx, root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get block %#x", root)
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, fmt.Errorf("block %#x missing from db, the canonical chain must be complete back to genesis", root)
+	}
+	return blk, nil
+}
+
+// supplyLedger is a snapshot of the supply components at an epoch boundary. All amounts
+// except Balances and Reserve are cumulative since genesis.
+type supplyLedger struct {
+	Epoch         primitives.Epoch
+	Balances      uint64
+	Reserve       uint64
+	Withdrawals   uint64
+	GenesisSupply uint64
+	Deposits      uint64
+	Issuance      uint64
+	SlashingBurns uint64
+	// Penalties are the attestation and sync committee penalties burned.
+	Penalties uint64
+	// ReserveAllowance is the cumulative feedback boost, plus per increment rounding,
+	// that may have been paid out of the reserve.
+	ReserveAllowance uint64
+}
+
+// held returns the tokens the chain accounts for.
+func (l *supplyLedger) held() uint64 {
+	return l.Balances + l.Reserve + l.Withdrawals
+}
+
+// expected returns the tokens the chain may account for at most.
+func (l *supplyLedger) expected() uint64 {
+	return l.GenesisSupply + l.Deposits + l.Issuance - l.SlashingBurns
+}
+
+// unpaid returns the issuance that was burned by penalties or never paid out. It is negative
+// when the chain holds more tokens than were ever issued.
+func (l *supplyLedger) unpaid() (int64, error) {
+	expected, err := toSigned(l.expected())
+	if err != nil {
+		return 0, err
+	}
+	held, err := toSigned(l.held())
+	if err != nil {
+		return 0, err
+	}
+	return subSigned(expected, held)
+}
+
+// missedRewards returns the unpaid tokens which the penalties do not explain, that is the
+// issuance and reserve usage which was never paid out as rewards.
+func (l *supplyLedger) missedRewards() (int64, error) {
+	unpaid, err := l.unpaid()
+	if err != nil {
+		return 0, err
+	}
+	penalties, err := toSigned(l.Penalties)
+	if err != nil {
+		return 0, err
+	}
+	return subSigned(unpaid, penalties)
+}
+
+// reserveSpent returns how much of the genesis reserve has been paid out.
+func (l *supplyLedger) reserveSpent(genesisReserve uint64) uint64 {
+	return genesisReserve - l.Reserve
+}
+
+// supplyAuditFailure describes the first epoch boundary at which the books do not balance.
+type supplyAuditFailure struct {
+	Epoch    primitives.Epoch
+	Reasons  []string
+	Previous supplyLedger
+	Current  supplyLedger
+	Rows     []supplyRow
+}
+
+// supplyRow compares a supply component at two epoch boundaries.
+type supplyRow struct {
+	name            string
+	prev, cur, diff int64
+}
+
+// supplyRows compares the components of two ledgers.
+func supplyRows(prev, cur *supplyLedger) ([]supplyRow, error) {
+	components := []struct {
+		name string
+		get  func(l *supplyLedger) (int64, error)
+	}{
+		{"balances", func(l *supplyLedger) (int64, error) { return toSigned(l.Balances) }},
+		{"reserve", func(l *supplyLedger) (int64, error) { return toSigned(l.Reserve) }},
+		{"withdrawals", func(l *supplyLedger) (int64, error) { return toSigned(l.Withdrawals) }},
+		{"held", func(l *supplyLedger) (int64, error) { return toSigned(l.held()) }},
+		{"genesis_supply", func(l *supplyLedger) (int64, error) { return toSigned(l.GenesisSupply) }},
+		{"deposits", func(l *supplyLedger) (int64, error) { return toSigned(l.Deposits) }},
+		{"issuance", func(l *supplyLedger) (int64, error) { return toSigned(l.Issuance) }},
+		{"slashing_burns", func(l *supplyLedger) (int64, error) { return toSigned(l.SlashingBurns) }},
+		{"expected", func(l *supplyLedger) (int64, error) { return toSigned(l.expected()) }},
+		{"unpaid", (*supplyLedger).unpaid},
+		{"penalties", func(l *supplyLedger) (int64, error) { return toSigned(l.Penalties) }},
+		{"missed_rewards", (*supplyLedger).missedRewards},
+		{"reserve_allowance", func(l *supplyLedger) (int64, error) { return toSigned(l.ReserveAllowance) }},
+	}
+	rows := make([]supplyRow, len(components))
+	for i, c := range components {
+		p, err := c.get(prev)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute %s", c.name)
+		}
+		q, err := c.get(cur)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute %s", c.name)
+		}
+		d, err := subSigned(q, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute %s diff", c.name)
+		}
+		rows[i] = supplyRow{name: c.name, prev: p, cur: q, diff: d}
+	}
+	return rows, nil
+}
+
+func (f *supplyAuditFailure) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Supply books do not balance at epoch %d:\n", f.Epoch)
+	for _, r := range f.Reasons {
+		fmt.Fprintf(&b, "  - %s\n", r)
+	}
+	fmt.Fprintf(&b, "%-20s %22s %22s %22s\n", "component", fmt.Sprintf("epoch %d", f.Previous.Epoch), fmt.Sprintf("epoch %d", f.Current.Epoch), "diff")
+	for _, r := range f.Rows {
+		fmt.Fprintf(&b, "%-20s %22d %22d %+22d\n", r.name, r.prev, r.cur, r.diff)
+	}
+	return b.String()
+}
+
+// supplyAuditor replays blocks on top of the genesis state and keeps the supply ledger.
+type supplyAuditor struct {
+	st             state.BeaconState
+	genesisReserve uint64
+	ledger         supplyLedger
+	// audited is the ledger at the last audited epoch boundary, while ledger keeps accumulating
+	// the deposits, withdrawals, burns and penalties of the epoch being replayed.
+	audited supplyLedger
+	// pendingAllowance is the reserve allowance of the epoch being replayed, computed
+	// from the state at its start.
+	pendingAllowance uint64
+}
+
+func newSupplyAuditor(genesis state.BeaconState) (*supplyAuditor, error) {
+	a := &supplyAuditor{
+		st:             genesis,
+		genesisReserve: genesis.CurrentEpochReserve(),
+	}
+	a.ledger.Epoch = slots.ToEpoch(genesis.Slot())
+	a.ledger.Balances = sumBalances(genesis)
+	a.ledger.Reserve = genesis.CurrentEpochReserve()
+	a.ledger.GenesisSupply = a.ledger.Balances + a.ledger.Reserve
+	allowance, err := reserveAllowance(genesis)
+	if err != nil {
+		return nil, err
+	}
+	a.pendingAllowance = allowance
+	a.audited = a.ledger
+	return a, nil
+}
+
+// run replays the given blocks, loading them one at a time, and audits every epoch boundary
+// up to toEpoch. It returns the first failing boundary, or nil if the books balance everywhere.
+func (a *supplyAuditor) run(ctx context.Context, refs []blockRef, load blockLoader, toEpoch primitives.Epoch) (*supplyAuditFailure, error) {
+	endSlot, err := slots.EpochStart(toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if ref.slot >= endSlot {
+			break
+		}
+		if failure, err := a.advance(ctx, ref.slot); err != nil || failure != nil {
+			return failure, err
+		}
+		blk, err := loadBlock(ctx, load, ref.root)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.processBlock(ctx, blk); err != nil {
+			return nil, err
+		}
+	}
+	return a.advance(ctx, endSlot)
+}
+
+// advance processes empty slots up to the given slot, auditing every epoch boundary on the way.
+func (a *supplyAuditor) advance(ctx context.Context, slot primitives.Slot) (*supplyAuditFailure, error) {
+	for a.st.Slot() < slot {
+		boundary, err := slots.EpochStart(slots.ToEpoch(a.st.Slot()) + 1)
+		if err != nil {
+			return nil, err
+		}
+		if boundary > slot {
+			a.st, err = stategen.ReplayProcessSlots(ctx, a.st, slot)
+			return nil, err
+		}
+		a.st, err = stategen.ReplayProcessSlots(ctx, a.st, boundary-1)
+		if err != nil {
+			return nil, err
+		}
+		burn, err := correlationPenalties(a.st)
+		if err != nil {
+			return nil, err
+		}
+		a.ledger.SlashingBurns += burn
+		penalties, err := attestationPenalties(ctx, a.st)
+		if err != nil {
+			return nil, err
+		}
+		a.ledger.Penalties += penalties
+		a.st, err = stategen.ReplayProcessSlots(ctx, a.st, boundary)
+		if err != nil {
+			return nil, err
+		}
+		if failure, err := a.audit(); err != nil || failure != nil {
+			return failure, err
+		}
+	}
+	return nil, nil
+}
+
+// processBlock applies the block to the state and accounts for the deposits, withdrawals,
+// slashings and sync committee penalties it contains.
+func (a *supplyAuditor) processBlock(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) error {
+	body := blk.Block().Body()
+	if blk.Version() >= version.Altair {
+		penalties, err := syncCommitteePenalties(a.st, body)
+		if err != nil {
+			return errors.Wrapf(err, "could not compute sync committee penalties of block at slot %d", blk.Block().Slot())
+		}
+		a.ledger.Penalties += penalties
+	}
+	slashed := make(map[primitives.ValidatorIndex]bool)
+	for _, s := range body.ProposerSlashings() {
+		slashed[s.Header_1.Header.ProposerIndex] = false
+	}
+	for _, s := range body.AttesterSlashings() {
+		for _, idx := range s.Attestation_1.AttestingIndices {
+			slashed[primitives.ValidatorIndex(idx)] = false
+		}
+	}
+	for idx := range slashed {
+		v, err := a.st.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return err
+		}
+		slashed[idx] = v.Slashed()
+	}
+	preBalances := a.st.Balances()
+
+	var err error
+	a.st, err = transition.ProcessBlockForStateRoot(ctx, a.st, blk)
+	if err != nil {
+		return errors.Wrapf(err, "could not process block at slot %d", blk.Block().Slot())
+	}
+
+	for idx, wasSlashed := range slashed {
+		if wasSlashed {
+			continue
+		}
+		burn, err := slashingBurn(a.st, idx, preBalances)
+		if err != nil {
+			return err
+		}
+		a.ledger.SlashingBurns += burn
+	}
+	for _, d := range body.Deposits() {
+		// Deposits with an invalid signature for an unknown public key are not credited.
+		if _, ok := a.st.ValidatorIndexByPubkey(bytesutil.ToBytes48(d.Data.PublicKey)); ok {
+			a.ledger.Deposits += d.Data.Amount
+		}
+	}
+	if blk.Version() >= version.Capella {
+		payload, err := body.Execution()
+		if err != nil {
+			return err
+		}
+		ws, err := payload.Withdrawals()
+		if err != nil {
+			return errors.Wrapf(err, "could not get withdrawals of block at slot %d", blk.Block().Slot())
+		}
+		for _, w := range ws {
+			a.ledger.Withdrawals += w.Amount
+		}
+	}
+	return nil
+}
+
+// audit checks the ledger at the epoch boundary the state is at.
+func (a *supplyAuditor) audit() (*supplyAuditFailure, error) {
+	prev := a.audited
+	cur := a.ledger
+	cur.Epoch = slots.ToEpoch(a.st.Slot())
+	cur.Balances = sumBalances(a.st)
+	cur.Reserve = a.st.CurrentEpochReserve()
+	for e := prev.Epoch; e < cur.Epoch; e++ {
+		cur.Issuance += helpers.EpochIssuance(e)
+	}
+	cur.ReserveAllowance += a.pendingAllowance
+	allowance, err := reserveAllowance(a.st)
+	if err != nil {
+		return nil, err
+	}
+	a.pendingAllowance = allowance
+	a.ledger = cur
+	a.audited = cur
+
+	prevMissed, err := prev.missedRewards()
+	if err != nil {
+		return nil, err
+	}
+	curMissed, err := cur.missedRewards()
+	if err != nil {
+		return nil, err
+	}
+	missed, err := subSigned(curMissed, prevMissed)
+	if err != nil {
+		return nil, err
+	}
+	// The rewards of the epoch are paid out of its issuance and the reserve usage, so at most
+	// these can go unpaid, and at least nothing beyond the rounding of the reserve usage.
+	budget := cur.Issuance - prev.Issuance
+	if cur.Reserve < prev.Reserve {
+		budget += prev.Reserve - cur.Reserve
+	}
+	maxMissed, err := toSigned(budget)
+	if err != nil {
+		return nil, err
+	}
+	minMissed, err := toSigned(rewardRounding(a.st))
+	if err != nil {
+		return nil, err
+	}
+	minMissed = -minMissed
+
+	var reasons []string
+	if cur.held() > cur.expected() {
+		reasons = append(reasons, fmt.Sprintf("held supply exceeds expected supply by %d Gwei", cur.held()-cur.expected()))
+	}
+	if cur.Reserve > prev.Reserve {
+		reasons = append(reasons, fmt.Sprintf("reserve increased by %d Gwei", cur.Reserve-prev.Reserve))
+	}
+	if spent := cur.reserveSpent(a.genesisReserve); spent > cur.ReserveAllowance {
+		reasons = append(reasons, fmt.Sprintf("reserve paid out %d Gwei more than the feedback boost allows", spent-cur.ReserveAllowance))
+	}
+	if missed < minMissed {
+		reasons = append(reasons, fmt.Sprintf("missed rewards changed by %d Gwei, the chain paid out more than the epoch issued", missed))
+	}
+	if missed > maxMissed {
+		reasons = append(reasons, fmt.Sprintf("missed rewards changed by %d Gwei, more than the %d Gwei the epoch could pay out", missed, maxMissed))
+	}
+	if len(reasons) == 0 {
+		log.WithFields(log.Fields{
+			"epoch":         cur.Epoch,
+			"held":          cur.held(),
+			"penalties":     cur.Penalties,
+			"missedRewards": curMissed,
+		}).Debug("Supply books balance")
+		return nil, nil
+	}
+	rows, err := supplyRows(&prev, &cur)
+	if err != nil {
+		return nil, err
+	}
+	return &supplyAuditFailure{
+		Epoch:    cur.Epoch,
+		Reasons:  reasons,
+		Previous: prev,
+		Current:  cur,
+		Rows:     rows,
+	}, nil
+}
+
+// toSigned converts a Gwei amount for signed arithmetic, failing instead of wrapping around.
+func toSigned(amount uint64) (int64, error) {
+	if amount > math.MaxInt64 {
+		return 0, fmt.Errorf("amount of %d Gwei overflows int64", amount)
+	}
+	return int64(amount), nil
+}
+
+// subSigned returns a - b, failing instead of wrapping around.
+func subSigned(a, b int64) (int64, error) {
+	d := a - b
+	if (b > 0 && d > a) || (b < 0 && d < a) {
+		return 0, fmt.Errorf("%d - %d overflows int64", a, b)
+	}
+	return d, nil
+}
+
+// rewardRounding returns by how many Gwei the rewards of an epoch may exceed its issuance and
+// reserve usage: every reward payment rounds down the reserve usage it draws by less than a
+// Gwei, and there is a payment per validator at the epoch transition and per attestation and
+// sync committee member in every block.
+func rewardRounding(st state.ReadOnlyBeaconState) uint64 {
+	cfg := params.BeaconConfig()
+	return uint64(st.NumValidators()) + uint64(cfg.SlotsPerEpoch)*(cfg.MaxAttestations+cfg.SyncCommitteeSize)
+}
+
+// attestationPenalties returns the attestation and inactivity penalties the upcoming epoch
+// transition will burn, given the state at the last slot of the epoch. The processing steps the
+// penalties depend on run on a copy of the state.
+func attestationPenalties(ctx context.Context, st state.BeaconState) (uint64, error) {
+	if st.Version() < version.Altair || slots.ToEpoch(st.Slot()) == params.BeaconConfig().GenesisEpoch {
+		return 0, nil
+	}
+	st = st.Copy()
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return 0, err
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return 0, err
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		return 0, err
+	}
+	st, vals, err = altair.ProcessInactivityAndBailOutScores(ctx, st, vals)
+	if err != nil {
+		return 0, err
+	}
+	deltas, _, err := altair.AttestationsDelta(st, bal, vals)
+	if err != nil {
+		return 0, err
+	}
+	balances := st.Balances()
+	burned := uint64(0)
+	for i, d := range deltas {
+		// Penalties are applied after the rewards and do not take the balance below zero.
+		penalty := d.SourcePenalty + d.TargetPenalty
+		if available := balances[i] + d.HeadReward + d.SourceReward + d.TargetReward; penalty > available {
+			penalty = available
+		}
+		burned += penalty
+	}
+	return burned, nil
+}
+
+// syncCommitteePenalties returns the penalties the sync aggregate of the block burns from the
+// sync committee members who did not participate, given the state the block is applied to.
+func syncCommitteePenalties(st state.BeaconState, body interfaces.ReadOnlyBeaconBlockBody) (uint64, error) {
+	aggregate, err := body.SyncAggregate()
+	if err != nil {
+		return 0, err
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return 0, err
+	}
+	_, participantReward, _, err := altair.SyncRewards(st)
+	if err != nil {
+		return 0, err
+	}
+	balances := st.Balances()
+	burned := uint64(0)
+	for i := uint64(0); i < aggregate.SyncCommitteeBits.Len() && i < uint64(len(committee.Pubkeys)); i++ {
+		if aggregate.SyncCommitteeBits.BitAt(i) {
+			continue
+		}
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(committee.Pubkeys[i]))
+		if !ok {
+			return 0, fmt.Errorf("sync committee member %#x not found", committee.Pubkeys[i])
+		}
+		penalty := participantReward
+		if balances[idx] < penalty {
+			penalty = balances[idx]
+		}
+		balances[idx] -= penalty
+		burned += penalty
+	}
+	return burned, nil
+}
+
+// reserveAllowance returns the most the reserve may pay out during the epoch of the given
+// epoch start state: the feedback boost plus one Gwei per active increment, which covers
+// the rounding up of the per increment reserve usage.
+func reserveAllowance(st state.ReadOnlyBeaconState) (uint64, error) {
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get total active balance")
+	}
+	return helpers.EpochFeedbackBoost(st) + activeBalance/params.BeaconConfig().EffectiveBalanceIncrement, nil
+}
+
+// slashingBurn returns the net amount burned when the validator got slashed by a block:
+// the initial penalty minus the whistleblower reward paid to the proposer.
+func slashingBurn(st state.ReadOnlyBeaconState, idx primitives.ValidatorIndex, preBalances []uint64) (uint64, error) {
+	v, err := st.ValidatorAtIndexReadOnly(idx)
+	if err != nil {
+		return 0, err
+	}
+	if !v.Slashed() {
+		return 0, nil
+	}
+	cfg := params.BeaconConfig()
+	var quotient uint64
+	switch {
+	case st.Version() == version.Phase0:
+		quotient = cfg.MinSlashingPenaltyQuotient
+	case st.Version() == version.Altair:
+		quotient = cfg.MinSlashingPenaltyQuotientAltair
+	default:
+		quotient = cfg.MinSlashingPenaltyQuotientBellatrix
+	}
+	penalty := v.EffectiveBalance() / quotient
+	if uint64(idx) < uint64(len(preBalances)) && preBalances[idx] < penalty {
+		penalty = preBalances[idx]
+	}
+	reward := v.EffectiveBalance() / cfg.WhistleBlowerRewardQuotient
+	if reward > penalty {
+		return 0, nil
+	}
+	return penalty - reward, nil
+}
+
+// correlationPenalties returns the slashing penalties the upcoming epoch transition will
+// burn, given the state at the last slot of the epoch.
+func correlationPenalties(st state.BeaconState) (uint64, error) {
+	multiplier, err := st.ProportionalSlashingMultiplier()
+	if err != nil {
+		return 0, err
+	}
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get total active balance")
+	}
+	totalSlashing := uint64(0)
+	for _, s := range st.Slashings() {
+		totalSlashing += s
+	}
+	adjusted := totalSlashing * multiplier
+	if adjusted > totalBalance {
+		adjusted = totalBalance
+	}
+	increment := params.BeaconConfig().EffectiveBalanceIncrement
+	withdrawableEpoch := slots.ToEpoch(st.Slot()) + params.BeaconConfig().EpochsPerSlashingsVector/2
+	balances := st.Balances()
+	burn := uint64(0)
+	err = st.ReadFromEveryValidator(func(idx int, v state.ReadOnlyValidator) error {
+		if !v.Slashed() || v.WithdrawableEpoch() != withdrawableEpoch {
+			return nil
+		}
+		penalty := v.EffectiveBalance() / increment * adjusted / totalBalance * increment
+		if balances[idx] < penalty {
+			penalty = balances[idx]
+		}
+		burn += penalty
+		return nil
+	})
+	return burn, err
+}
+
+func sumBalances(st state.ReadOnlyBeaconState) uint64 {
+	total := uint64(0)
+	for _, b := range st.Balances() {
+		total += b
+	}
+	return total
+}
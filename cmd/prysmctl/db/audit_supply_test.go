@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestSupplyAuditor_EmptyEpochs(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	st, _ := util.DeterministicGenesisStateCapella(t, 64)
+	require.NoError(t, st.SetCurrentEpochReserve(1000*params.BeaconConfig().EffectiveBalanceIncrement))
+	require.NoError(t, st.SetPreviousEpochReserve(st.CurrentEpochReserve()))
+
+	a, err := newSupplyAuditor(st)
+	require.NoError(t, err)
+	failure, err := a.run(context.Background(), nil, nil, 3)
+	require.NoError(t, err)
+	require.Equal(t, (*supplyAuditFailure)(nil), failure)
+	require.Equal(t, uint64(3), uint64(a.ledger.Epoch))
+	require.Equal(t, true, a.ledger.Issuance > 0)
+	// Nobody attested, so nothing was paid out and inactive validators were penalized.
+	require.Equal(t, true, a.ledger.Penalties > 0)
+	unpaid, err := a.ledger.unpaid()
+	require.NoError(t, err)
+	require.Equal(t, int64(a.ledger.Issuance+a.ledger.Penalties), unpaid)
+	missed, err := a.ledger.missedRewards()
+	require.NoError(t, err)
+	require.Equal(t, int64(a.ledger.Issuance), missed)
+	require.Equal(t, true, a.ledger.reserveSpent(a.genesisReserve) <= a.ledger.ReserveAllowance)
+}
+
+func TestSupplyAuditor_Imbalance(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	ctx := context.Background()
+
+	t.Run("minted balance", func(t *testing.T) {
+		st, _ := util.DeterministicGenesisStateCapella(t, 64)
+		a, err := newSupplyAuditor(st)
+		require.NoError(t, err)
+		failure, err := a.run(ctx, nil, nil, 1)
+		require.NoError(t, err)
+		require.Equal(t, (*supplyAuditFailure)(nil), failure)
+
+		// Far more than a single epoch could issue or penalize.
+		bal, err := a.st.BalanceAtIndex(0)
+		require.NoError(t, err)
+		require.NoError(t, a.st.UpdateBalancesAtIndex(0, bal+params.BeaconConfig().MaxTokenSupply))
+		failure, err = a.run(ctx, nil, nil, 2)
+		require.NoError(t, err)
+		require.NotNil(t, failure)
+		require.Equal(t, uint64(2), uint64(failure.Epoch))
+		require.Equal(t, 2, len(failure.Reasons))
+		assert.StringContains(t, "held supply exceeds expected supply", failure.Reasons[0])
+		assert.StringContains(t, "the chain paid out more than the epoch issued", failure.Reasons[1])
+		assert.StringContains(t, "balances", failure.String())
+	})
+	t.Run("burned balance", func(t *testing.T) {
+		st, _ := util.DeterministicGenesisStateCapella(t, 64)
+		a, err := newSupplyAuditor(st)
+		require.NoError(t, err)
+		failure, err := a.run(ctx, nil, nil, 1)
+		require.NoError(t, err)
+		require.Equal(t, (*supplyAuditFailure)(nil), failure)
+
+		// More than the penalties and the rewards the epoch could miss explain.
+		bal, err := a.st.BalanceAtIndex(0)
+		require.NoError(t, err)
+		burn := helpers.EpochIssuance(1) + 1
+		require.Equal(t, true, bal > burn)
+		require.NoError(t, a.st.UpdateBalancesAtIndex(0, bal-burn))
+		failure, err = a.run(ctx, nil, nil, 2)
+		require.NoError(t, err)
+		require.NotNil(t, failure)
+		require.Equal(t, 1, len(failure.Reasons))
+		assert.StringContains(t, "more than the", failure.Reasons[0])
+		assert.StringContains(t, "missed_rewards", failure.String())
+	})
+	t.Run("reserve increased", func(t *testing.T) {
+		st, _ := util.DeterministicGenesisStateCapella(t, 64)
+		require.NoError(t, st.SetCurrentEpochReserve(1000))
+		a, err := newSupplyAuditor(st)
+		require.NoError(t, err)
+		failure, err := a.run(ctx, nil, nil, 1)
+		require.NoError(t, err)
+		require.Equal(t, (*supplyAuditFailure)(nil), failure)
+
+		require.NoError(t, a.st.SetCurrentEpochReserve(a.st.CurrentEpochReserve()+1))
+		failure, err = a.run(ctx, nil, nil, 2)
+		require.NoError(t, err)
+		require.NotNil(t, failure)
+		assert.StringContains(t, "reserve increased by 1 Gwei", failure.Reasons[0])
+	})
+}
+
+func TestSupplyAuditor_LoadsBlocksOneAtATime(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	st, _ := util.DeterministicGenesisStateCapella(t, 64)
+	a, err := newSupplyAuditor(st)
+	require.NoError(t, err)
+
+	var loaded [][32]byte
+	load := func(_ context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+		loaded = append(loaded, root)
+		return nil, nil
+	}
+	refs := []blockRef{{root: [32]byte{'a'}, slot: 1}, {root: [32]byte{'b'}, slot: 2}}
+	_, err = a.run(context.Background(), refs, load, 1)
+	require.ErrorContains(t, "missing from db", err)
+	// The replay stops at the first missing block, later blocks are never loaded.
+	require.DeepEqual(t, [][32]byte{{'a'}}, loaded)
+}
+
+func TestSupplyAuditor_Blocks(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, genesis.SetCurrentEpochReserve(1000*params.BeaconConfig().EffectiveBalanceIncrement))
+	require.NoError(t, genesis.SetPreviousEpochReserve(genesis.CurrentEpochReserve()))
+	require.NoError(t, genesis.SetRewardAdjustmentFactor(params.BeaconConfig().RewardFeedbackPrecision/100))
+	committee, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(committee))
+	require.NoError(t, genesis.SetNextSyncCommittee(committee))
+
+	conf := util.DefaultBlockGenConfig()
+	st := genesis.Copy()
+	blocks := make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock)
+	var refs []blockRef
+	for slot := primitives.Slot(1); slot < 2*params.BeaconConfig().SlotsPerEpoch; slot++ {
+		// Missed slots leave their attesters to be penalized.
+		if slot%8 == 0 {
+			continue
+		}
+		conf.FullSyncAggregate = slot%2 == 0
+		blk, err := util.GenerateFullBlockAltair(st, keys, conf, slot)
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blocks[root] = wsb
+		refs = append(refs, blockRef{root: root, slot: slot})
+	}
+	load := func(_ context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+		return blocks[root], nil
+	}
+
+	a, err := newSupplyAuditor(genesis)
+	require.NoError(t, err)
+	failure, err := a.run(ctx, refs, load, 2)
+	require.NoError(t, err)
+	require.Equal(t, (*supplyAuditFailure)(nil), failure)
+	require.Equal(t, true, a.ledger.Penalties > 0)
+	missed, err := a.ledger.missedRewards()
+	require.NoError(t, err)
+	require.Equal(t, true, missed >= 0)
+}
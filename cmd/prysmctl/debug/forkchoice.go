@@ -0,0 +1,106 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/forkchoice"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+const forkChoiceGraphPath = "/chronos/debug/fork_choice/graph"
+
+var forkChoiceFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Format         string
+	Output         string
+}{}
+
+var forkChoiceCmd = &cli.Command{
+	Name:  "forkchoice",
+	Usage: "Export the fork choice tree of a beacon node as a graphviz dot graph or as JSON. The beacon node must run with --enable-debug-rpc-endpoints.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionForkChoice(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export fork choice graph")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &forkChoiceFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &forkChoiceFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, one of: dot, json",
+			Destination: &forkChoiceFlags.Format,
+			Value:       "dot",
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "file to write the fork choice graph to, printed to stdout when empty",
+			Destination: &forkChoiceFlags.Output,
+		},
+	},
+}
+
+func cliActionForkChoice(_ *cli.Context) error {
+	ctx := context.Background()
+	f := forkChoiceFlags
+	if f.Format != "dot" && f.Format != "json" {
+		return fmt.Errorf("unknown format %s, expected one of: dot, json", f.Format)
+	}
+
+	c, err := client.NewClient(f.BeaconNodeHost, client.WithTimeout(f.Timeout))
+	if err != nil {
+		return err
+	}
+	body, err := c.Get(ctx, forkChoiceGraphPath)
+	if err != nil {
+		return errors.Wrap(err, "could not get fork choice graph")
+	}
+	resp := &forkchoice.GetForkChoiceGraphResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return errors.Wrap(err, "could not decode fork choice graph")
+	}
+	if resp.Data == nil {
+		return errors.New("empty fork choice graph in response")
+	}
+
+	var out []byte
+	if f.Format == "dot" {
+		out = []byte(forkchoice.DotGraph(resp.Data).String())
+	} else {
+		out, err = json.MarshalIndent(resp.Data, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "could not encode fork choice graph")
+		}
+	}
+	if f.Output == "" {
+		fmt.Println(string(out))
+		return nil
+	}
+	if err := file.WriteFile(f.Output, out); err != nil {
+		return errors.Wrapf(err, "could not write fork choice graph to %s", f.Output)
+	}
+	log.WithFields(log.Fields{
+		"nodes": len(resp.Data.Nodes),
+		"file":  f.Output,
+	}).Info("Saved fork choice graph")
+	return nil
+}
@@ -5,9 +5,11 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/checkpointsync"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/db"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/debug"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/deprecated"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/p2p"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/testnet"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/tokenomics"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/validator"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/weaksubjectivity"
 	log "github.com/sirupsen/logrus"
@@ -33,8 +35,10 @@ func init() {
 
 	prysmctlCommands = append(prysmctlCommands, checkpointsync.Commands...)
 	prysmctlCommands = append(prysmctlCommands, db.Commands...)
+	prysmctlCommands = append(prysmctlCommands, debug.Commands...)
 	prysmctlCommands = append(prysmctlCommands, p2p.Commands...)
 	prysmctlCommands = append(prysmctlCommands, testnet.Commands...)
+	prysmctlCommands = append(prysmctlCommands, tokenomics.Commands...)
 	prysmctlCommands = append(prysmctlCommands, weaksubjectivity.Commands...)
 	prysmctlCommands = append(prysmctlCommands, validator.Commands...)
 }
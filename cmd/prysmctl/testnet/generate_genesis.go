@@ -30,6 +30,7 @@ import (
 var (
 	generateGenesisStateFlags = struct {
 		DepositJsonFile    string
+		AllocationsFile    string
 		ChainConfigFile    string
 		ConfigName         string
 		NumValidators      uint64
@@ -42,6 +43,8 @@ var (
 		ExecutionEndpoint  string
 		GethGenesisJsonIn  string
 		GethGenesisJsonOut string
+		InitialReserve     uint64
+		RewardAdjustment   uint64
 	}{}
 	log           = logrus.WithField("prefix", "genesis")
 	outputSSZFlag = &cli.StringFlag{
@@ -82,6 +85,11 @@ var (
 				Destination: &generateGenesisStateFlags.DepositJsonFile,
 				Usage:       "Path to deposit_data.json file generated by the staking-deposit-cli tool for optionally specifying validators in genesis state",
 			},
+			&cli.StringFlag{
+				Name:        "allocations-yaml-file",
+				Destination: &generateGenesisStateFlags.AllocationsFile,
+				Usage:       "Path to a YAML list of genesis validator allocations, each with a count, a deposit amount in Gwei and withdrawal credentials or address. Validator keys are deterministically generated",
+			},
 			&cli.StringFlag{
 				Name:        "config-name",
 				Usage:       "Config kind to be used for generating the genesis state. Default: mainnet. Options include mainnet, interop, minimal, dolphin. --chain-config-file will override this flag.",
@@ -92,13 +100,22 @@ var (
 				Name:        "num-validators",
 				Usage:       "Number of validators to deterministically generate in the genesis state",
 				Destination: &generateGenesisStateFlags.NumValidators,
-				Required:    true,
 			},
 			&cli.Uint64Flag{
 				Name:        "genesis-time",
 				Destination: &generateGenesisStateFlags.GenesisTime,
 				Usage:       "Unix timestamp seconds used as the genesis time in the genesis state. If unset, defaults to now()",
 			},
+			&cli.Uint64Flag{
+				Name:        "initial-reserve",
+				Destination: &generateGenesisStateFlags.InitialReserve,
+				Usage:       "Previous and current epoch reserve of the genesis state, in Gwei",
+			},
+			&cli.Uint64Flag{
+				Name:        "initial-reward-adjustment-factor",
+				Destination: &generateGenesisStateFlags.RewardAdjustment,
+				Usage:       "Reward adjustment factor of the genesis state",
+			},
 			&cli.BoolFlag{
 				Name:        "override-eth1data",
 				Destination: &generateGenesisStateFlags.OverrideEth1Data,
@@ -154,6 +171,20 @@ type depositDataJSON struct {
 	Signature             string `json:"signature"`
 }
 
+// Represents a genesis validator allocation of the YAML file given by --allocations-yaml-file, e.g.
+//
+//   - count: 64
+//     amount: 32000000000
+//     withdrawal_address: "0x8a9f1c2b6a0e3f8b5d4c7e2a1f0b9c8d7e6f5a4b"
+//
+// Amount defaults to the max effective balance, and withdrawal credentials to BLS credentials.
+type allocationYAML struct {
+	Count                 uint64 `json:"count"`
+	Amount                uint64 `json:"amount"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	WithdrawalAddress     string `json:"withdrawal_address"`
+}
+
 func cliActionGenerateGenesisState(cliCtx *cli.Context) error {
 	outputJson := generateGenesisStateFlags.OutputJSON
 	outputYaml := generateGenesisStateFlags.OutputYaml
@@ -228,9 +259,34 @@ func generateGenesis(ctx context.Context) (state.BeaconState, error) {
 	if err != nil {
 		return nil, err
 	}
-	opts := make([]interop.PremineGenesisOpt, 0)
+	opts := []interop.PremineGenesisOpt{
+		interop.WithInitialReserve(f.InitialReserve),
+		interop.WithRewardAdjustmentFactor(f.RewardAdjustment),
+	}
 	nv := f.NumValidators
-	if f.DepositJsonFile != "" {
+	if f.DepositJsonFile != "" && f.AllocationsFile != "" {
+		return nil, errors.New("--deposit-json-file and --allocations-yaml-file can not be used together")
+	}
+	if f.AllocationsFile != "" {
+		expanded, err := file.ExpandPath(f.AllocationsFile)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("reading allocations from YAML at %s", expanded)
+		b, err := os.ReadFile(expanded) // #nosec G304
+		if err != nil {
+			return nil, err
+		}
+		allocs, err := allocationsFromYAML(b)
+		if err != nil {
+			return nil, err
+		}
+		dds, roots, err := interop.DepositDataFromAllocations(allocs)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, interop.WithDepositData(dds, roots))
+	} else if f.DepositJsonFile != "" {
 		expanded, err := file.ExpandPath(f.DepositJsonFile)
 		if err != nil {
 			return nil, err
@@ -247,7 +303,7 @@ func generateGenesis(ctx context.Context) (state.BeaconState, error) {
 		opts = append(opts, interop.WithDepositData(dds, roots))
 	} else if nv == 0 {
 		return nil, fmt.Errorf(
-			"expected --num-validators > 0 or --deposit-json-file to have been provided",
+			"expected --num-validators > 0, --deposit-json-file or --allocations-yaml-file to have been provided",
 		)
 	}
 
@@ -348,6 +404,40 @@ func depositEntriesFromJSON(enc []byte) ([][]byte, []*ethpb.Deposit_Data, error)
 	return roots, dds, nil
 }
 
+func allocationsFromYAML(enc []byte) ([]*interop.GenesisAllocation, error) {
+	var allocsYAML []*allocationYAML
+	if err := yaml.Unmarshal(enc, &allocsYAML); err != nil {
+		return nil, err
+	}
+	allocs := make([]*interop.GenesisAllocation, len(allocsYAML))
+	for i, a := range allocsYAML {
+		alloc := &interop.GenesisAllocation{Count: a.Count, Amount: a.Amount}
+		switch {
+		case a.WithdrawalCredentials != "" && a.WithdrawalAddress != "":
+			return nil, fmt.Errorf("allocation %d has both withdrawal credentials and address", i)
+		case a.WithdrawalCredentials != "":
+			creds, err := hex.DecodeString(strings.TrimPrefix(a.WithdrawalCredentials, "0x"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid withdrawal credentials of allocation %d", i)
+			}
+			alloc.WithdrawalCredentials = creds
+		case a.WithdrawalAddress != "":
+			addr, err := hex.DecodeString(strings.TrimPrefix(a.WithdrawalAddress, "0x"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid withdrawal address of allocation %d", i)
+			}
+			if len(addr) != 20 {
+				return nil, fmt.Errorf("withdrawal address of allocation %d has %d bytes, expected 20", i, len(addr))
+			}
+			alloc.WithdrawalCredentials = make([]byte, 12, 32)
+			alloc.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+			alloc.WithdrawalCredentials = append(alloc.WithdrawalCredentials, addr...)
+		}
+		allocs[i] = alloc
+	}
+	return allocs, nil
+}
+
 func depositJSONToDepositData(input *depositDataJSON) ([]byte, *ethpb.Deposit_Data, error) {
 	root, err := hex.DecodeString(strings.TrimPrefix(input.DepositDataRoot, "0x"))
 	if err != nil {
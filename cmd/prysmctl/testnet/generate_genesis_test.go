@@ -1,12 +1,18 @@
 package testnet
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
@@ -48,3 +54,70 @@ func createGenesisDepositData(t *testing.T, numKeys int) []*depositDataJSON {
 	}
 	return jsonData
 }
+
+func Test_allocationsFromYAML(t *testing.T) {
+	enc := []byte(`
+- count: 2
+- count: 3
+  amount: 64000000000
+  withdrawal_address: "0x00000000000000000000000000000000000000aa"
+- count: 1
+  withdrawal_credentials: "0x00000000000000000000000000000000000000000000000000000000000000bb"
+`)
+	allocs, err := allocationsFromYAML(enc)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(allocs))
+	assert.DeepEqual(t, &interop.GenesisAllocation{Count: 2}, allocs[0])
+	assert.Equal(t, uint64(3), allocs[1].Count)
+	assert.Equal(t, uint64(64000000000), allocs[1].Amount)
+	wantCreds := make([]byte, 32)
+	wantCreds[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	wantCreds[31] = 0xaa
+	assert.DeepEqual(t, wantCreds, allocs[1].WithdrawalCredentials)
+	wantCreds = make([]byte, 32)
+	wantCreds[31] = 0xbb
+	assert.DeepEqual(t, wantCreds, allocs[2].WithdrawalCredentials)
+
+	_, err = allocationsFromYAML([]byte(`- {count: 1, withdrawal_address: "0xaa", withdrawal_credentials: "0xbb"}`))
+	require.ErrorContains(t, "allocation 0 has both withdrawal credentials and address", err)
+	_, err = allocationsFromYAML([]byte(`- {count: 1, withdrawal_address: "0xaa"}`))
+	require.ErrorContains(t, "withdrawal address of allocation 0 has 1 bytes, expected 20", err)
+}
+
+func Test_generateGenesis_Allocations(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	dir := t.TempDir()
+	allocations := filepath.Join(dir, "allocations.yaml")
+	require.NoError(t, os.WriteFile(allocations, []byte("- count: 4\n- count: 2\n  amount: 64000000000\n"), 0600))
+	gethGenesis := filepath.Join(dir, "genesis.json")
+
+	saved := generateGenesisStateFlags
+	defer func() {
+		generateGenesisStateFlags = saved
+	}()
+	generateGenesisStateFlags.AllocationsFile = allocations
+	generateGenesisStateFlags.GenesisTime = 1000
+	generateGenesisStateFlags.ForkName = version.String(version.Capella)
+	generateGenesisStateFlags.GethGenesisJsonOut = gethGenesis
+	generateGenesisStateFlags.InitialReserve = 5000
+	generateGenesisStateFlags.RewardAdjustment = 3
+
+	st, err := generateGenesis(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 6, st.NumValidators())
+	assert.Equal(t, uint64(5000), st.CurrentEpochReserve())
+	assert.Equal(t, uint64(5000), st.PreviousEpochReserve())
+	assert.Equal(t, uint64(3), st.RewardAdjustmentFactor())
+	balance, err := st.BalanceAtIndex(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(64000000000), balance)
+
+	enc, err := os.ReadFile(gethGenesis) // #nosec G304
+	require.NoError(t, err)
+	gen := &core.Genesis{}
+	require.NoError(t, json.Unmarshal(enc, gen))
+	assert.Equal(t, uint64(1000), gen.Timestamp)
+	header, err := st.LatestExecutionPayloadHeader()
+	require.NoError(t, err)
+	assert.DeepEqual(t, gen.ToBlock().Hash().Bytes(), header.BlockHash())
+}
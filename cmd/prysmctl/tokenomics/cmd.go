@@ -0,0 +1,13 @@
+package tokenomics
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "tokenomics",
+		Usage: "commands to inspect the tokenomics of a chain config offline",
+		Subcommands: []*cli.Command{
+			scheduleCmd,
+		},
+	},
+}
@@ -0,0 +1,312 @@
+package tokenomics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/cmd/flags"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	depositPlanMainnet = "mainnet"
+	depositPlanDolphin = "dolphin"
+
+	granularityEpoch = "epoch"
+	granularityYear  = "year"
+
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+var (
+	scheduleFlags = struct {
+		ChainConfigFile string
+		ConfigName      string
+		DepositPlan     string
+		Granularity     string
+		FromEpoch       uint64
+		ToEpoch         uint64
+		Step            uint64
+		Format          string
+		Output          string
+	}{}
+	scheduleCmd = &cli.Command{
+		Name:  "schedule",
+		Usage: "Write the issuance, deposit plan and boost schedules of a chain config as CSV or JSON",
+		Description: `The schedule is computed by the same functions the beacon node runs, so that proposals to change
+the issuance rate or the deposit plan can be reviewed with the exact numbers they would produce.
+
+Each row covers a period of epochs (a single epoch, --step epochs or a year) and reports the values
+at the first epoch of the period, except for cumulative_issuance and max_token_supply_remaining which
+account for the issuance up to the end of the period.`,
+		Action: func(cliCtx *cli.Context) error {
+			if err := cliActionSchedule(cliCtx); err != nil {
+				log.WithError(err).Fatal("Could not write tokenomics schedule")
+			}
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "chain-config-file",
+				Destination: &scheduleFlags.ChainConfigFile,
+				Usage:       "The path to a YAML file with chain config values",
+			},
+			&cli.StringFlag{
+				Name:        "config-name",
+				Usage:       "Config to compute the schedule of. Options include mainnet, dolphin. --chain-config-file will override this flag.",
+				Destination: &scheduleFlags.ConfigName,
+				Value:       params.MainnetName,
+			},
+			&cli.StringFlag{
+				Name:        "deposit-plan",
+				Usage:       "Deposit plan preset to replace the DEPOSIT_PLAN of the config with, one of mainnet or dolphin. Defaults to the deposit plan of the loaded config.",
+				Destination: &scheduleFlags.DepositPlan,
+			},
+			flags.EnumValue{
+				Name:        "granularity",
+				Usage:       "Period covered by each row, one of epoch or year",
+				Enum:        []string{granularityYear, granularityEpoch},
+				Value:       granularityYear,
+				Destination: &scheduleFlags.Granularity,
+			}.GenericFlag(),
+			&cli.Uint64Flag{
+				Name:        "from-epoch",
+				Usage:       "First epoch of the schedule",
+				Destination: &scheduleFlags.FromEpoch,
+			},
+			&cli.Uint64Flag{
+				Name:        "to-epoch",
+				Usage:       "Last epoch of the schedule. Defaults to the last epoch of the issuance schedule",
+				Destination: &scheduleFlags.ToEpoch,
+			},
+			&cli.Uint64Flag{
+				Name:        "step",
+				Usage:       "Number of epochs covered by each row with the epoch granularity",
+				Destination: &scheduleFlags.Step,
+				Value:       1,
+			},
+			flags.EnumValue{
+				Name:        "format",
+				Usage:       "Output format, one of csv or json",
+				Enum:        []string{formatCSV, formatJSON},
+				Value:       formatCSV,
+				Destination: &scheduleFlags.Format,
+			}.GenericFlag(),
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Output file name. Defaults to stdout",
+				Destination: &scheduleFlags.Output,
+			},
+		},
+	}
+)
+
+// scheduleRow is the tokenomics schedule of a period of epochs. Values are strings as they
+// do not fit in the integers of most JSON decoders.
+type scheduleRow struct {
+	FromEpoch               string `json:"from_epoch"`
+	ToEpoch                 string `json:"to_epoch"`
+	Year                    string `json:"year"`
+	EpochIssuance           string `json:"epoch_issuance"`
+	TargetDepositPlan       string `json:"target_deposit_plan"`
+	MaxBoostYield           string `json:"max_boost_yield"`
+	MaxEpochFeedbackBoost   string `json:"max_epoch_feedback_boost"`
+	ChurnLimitBias          string `json:"churn_limit_bias"`
+	ChurnBiasThreshold      string `json:"churn_bias_threshold"`
+	CumulativeIssuance      string `json:"cumulative_issuance"`
+	MaxTokenSupplyRemaining string `json:"max_token_supply_remaining"`
+}
+
+var scheduleHeader = []string{
+	"from_epoch",
+	"to_epoch",
+	"year",
+	"epoch_issuance",
+	"target_deposit_plan",
+	"max_boost_yield",
+	"max_epoch_feedback_boost",
+	"churn_limit_bias",
+	"churn_bias_threshold",
+	"cumulative_issuance",
+	"max_token_supply_remaining",
+}
+
+func (r *scheduleRow) record() []string {
+	return []string{
+		r.FromEpoch,
+		r.ToEpoch,
+		r.Year,
+		r.EpochIssuance,
+		r.TargetDepositPlan,
+		r.MaxBoostYield,
+		r.MaxEpochFeedbackBoost,
+		r.ChurnLimitBias,
+		r.ChurnBiasThreshold,
+		r.CumulativeIssuance,
+		r.MaxTokenSupplyRemaining,
+	}
+}
+
+func cliActionSchedule(_ *cli.Context) error {
+	f := &scheduleFlags
+	if err := setScheduleParams(f.ChainConfigFile, f.ConfigName, f.DepositPlan); err != nil {
+		return err
+	}
+	rows, err := schedule(f.Granularity, primitives.Epoch(f.FromEpoch), primitives.Epoch(f.ToEpoch), f.Step)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if f.Output != "" {
+		out, err := os.Create(f.Output)
+		if err != nil {
+			return errors.Wrap(err, "could not create output file")
+		}
+		defer func() {
+			if err := out.Close(); err != nil {
+				log.WithError(err).Error("Could not close output file")
+			}
+		}()
+		w = out
+	}
+	return writeSchedule(w, f.Format, rows)
+}
+
+// setScheduleParams activates the chain config to compute the schedule of, optionally replacing its
+// deposit plan with one of the presets.
+func setScheduleParams(chainConfigFile, configName, depositPlan string) error {
+	var cfg *params.BeaconChainConfig
+	if chainConfigFile != "" {
+		log.Infof("Specified a chain config file: %s", chainConfigFile)
+		c, err := params.UnmarshalConfigFile(chainConfigFile, nil)
+		if err != nil {
+			return err
+		}
+		cfg = c
+	} else {
+		c, err := params.ByName(configName)
+		if err != nil {
+			return fmt.Errorf("unable to find config using name %s: %v", configName, err)
+		}
+		cfg = c.Copy()
+	}
+
+	switch depositPlan {
+	case "":
+	case depositPlanMainnet:
+		cfg.DepositPlan = params.MainnetDepositPlan()
+	case depositPlanDolphin:
+		cfg.DepositPlan = params.DolphinDepositPlan()
+	default:
+		return fmt.Errorf("unknown deposit plan %s, allowed values are %s, %s", depositPlan, depositPlanMainnet, depositPlanDolphin)
+	}
+	// The config is not registered by fork versions, so that configs sharing the fork schedule
+	// of a known network (e.g. a proposed change to mainnet) can be loaded.
+	params.OverrideBeaconConfig(cfg)
+	return nil
+}
+
+// schedule computes the schedule rows of the epochs between from and to (inclusive) with the active config.
+// A zero `to` stands for the last epoch of the issuance schedule.
+func schedule(granularity string, from, to primitives.Epoch, step uint64) ([]*scheduleRow, error) {
+	cfg := params.BeaconConfig()
+	if to == 0 {
+		to = primitives.Epoch(cfg.EpochsPerYear*uint64(len(cfg.IssuanceRate)) - 1)
+	}
+	if from > to {
+		return nil, fmt.Errorf("from epoch %d is greater than to epoch %d", from, to)
+	}
+	switch granularity {
+	case granularityEpoch:
+		if step == 0 {
+			return nil, errors.New("step must be greater than 0")
+		}
+	case granularityYear:
+		step = cfg.EpochsPerYear
+	default:
+		return nil, fmt.Errorf("unknown granularity %s", granularity)
+	}
+
+	// The issuance of the epochs before the schedule counts towards the cumulative issuance.
+	var cumulative uint64
+	for epoch := primitives.Epoch(0); epoch < from; epoch++ {
+		cumulative += helpers.EpochIssuance(epoch)
+	}
+
+	rows := make([]*scheduleRow, 0)
+	for start := from; start <= to; {
+		end := start + primitives.Epoch(step) - 1
+		if granularity == granularityYear {
+			// Yearly rows are aligned on years, the first one may be partial.
+			end = primitives.Epoch((uint64(start)/cfg.EpochsPerYear+1)*cfg.EpochsPerYear - 1)
+		}
+		if end > to || end < start {
+			end = to
+		}
+		for epoch := start; epoch <= end; epoch++ {
+			cumulative += helpers.EpochIssuance(epoch)
+			if epoch == end {
+				break
+			}
+		}
+		var remaining uint64
+		if cumulative < cfg.MaxTokenSupply {
+			remaining = cfg.MaxTokenSupply - cumulative
+		}
+		maxBoostYield := helpers.MaxBoostYield(start)
+		targetDepositPlan := helpers.TargetDepositPlan(start)
+		rows = append(rows, &scheduleRow{
+			FromEpoch:               strconv.FormatUint(uint64(start), 10),
+			ToEpoch:                 strconv.FormatUint(uint64(end), 10),
+			Year:                    strconv.Itoa(helpers.EpochToYear(start)),
+			EpochIssuance:           strconv.FormatUint(helpers.EpochIssuance(start), 10),
+			TargetDepositPlan:       strconv.FormatUint(targetDepositPlan, 10),
+			MaxBoostYield:           strconv.FormatUint(maxBoostYield, 10),
+			MaxEpochFeedbackBoost:   strconv.FormatUint(helpers.FeedbackBoost(maxBoostYield, math.MaxUint64), 10),
+			ChurnLimitBias:          strconv.FormatUint(cfg.ChurnLimitBias, 10),
+			ChurnBiasThreshold:      strconv.FormatUint(targetDepositPlan, 10),
+			CumulativeIssuance:      strconv.FormatUint(cumulative, 10),
+			MaxTokenSupplyRemaining: strconv.FormatUint(remaining, 10),
+		})
+		if end == to {
+			break
+		}
+		start = end + 1
+	}
+	return rows, nil
+}
+
+func writeSchedule(w io.Writer, format string, rows []*scheduleRow) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(scheduleHeader); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := cw.Write(row.record()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unknown format %s", format)
+	}
+}
@@ -0,0 +1,104 @@
+package tokenomics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestSchedule_Years(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	require.NoError(t, setScheduleParams("", params.MainnetName, ""))
+	cfg := params.BeaconConfig()
+
+	rows, err := schedule(granularityYear, 0, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(cfg.IssuanceRate), len(rows))
+
+	var cumulative uint64
+	for year, row := range rows {
+		start := primitives.Epoch(uint64(year) * cfg.EpochsPerYear)
+		cumulative += helpers.EpochIssuance(start) * cfg.EpochsPerYear
+		assert.Equal(t, strconv.FormatUint(uint64(start), 10), row.FromEpoch)
+		assert.Equal(t, strconv.FormatUint(uint64(start)+cfg.EpochsPerYear-1, 10), row.ToEpoch)
+		assert.Equal(t, strconv.Itoa(year), row.Year)
+		assert.Equal(t, strconv.FormatUint(helpers.EpochIssuance(start), 10), row.EpochIssuance)
+		assert.Equal(t, strconv.FormatUint(helpers.TargetDepositPlan(start), 10), row.TargetDepositPlan)
+		assert.Equal(t, strconv.FormatUint(helpers.MaxBoostYield(start), 10), row.MaxBoostYield)
+		assert.Equal(t, strconv.FormatUint(cumulative, 10), row.CumulativeIssuance)
+		assert.Equal(t, strconv.FormatUint(cfg.MaxTokenSupply-cumulative, 10), row.MaxTokenSupplyRemaining)
+	}
+}
+
+func TestSchedule_Epochs(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	require.NoError(t, setScheduleParams("", params.MainnetName, ""))
+
+	rows, err := schedule(granularityEpoch, 10, 20, 5)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(rows))
+	assert.Equal(t, "10", rows[0].FromEpoch)
+	assert.Equal(t, "14", rows[0].ToEpoch)
+	assert.Equal(t, "20", rows[2].FromEpoch)
+	assert.Equal(t, "20", rows[2].ToEpoch)
+	// The issuance of the epochs before the schedule counts towards the cumulative issuance.
+	assert.Equal(t, strconv.FormatUint(helpers.EpochIssuance(0)*21, 10), rows[2].CumulativeIssuance)
+
+	_, err = schedule(granularityEpoch, 20, 10, 1)
+	assert.ErrorContains(t, "greater than to epoch", err)
+	_, err = schedule(granularityEpoch, 0, 10, 0)
+	assert.ErrorContains(t, "step must be greater than 0", err)
+}
+
+func TestSetScheduleParams_DepositPlan(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("CONFIG_NAME: tokenomics-test\nEPOCHS_PER_YEAR: 1000\n"), 0600))
+
+	// The deposit plan follows the overridden epochs per year.
+	require.NoError(t, setScheduleParams(configFile, "", ""))
+	assert.Equal(t, uint64(1000), params.BeaconConfig().EpochsPerYear)
+	assert.Equal(t, uint64(20000000*1e9), helpers.TargetDepositPlan(0))
+	assert.Equal(t, uint64(20000000*1e9+180000000*1e9/4000), helpers.TargetDepositPlan(1))
+
+	require.NoError(t, setScheduleParams(configFile, "", depositPlanDolphin))
+	assert.Equal(t, uint64(40000000*1e9), helpers.TargetDepositPlan(0))
+	assert.Equal(t, uint64(40000000*1e9+160000000*1e9/4000), helpers.TargetDepositPlan(1))
+
+	// The dolphin config defaults to the dolphin deposit plan.
+	require.NoError(t, setScheduleParams("", params.DolphinName, ""))
+	assert.Equal(t, uint64(40000000*1e9), helpers.TargetDepositPlan(0))
+
+	assert.ErrorContains(t, "unknown deposit plan", setScheduleParams(configFile, "", "foo"))
+}
+
+func TestWriteSchedule(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	require.NoError(t, setScheduleParams("", params.MainnetName, ""))
+	rows, err := schedule(granularityYear, 0, 0, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeSchedule(&buf, formatCSV, rows))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, len(rows)+1, len(records))
+	assert.DeepEqual(t, scheduleHeader, records[0])
+	assert.DeepEqual(t, rows[3].record(), records[4])
+
+	buf.Reset()
+	require.NoError(t, writeSchedule(&buf, formatJSON, rows))
+	var decoded []*scheduleRow
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.DeepEqual(t, rows, decoded)
+}
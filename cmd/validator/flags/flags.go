@@ -85,6 +85,14 @@ var (
 		Name:  "disable-rewards-penalties-logging",
 		Usage: "Disable reward/penalty logging during cluster deployment",
 	}
+	// BailOutScoreWarnThresholdsFlag defines the percentages of the bailout score threshold at which
+	// warnings about the bailout score of a validator are logged.
+	BailOutScoreWarnThresholdsFlag = &cli.IntSliceFlag{
+		Name: "bailout-score-warn-thresholds",
+		Usage: "Percentages of the bailout score threshold at which warnings of increasing severity are logged " +
+			"for a validator. The highest percentage is logged as an error, e.g. --bailout-score-warn-thresholds=50,75,90",
+		Value: cli.NewIntSlice(50, 75, 90),
+	}
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
 		Name:  "graffiti",
@@ -53,6 +53,7 @@ var appFlags = []cli.Flag{
 	flags.CertFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
+	flags.BailOutScoreWarnThresholdsFlag,
 	flags.InteropStartIndex,
 	flags.InteropNumValidators,
 	flags.EnableRPCFlag,
@@ -95,6 +95,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.CertFlag,
 			flags.EnableOverNodeFlag,
 			flags.DisablePenaltyRewardLogFlag,
+			flags.BailOutScoreWarnThresholdsFlag,
 			flags.GraffitiFlag,
 			flags.EnableRPCFlag,
 			flags.RPCHost,
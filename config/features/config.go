@@ -75,6 +75,9 @@ type Flags struct {
 	BuildBlockParallel bool // BuildBlockParallel builds beacon block for proposer in parallel.
 	AggregateParallel  bool // AggregateParallel aggregates attestations in parallel.
 
+	EnableTokenomicsHistory  bool // EnableTokenomicsHistory records the tokenomics of every epoch in the database.
+	EnableWithdrawalsHistory bool // EnableWithdrawalsHistory indexes every executed withdrawal in the database.
+
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
 	KeystoreImportDebounceInterval time.Duration
@@ -233,6 +236,14 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(aggregateParallel)
 		cfg.AggregateParallel = true
 	}
+	if ctx.IsSet(enableTokenomicsHistory.Name) {
+		logEnabled(enableTokenomicsHistory)
+		cfg.EnableTokenomicsHistory = true
+	}
+	if ctx.IsSet(enableWithdrawalsHistory.Name) {
+		logEnabled(enableWithdrawalsHistory)
+		cfg.EnableWithdrawalsHistory = true
+	}
 	if ctx.IsSet(disableResourceManager.Name) {
 		logEnabled(disableResourceManager)
 		cfg.DisableResourceManager = true
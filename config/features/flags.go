@@ -157,6 +157,14 @@ var (
 		Name:  "aggregate-parallel",
 		Usage: "Enables parallel aggregation of attestations",
 	}
+	enableTokenomicsHistory = &cli.BoolFlag{
+		Name:  "enable-tokenomics-history",
+		Usage: "Records the tokenomics of every epoch in the database and backfills the finalized epochs recorded before enabling",
+	}
+	enableWithdrawalsHistory = &cli.BoolFlag{
+		Name:  "enable-withdrawals-history",
+		Usage: "Indexes every withdrawal executed by canonical blocks in the database and backfills the withdrawals of finalized blocks imported before enabling",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -210,6 +218,8 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	disableResourceManager,
 	DisableRegistrationCache,
 	aggregateParallel,
+	enableTokenomicsHistory,
+	enableWithdrawalsHistory,
 }...)...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -22,34 +22,35 @@ type BeaconChainConfig struct {
 	JustificationBitsLength  uint64           `yaml:"JUSTIFICATION_BITS_LENGTH"`   // JustificationBitsLength defines number of epochs to track when implementing k-finality in Casper FFG.
 
 	// Misc constants.
-	PresetBase                        string     `yaml:"PRESET_BASE" spec:"true"`                          // PresetBase represents the underlying spec preset this config is based on.
-	ConfigName                        string     `yaml:"CONFIG_NAME" spec:"true"`                          // ConfigName for allowing an easy human-readable way of knowing what chain is being used.
-	TargetCommitteeSize               uint64     `yaml:"TARGET_COMMITTEE_SIZE" spec:"true"`                // TargetCommitteeSize is the number of validators in a committee when the chain is healthy.
-	MaxValidatorsPerCommittee         uint64     `yaml:"MAX_VALIDATORS_PER_COMMITTEE" spec:"true"`         // MaxValidatorsPerCommittee defines the upper bound of the size of a committee.
-	MaxCommitteesPerSlot              uint64     `yaml:"MAX_COMMITTEES_PER_SLOT" spec:"true"`              // MaxCommitteesPerSlot defines the max amount of committee in a single slot.
-	MinPerEpochChurnLimit             uint64     `yaml:"MIN_PER_EPOCH_CHURN_LIMIT" spec:"true"`            // MinPerEpochChurnLimit is the minimum amount of churn allotted for validator rotations.
-	ChurnLimitQuotient                uint64     `yaml:"CHURN_LIMIT_QUOTIENT" spec:"true"`                 // ChurnLimitQuotient is used to determine the limit of how many validators can rotate per epoch.
-	ChurnLimitBias                    uint64     `yaml:"CHURN_LIMIT_BIAS" spec:"true"`                     // ChurnLimitBias is a parameter for dynamic churn limit calculation.
-	ShuffleRoundCount                 uint64     `yaml:"SHUFFLE_ROUND_COUNT" spec:"true"`                  // ShuffleRoundCount is used for retrieving the permuted index.
-	MinGenesisActiveValidatorCount    uint64     `yaml:"MIN_GENESIS_ACTIVE_VALIDATOR_COUNT" spec:"true"`   // MinGenesisActiveValidatorCount defines how many validator deposits needed to kick off beacon chain.
-	MinGenesisTime                    uint64     `yaml:"MIN_GENESIS_TIME" spec:"true"`                     // MinGenesisTime is the time that needed to pass before kicking off beacon chain.
-	TargetAggregatorsPerCommittee     uint64     `yaml:"TARGET_AGGREGATORS_PER_COMMITTEE" spec:"true"`     // TargetAggregatorsPerCommittee defines the number of aggregators inside one committee.
-	HysteresisQuotient                uint64     `yaml:"HYSTERESIS_QUOTIENT" spec:"true"`                  // HysteresisQuotient defines the hysteresis quotient for effective balance calculations.
-	HysteresisDownwardMultiplier      uint64     `yaml:"HYSTERESIS_DOWNWARD_MULTIPLIER" spec:"true"`       // HysteresisDownwardMultiplier defines the hysteresis downward multiplier for effective balance calculations.
-	HysteresisUpwardMultiplier        uint64     `yaml:"HYSTERESIS_UPWARD_MULTIPLIER" spec:"true"`         // HysteresisUpwardMultiplier defines the hysteresis upward multiplier for effective balance calculations.
-	IssuanceRate                      [11]uint64 `yaml:"ISSUANCE_RATE" spec:"true"`                        // IssuanceRate defines the issuance rate for the beacon chain.
-	IssuancePrecision                 uint64     `yaml:"ISSUANCE_PRECISION" spec:"true"`                   // IssuancePrecision defines the precision of the issuance rate.
-	DepositPlanEarlyEnd               uint64     `yaml:"DEPOSIT_PLAN_EARLY_END" spec:"true"`               // DepositPlanEarlyEnd defines the first end of the ~x year deposit plan.
-	DepositPlanEarlySlope             uint64     `yaml:"DEPOSIT_PLAN_EARLY_SLOPE" spec:"true"`             // DepositPlanEarlySlope defines the slope of the ~x year deposit plan.
-	DepositPlanEarlyOffset            uint64     `yaml:"DEPOSIT_PLAN_EARLY_OFFSET" spec:"true"`            // DepositPlanEarlyOffset defines the bias of the ~x year deposit plan.
-	DepositPlanLaterEnd               uint64     `yaml:"DEPOSIT_PLAN_LATER_END" spec:"true"`               // DepositPlanLaterEnd defines the last end of the ~y year deposit plan.
-	DepositPlanLaterSlope             uint64     `yaml:"DEPOSIT_PLAN_LATER_SLOPE" spec:"true"`             // DepositPlanLaterSlope defines the slope of the x~y year deposit plan.
-	DepositPlanLaterOffset            uint64     `yaml:"DEPOSIT_PLAN_LATER_OFFSET" spec:"true"`            // DepositPlanLaterOffset defines the bias of the x~y year deposit plan.
-	DepositPlanFinal                  uint64     `yaml:"DEPOSIT_PLAN_FINAL" spec:"true"`                   // DepositPlanFinal defines the final deposit amount after 6 years.
-	RewardFeedbackPrecision           uint64     `yaml:"REWARD_FEEDBACK_PRECISION" spec:"true"`            // RewardFeedbackPrecision defines the precision of the reward feedback.
-	RewardFeedbackThresholdReciprocal uint64     `yaml:"REWARD_FEEDBACK_THRESHOLD_RECIPROCAL" spec:"true"` // RewardFeedbackThresholdReciprocal defines the reciprocal of threshold in the reward feedback.
-	TargetChangeRate                  uint64     `yaml:"TARGET_CHANGE_RATE" spec:"true"`                   // TargetChangeRate defines the target change rate for the reward feedback.
-	MaxBoostYield                     [11]uint64 `yaml:"MAX_BOOST_YIELD" spec:"true"`                      // MaxBoostYield defines the maximum value(1%) for the reward feedback.
+	PresetBase                        string               `yaml:"PRESET_BASE" spec:"true"`                          // PresetBase represents the underlying spec preset this config is based on.
+	ConfigName                        string               `yaml:"CONFIG_NAME" spec:"true"`                          // ConfigName for allowing an easy human-readable way of knowing what chain is being used.
+	TargetCommitteeSize               uint64               `yaml:"TARGET_COMMITTEE_SIZE" spec:"true"`                // TargetCommitteeSize is the number of validators in a committee when the chain is healthy.
+	MaxValidatorsPerCommittee         uint64               `yaml:"MAX_VALIDATORS_PER_COMMITTEE" spec:"true"`         // MaxValidatorsPerCommittee defines the upper bound of the size of a committee.
+	MaxCommitteesPerSlot              uint64               `yaml:"MAX_COMMITTEES_PER_SLOT" spec:"true"`              // MaxCommitteesPerSlot defines the max amount of committee in a single slot.
+	MinPerEpochChurnLimit             uint64               `yaml:"MIN_PER_EPOCH_CHURN_LIMIT" spec:"true"`            // MinPerEpochChurnLimit is the minimum amount of churn allotted for validator rotations.
+	ChurnLimitQuotient                uint64               `yaml:"CHURN_LIMIT_QUOTIENT" spec:"true"`                 // ChurnLimitQuotient is used to determine the limit of how many validators can rotate per epoch.
+	ChurnLimitBias                    uint64               `yaml:"CHURN_LIMIT_BIAS" spec:"true"`                     // ChurnLimitBias is a parameter for dynamic churn limit calculation.
+	ShuffleRoundCount                 uint64               `yaml:"SHUFFLE_ROUND_COUNT" spec:"true"`                  // ShuffleRoundCount is used for retrieving the permuted index.
+	MinGenesisActiveValidatorCount    uint64               `yaml:"MIN_GENESIS_ACTIVE_VALIDATOR_COUNT" spec:"true"`   // MinGenesisActiveValidatorCount defines how many validator deposits needed to kick off beacon chain.
+	MinGenesisTime                    uint64               `yaml:"MIN_GENESIS_TIME" spec:"true"`                     // MinGenesisTime is the time that needed to pass before kicking off beacon chain.
+	TargetAggregatorsPerCommittee     uint64               `yaml:"TARGET_AGGREGATORS_PER_COMMITTEE" spec:"true"`     // TargetAggregatorsPerCommittee defines the number of aggregators inside one committee.
+	HysteresisQuotient                uint64               `yaml:"HYSTERESIS_QUOTIENT" spec:"true"`                  // HysteresisQuotient defines the hysteresis quotient for effective balance calculations.
+	HysteresisDownwardMultiplier      uint64               `yaml:"HYSTERESIS_DOWNWARD_MULTIPLIER" spec:"true"`       // HysteresisDownwardMultiplier defines the hysteresis downward multiplier for effective balance calculations.
+	HysteresisUpwardMultiplier        uint64               `yaml:"HYSTERESIS_UPWARD_MULTIPLIER" spec:"true"`         // HysteresisUpwardMultiplier defines the hysteresis upward multiplier for effective balance calculations.
+	IssuanceRate                      [11]uint64           `yaml:"ISSUANCE_RATE" spec:"true"`                        // IssuanceRate defines the issuance rate for the beacon chain.
+	IssuancePrecision                 uint64               `yaml:"ISSUANCE_PRECISION" spec:"true"`                   // IssuancePrecision defines the precision of the issuance rate.
+	DepositPlan                       []DepositPlanSegment `yaml:"DEPOSIT_PLAN" spec:"true"`                         // DepositPlan defines the target deposit as consecutive linear segments.
+	DepositPlanEarlyEnd               uint64               `yaml:"DEPOSIT_PLAN_EARLY_END" spec:"true"`               // Deprecated: DepositPlanEarlyEnd is the end year of the first DepositPlan segment.
+	DepositPlanEarlySlope             uint64               `yaml:"DEPOSIT_PLAN_EARLY_SLOPE" spec:"true"`             // Deprecated: DepositPlanEarlySlope is the per epoch slope of the first DepositPlan segment.
+	DepositPlanEarlyOffset            uint64               `yaml:"DEPOSIT_PLAN_EARLY_OFFSET" spec:"true"`            // Deprecated: DepositPlanEarlyOffset is the offset of the first DepositPlan segment.
+	DepositPlanLaterEnd               uint64               `yaml:"DEPOSIT_PLAN_LATER_END" spec:"true"`               // Deprecated: DepositPlanLaterEnd is the end year of the second DepositPlan segment.
+	DepositPlanLaterSlope             uint64               `yaml:"DEPOSIT_PLAN_LATER_SLOPE" spec:"true"`             // Deprecated: DepositPlanLaterSlope is the per epoch slope of the second DepositPlan segment.
+	DepositPlanLaterOffset            uint64               `yaml:"DEPOSIT_PLAN_LATER_OFFSET" spec:"true"`            // Deprecated: DepositPlanLaterOffset is the offset of the second DepositPlan segment.
+	DepositPlanFinal                  uint64               `yaml:"DEPOSIT_PLAN_FINAL" spec:"true"`                   // DepositPlanFinal defines the final deposit amount after the last deposit plan segment.
+	RewardFeedbackPrecision           uint64               `yaml:"REWARD_FEEDBACK_PRECISION" spec:"true"`            // RewardFeedbackPrecision defines the precision of the reward feedback.
+	RewardFeedbackThresholdReciprocal uint64               `yaml:"REWARD_FEEDBACK_THRESHOLD_RECIPROCAL" spec:"true"` // RewardFeedbackThresholdReciprocal defines the reciprocal of threshold in the reward feedback.
+	TargetChangeRate                  uint64               `yaml:"TARGET_CHANGE_RATE" spec:"true"`                   // TargetChangeRate defines the target change rate for the reward feedback.
+	MaxBoostYield                     [11]uint64           `yaml:"MAX_BOOST_YIELD" spec:"true"`                      // MaxBoostYield defines the maximum value(1%) for the reward feedback.
 
 	// Gwei value constants.
 	MinDepositAmount          uint64 `yaml:"MIN_DEPOSIT_AMOUNT" spec:"true"`          // MinDepositAmount is the minimum amount of Gwei a validator can send to the deposit contract at once (lower amounts will be reverted).
@@ -77,6 +78,7 @@ type BeaconChainConfig struct {
 	SlotsPerHistoricalRoot                    primitives.Slot  `yaml:"SLOTS_PER_HISTORICAL_ROOT" spec:"true"`           // SlotsPerHistoricalRoot defines how often the historical root is saved.
 	MinValidatorWithdrawabilityDelay          primitives.Epoch `yaml:"MIN_VALIDATOR_WITHDRAWABILITY_DELAY" spec:"true"` // MinValidatorWithdrawabilityDelay is the shortest amount of time a validator has to wait to withdraw.
 	ShardCommitteePeriod                      primitives.Epoch `yaml:"SHARD_COMMITTEE_PERIOD" spec:"true"`              // ShardCommitteePeriod is the minimum amount of epochs a validator must participate before exiting.
+	MinEpochsForBlockRequests                 primitives.Epoch `yaml:"MIN_EPOCHS_FOR_BLOCK_REQUESTS" spec:"true"`       // MinEpochsForBlockRequests is the minimum number of epochs of blocks a node must serve to its peers.
 	MinEpochsToInactivityPenalty              primitives.Epoch `yaml:"MIN_EPOCHS_TO_INACTIVITY_PENALTY" spec:"true"`    // MinEpochsToInactivityPenalty defines the minimum amount of epochs since finality to begin penalizing inactivity.
 	Eth1FollowDistance                        uint64           `yaml:"ETH1_FOLLOW_DISTANCE" spec:"true"`                // Eth1FollowDistance is the number of eth1.0 blocks to wait before considering a new deposit for voting. This only applies after the chain as been started.
 	DeprecatedSafeSlotsToUpdateJustified      primitives.Slot  `yaml:"SAFE_SLOTS_TO_UPDATE_JUSTIFIED" spec:"true"`      // DeprecateSafeSlotsToUpdateJustified is the minimal slots needed to update justified check point.
@@ -198,12 +200,13 @@ type BeaconChainConfig struct {
 	SyncCommitteeSubnetCount             uint64 `yaml:"SYNC_COMMITTEE_SUBNET_COUNT" spec:"true"`              // SyncCommitteeSubnetCount for sync committee subnet count.
 
 	// Misc.
-	SyncCommitteeSize            uint64           `yaml:"SYNC_COMMITTEE_SIZE" spec:"true"`              // SyncCommitteeSize for light client sync committee size.
-	InactivityScoreBias          uint64           `yaml:"INACTIVITY_SCORE_BIAS" spec:"true"`            // InactivityScoreBias for calculating score bias penalties during inactivity
-	InactivityScoreRecoveryRate  uint64           `yaml:"INACTIVITY_SCORE_RECOVERY_RATE" spec:"true"`   // InactivityScoreRecoveryRate for recovering score bias penalties during inactivity.
-	BailOutScoreBias             uint64           `yaml:"BAILOUT_SCORE_BIAS" spec:"true"`               // BailOutScoreBias for calculating score bias penalties in bail out
-	BailOutScoreThreshold        uint64           `yaml:"BAILOUT_SCORE_THRESHOLD" spec:"true"`          // BailOutScoreThreshold is the threshold used to determine which validator should be triggered for bail out.
-	EpochsPerSyncCommitteePeriod primitives.Epoch `yaml:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD" spec:"true"` // EpochsPerSyncCommitteePeriod defines how many epochs per sync committee period.
+	SyncCommitteeSize            uint64                `yaml:"SYNC_COMMITTEE_SIZE" spec:"true"`              // SyncCommitteeSize for light client sync committee size.
+	InactivityScoreBias          uint64                `yaml:"INACTIVITY_SCORE_BIAS" spec:"true"`            // InactivityScoreBias for calculating score bias penalties during inactivity
+	InactivityScoreRecoveryRate  uint64                `yaml:"INACTIVITY_SCORE_RECOVERY_RATE" spec:"true"`   // InactivityScoreRecoveryRate for recovering score bias penalties during inactivity.
+	BailOutScoreBias             uint64                `yaml:"BAILOUT_SCORE_BIAS" spec:"true"`               // BailOutScoreBias for calculating score bias penalties in bail out
+	BailOutScoreThreshold        uint64                `yaml:"BAILOUT_SCORE_THRESHOLD" spec:"true"`          // BailOutScoreThreshold is the threshold used to determine which validator should be triggered for bail out.
+	BailOutRecoverySchedule      []BailOutRecoveryStep `yaml:"BAILOUT_RECOVERY_SCHEDULE" spec:"true"`        // BailOutRecoverySchedule defines the bail out score recovered per epoch by the number of validators.
+	EpochsPerSyncCommitteePeriod primitives.Epoch      `yaml:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD" spec:"true"` // EpochsPerSyncCommitteePeriod defines how many epochs per sync committee period.
 
 	// Updated penalty values. This moves penalty parameters toward their final, maximum security values.
 	// Note: We do not override previous configuration values but instead creates new values and replaces usage throughout.
@@ -278,21 +281,3 @@ func (b *BeaconChainConfig) PreviousEpochAttestationsLength() uint64 {
 func (b *BeaconChainConfig) CurrentEpochAttestationsLength() uint64 {
 	return uint64(b.SlotsPerEpoch.Mul(b.MaxAttestations))
 }
-
-// InitializeDepositPlan initializes the deposit plan of consensus.
-func (b *BeaconChainConfig) InitializeDepositPlan() {
-	b.DepositPlanEarlySlope = 180000000 * 1e9 / (b.EpochsPerYear * b.DepositPlanEarlyEnd)
-	b.DepositPlanEarlyOffset = 20000000 * 1e9
-	b.DepositPlanLaterSlope = 100000000 * 1e9 / (b.EpochsPerYear * (b.DepositPlanLaterEnd - b.DepositPlanEarlyEnd))
-	b.DepositPlanLaterOffset = 133333334 * 1e9
-	b.DepositPlanFinal = 300000000 * 1e9
-}
-
-// InitializeDolphinDepositPlan initializes the deposit plan of Dolphin testnet consensus.
-func (b *BeaconChainConfig) InitializeDolphinDepositPlan() {
-	b.DepositPlanEarlySlope = 160000000 * 1e9 / (b.EpochsPerYear * b.DepositPlanEarlyEnd)
-	b.DepositPlanEarlyOffset = 40000000 * 1e9
-	b.DepositPlanLaterSlope = 100000000 * 1e9 / (b.EpochsPerYear * (b.DepositPlanLaterEnd - b.DepositPlanEarlyEnd))
-	b.DepositPlanLaterOffset = 133333334 * 1e9
-	b.DepositPlanFinal = 300000000 * 1e9
-}
@@ -40,6 +40,9 @@ func isUnderDevnet(lines []string) bool {
 func UnmarshalConfig(yamlFile []byte, conf *BeaconChainConfig) (*BeaconChainConfig, error) {
 	// To track if config name is defined inside config file.
 	hasConfigName := false
+	// To track how the deposit plan is defined inside config file.
+	hasDepositPlan := false
+	hasDeprecatedDepositPlan := false
 	// Convert 0x hex inputs to fixed bytes arrays
 	lines := strings.Split(string(yamlFile), "\n")
 	if conf == nil {
@@ -61,6 +64,12 @@ func UnmarshalConfig(yamlFile []byte, conf *BeaconChainConfig) (*BeaconChainConf
 		if strings.HasPrefix(line, "CONFIG_NAME") {
 			hasConfigName = true
 		}
+		if strings.HasPrefix(line, "DEPOSIT_PLAN:") {
+			hasDepositPlan = true
+		}
+		if strings.HasPrefix(line, "DEPOSIT_PLAN_EARLY_") || strings.HasPrefix(line, "DEPOSIT_PLAN_LATER_") {
+			hasDeprecatedDepositPlan = true
+		}
 		if !strings.HasPrefix(line, "#") && strings.Contains(line, "0x") {
 			parts := ReplaceHexStringWithYAMLFormat(line)
 			lines[i] = strings.Join(parts, "\n")
@@ -77,6 +86,21 @@ func UnmarshalConfig(yamlFile []byte, conf *BeaconChainConfig) (*BeaconChainConf
 	if !hasConfigName {
 		conf.ConfigName = DevnetName
 	}
+	if hasDeprecatedDepositPlan {
+		if hasDepositPlan {
+			return nil, errors.New("DEPOSIT_PLAN can not be combined with the deprecated DEPOSIT_PLAN_EARLY_* and DEPOSIT_PLAN_LATER_* keys in chain config yaml file")
+		}
+		log.Warn("The DEPOSIT_PLAN_EARLY_* and DEPOSIT_PLAN_LATER_* chain config keys are deprecated, use DEPOSIT_PLAN instead")
+		plan, err := conf.depositPlanFromDeprecated()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid deprecated deposit plan in chain config yaml file")
+		}
+		conf.DepositPlan = plan
+	}
+	if err := conf.ValidateTokenomicsSchedules(); err != nil {
+		return nil, errors.Wrap(err, "invalid tokenomics schedule in chain config yaml file")
+	}
+	conf.setDeprecatedDepositPlan()
 	// recompute SqrRootSlotsPerEpoch constant to handle non-standard values of SlotsPerEpoch
 	conf.SqrRootSlotsPerEpoch = primitives.Slot(math.IntegerSquareRoot(uint64(conf.SlotsPerEpoch)))
 	log.Debugf("Config file values: %+v", conf)
@@ -22,7 +22,7 @@ import (
 // These are variables that we don't use in Prysm. (i.e. future hardfork, light client... etc)
 var placeholderFields = []string{"UPDATE_TIMEOUT", "DENEB_FORK_EPOCH", "DENEB_FORK_VERSION",
 	"ATTESTATION_SUBNET_EXTRA_BITS", "RESP_TIMEOUT", "MAX_REQUEST_BLOCKS", "EPOCHS_PER_SUBNET_SUBSCRIPTION",
-	"EIP6110_FORK_EPOCH", "MESSAGE_DOMAIN_INVALID_SNAPPY", "MIN_EPOCHS_FOR_BLOCK_REQUESTS", "MAXIMUM_GOSSIP_CLOCK_DISPARITY",
+	"EIP6110_FORK_EPOCH", "MESSAGE_DOMAIN_INVALID_SNAPPY", "MAXIMUM_GOSSIP_CLOCK_DISPARITY",
 	"MESSAGE_DOMAIN_VALID_SNAPPY", "GOSSIP_MAX_SIZE", "SUBNETS_PER_NODE", "ATTESTATION_SUBNET_COUNT",
 	"MAX_CHUNK_SIZE", "ATTESTATION_PROPAGATION_SLOT_RANGE", "ATTESTATION_SUBNET_PREFIX_BITS", "EIP6110_FORK_VERSION", "TTFB_TIMEOUT"}
 
@@ -218,6 +218,110 @@ func TestLoadConfigFile_OverwriteCorrectly(t *testing.T) {
 	require.Equal(t, params.MinimalName, params.BeaconConfig().ConfigName)
 }
 
+func TestUnmarshalConfig_TokenomicsSchedules(t *testing.T) {
+	yml := `CONFIG_NAME: 'tokenomics'
+DEPOSIT_PLAN:
+  - END_YEAR: 2
+    INCREASE: 100000000000000000
+    OFFSET: 10000000000000000
+  - END_YEAR: 5
+    INCREASE: 0
+    OFFSET: 110000000000000000
+DEPOSIT_PLAN_FINAL: 120000000000000000
+BAILOUT_RECOVERY_SCHEDULE:
+  - MIN_VALIDATORS: 0
+    RECOVERY_SCORE: 1000
+  - MIN_VALIDATORS: 64
+    RECOVERY_SCORE: 2000
+`
+	cfg, err := params.UnmarshalConfig([]byte(yml), nil)
+	require.NoError(t, err)
+	require.DeepEqual(t, []params.DepositPlanSegment{
+		{EndYear: 2, Increase: 100000000 * 1e9, Offset: 10000000 * 1e9},
+		{EndYear: 5, Increase: 0, Offset: 110000000 * 1e9},
+	}, cfg.DepositPlan)
+	assert.Equal(t, uint64(120000000*1e9), cfg.DepositPlanFinal)
+	require.DeepEqual(t, []params.BailOutRecoveryStep{
+		{MinValidators: 0, RecoveryScore: 1000},
+		{MinValidators: 64, RecoveryScore: 2000},
+	}, cfg.BailOutRecoverySchedule)
+	// The deprecated keys follow the first two segments.
+	assert.Equal(t, uint64(2), cfg.DepositPlanEarlyEnd)
+	assert.Equal(t, uint64(100000000*1e9/(2*cfg.EpochsPerYear)), cfg.DepositPlanEarlySlope)
+	assert.Equal(t, uint64(5), cfg.DepositPlanLaterEnd)
+	assert.Equal(t, uint64(0), cfg.DepositPlanLaterSlope)
+	assert.Equal(t, uint64(110000000*1e9), cfg.DepositPlanLaterOffset)
+	// The presets are untouched.
+	require.DeepEqual(t, params.MainnetDepositPlan(), params.MainnetConfig().DepositPlan)
+
+	tests := []struct {
+		name    string
+		yml     string
+		wantErr string
+	}{
+		{
+			name:    "empty deposit plan",
+			yml:     "DEPOSIT_PLAN: []\n",
+			wantErr: "DEPOSIT_PLAN must have at least one segment",
+		},
+		{
+			name:    "unordered deposit plan",
+			yml:     "DEPOSIT_PLAN:\n  - END_YEAR: 4\n  - END_YEAR: 4\n",
+			wantErr: "DEPOSIT_PLAN segment 1 ends at year 4, which is not after year 4",
+		},
+		{
+			name:    "zero length segment",
+			yml:     "DEPOSIT_PLAN:\n  - END_YEAR: 0\n",
+			wantErr: "DEPOSIT_PLAN segment 0 ends at year 0",
+		},
+		{
+			name:    "deposit plan with deprecated keys",
+			yml:     "DEPOSIT_PLAN:\n  - END_YEAR: 4\nDEPOSIT_PLAN_EARLY_END: 4\n",
+			wantErr: "DEPOSIT_PLAN can not be combined with the deprecated DEPOSIT_PLAN_EARLY_* and DEPOSIT_PLAN_LATER_* keys",
+		},
+		{
+			name:    "deprecated deposit plan ending early",
+			yml:     "DEPOSIT_PLAN_EARLY_END: 4\nDEPOSIT_PLAN_LATER_END: 4\n",
+			wantErr: "DEPOSIT_PLAN_LATER_END 4 must be after DEPOSIT_PLAN_EARLY_END 4",
+		},
+		{
+			name:    "recovery schedule not starting at zero",
+			yml:     "BAILOUT_RECOVERY_SCHEDULE:\n  - MIN_VALIDATORS: 1\n    RECOVERY_SCORE: 1\n",
+			wantErr: "BAILOUT_RECOVERY_SCHEDULE must start at zero validators",
+		},
+		{
+			name:    "unordered recovery schedule",
+			yml:     "BAILOUT_RECOVERY_SCHEDULE:\n  - MIN_VALIDATORS: 0\n  - MIN_VALIDATORS: 8\n  - MIN_VALIDATORS: 4\n",
+			wantErr: "BAILOUT_RECOVERY_SCHEDULE step 2 starts at 4 validators, which is not above 8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := params.UnmarshalConfig([]byte(tt.yml), nil)
+			assert.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestUnmarshalConfig_DeprecatedDepositPlan(t *testing.T) {
+	yml := `CONFIG_NAME: 'deprecated'
+EPOCHS_PER_YEAR: 1000
+DEPOSIT_PLAN_EARLY_END: 2
+DEPOSIT_PLAN_EARLY_SLOPE: 50000000000000
+DEPOSIT_PLAN_EARLY_OFFSET: 10000000000000000
+DEPOSIT_PLAN_LATER_END: 5
+DEPOSIT_PLAN_LATER_SLOPE: 0
+DEPOSIT_PLAN_LATER_OFFSET: 110000000000000000
+`
+	cfg, err := params.UnmarshalConfig([]byte(yml), nil)
+	require.NoError(t, err)
+	require.DeepEqual(t, []params.DepositPlanSegment{
+		{EndYear: 2, Increase: 100000000 * 1e9, Offset: 10000000 * 1e9},
+		{EndYear: 5, Increase: 0, Offset: 110000000 * 1e9},
+	}, cfg.DepositPlan)
+	assert.Equal(t, uint64(50000*1e9), cfg.DepositPlanEarlySlope)
+}
+
 func Test_replaceHexStringWithYAMLFormat(t *testing.T) {
 
 	testLines := []struct {
@@ -13,7 +13,6 @@ func MainnetConfig() *BeaconChainConfig {
 	if mainnetBeaconConfig.ForkVersionSchedule == nil {
 		mainnetBeaconConfig.InitializeForkSchedule()
 	}
-	mainnetBeaconConfig.InitializeDepositPlan()
 	return mainnetBeaconConfig
 }
 
@@ -77,8 +76,14 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	HysteresisUpwardMultiplier:        5,
 	IssuanceRate:                      [11]uint64{20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 0},
 	IssuancePrecision:                 1000,
+	DepositPlan:                       MainnetDepositPlan(),
 	DepositPlanEarlyEnd:               4,
+	DepositPlanEarlySlope:             547945205479,
+	DepositPlanEarlyOffset:            20000000 * 1e9,
 	DepositPlanLaterEnd:               10,
+	DepositPlanLaterSlope:             202942668696,
+	DepositPlanLaterOffset:            133333334 * 1e9,
+	DepositPlanFinal:                  300000000 * 1e9,
 	RewardFeedbackPrecision:           1000000000000,
 	RewardFeedbackThresholdReciprocal: 10,
 	TargetChangeRate:                  1500000,
@@ -109,6 +114,7 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	SlotsPerHistoricalRoot:           8192,
 	MinValidatorWithdrawabilityDelay: 256,
 	ShardCommitteePeriod:             256,
+	MinEpochsForBlockRequests:        33024, // MinValidatorWithdrawabilityDelay + ChurnLimitQuotient / 2
 	MinEpochsToInactivityPenalty:     4,
 	Eth1FollowDistance:               1024,
 
@@ -238,6 +244,7 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	InactivityScoreRecoveryRate:  16,
 	BailOutScoreBias:             1000000000000000,
 	BailOutScoreThreshold:        1575000000000000000,
+	BailOutRecoverySchedule:      MainnetBailOutRecoverySchedule(),
 	EpochsPerSyncCommitteePeriod: 256,
 
 	// Updated penalty values.
@@ -37,11 +37,17 @@ func MinimalSpecConfig() *BeaconChainConfig {
 	minimalConfig.SlotsPerEpoch = 8
 	minimalConfig.SqrRootSlotsPerEpoch = 2
 	minimalConfig.EpochsPerYear = 657000
+	// The deposit plan keeps the per epoch slopes of mainnet over the longer years.
+	minimalConfig.DepositPlan = []DepositPlanSegment{
+		{EndYear: 4, Increase: 547945205479 * 657000 * 4, Offset: 20000000 * 1e9},
+		{EndYear: 10, Increase: 202942668696 * 657000 * 6, Offset: 133333334 * 1e9},
+	}
 	minimalConfig.MinSeedLookahead = 1
 	minimalConfig.MaxSeedLookahead = 4
 	minimalConfig.EpochsPerEth1VotingPeriod = 4
 	minimalConfig.SlotsPerHistoricalRoot = 64
 	minimalConfig.MinValidatorWithdrawabilityDelay = 256
+	minimalConfig.MinEpochsForBlockRequests = 272
 	minimalConfig.ShardCommitteePeriod = 64
 	minimalConfig.MinEpochsToInactivityPenalty = 4
 	minimalConfig.Eth1FollowDistance = 16
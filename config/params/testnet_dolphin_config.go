@@ -26,7 +26,8 @@ func DolphinConfig() *BeaconChainConfig {
 	cfg.CapellaForkVersion = []byte{0x03, 0x00, 0x00, 0x28}
 	cfg.IssuanceRate = [11]uint64{20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 0}
 	cfg.MaxBoostYield = [11]uint64{0, 10000000000, 10000000000, 10000000000, 10000000000, 10000000000, 10000000000, 10000000000, 10000000000, 10000000000, 10000000000}
+	cfg.DepositPlan = DolphinDepositPlan()
+	cfg.setDeprecatedDepositPlan()
 	cfg.InitializeForkSchedule()
-	cfg.InitializeDolphinDepositPlan()
 	return cfg
 }
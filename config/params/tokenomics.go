@@ -0,0 +1,139 @@
+package params
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/math"
+)
+
+// DepositPlanSegment is a linear piece of the target deposit plan. A segment covers the epochs
+// from the end of the previous segment (or genesis) up to EndYear*EpochsPerYear, exclusive.
+// Within it the target deposit is Slope*epoch + Offset in Gwei, where the slope spreads Increase
+// evenly over the epochs of the segment.
+type DepositPlanSegment struct {
+	EndYear  uint64 `yaml:"END_YEAR" json:"END_YEAR,string"` // EndYear is the year the segment ends at.
+	Increase uint64 `yaml:"INCREASE" json:"INCREASE,string"` // Increase is the deposit in Gwei added over the segment.
+	Offset   uint64 `yaml:"OFFSET" json:"OFFSET,string"`     // Offset is the deposit in Gwei of the segment line at epoch zero.
+}
+
+// BailOutRecoveryStep is the bail out score recovered per epoch by a validator meeting the target
+// while the validator registry has at least MinValidators validators, up to the next step.
+type BailOutRecoveryStep struct {
+	MinValidators uint64 `yaml:"MIN_VALIDATORS" json:"MIN_VALIDATORS,string"` // MinValidators is the registry size the step starts at.
+	RecoveryScore uint64 `yaml:"RECOVERY_SCORE" json:"RECOVERY_SCORE,string"` // RecoveryScore is the score recovered per epoch.
+}
+
+// MainnetDepositPlan is the target deposit plan of mainnet: 20M to 200M OVER in the first four years,
+// then up to ~300M OVER in year ten.
+func MainnetDepositPlan() []DepositPlanSegment {
+	return []DepositPlanSegment{
+		{EndYear: 4, Increase: 180000000 * 1e9, Offset: 20000000 * 1e9},
+		{EndYear: 10, Increase: 100000000 * 1e9, Offset: 133333334 * 1e9},
+	}
+}
+
+// DolphinDepositPlan is the target deposit plan of the Dolphin testnet, which starts from 40M OVER.
+func DolphinDepositPlan() []DepositPlanSegment {
+	return []DepositPlanSegment{
+		{EndYear: 4, Increase: 160000000 * 1e9, Offset: 40000000 * 1e9},
+		{EndYear: 10, Increase: 100000000 * 1e9, Offset: 133333334 * 1e9},
+	}
+}
+
+// MainnetBailOutRecoverySchedule is the bail out score recovery of mainnet, growing with every
+// doubling of the validator registry from 2^13 to 2^22 validators.
+func MainnetBailOutRecoverySchedule() []BailOutRecoveryStep {
+	return []BailOutRecoveryStep{
+		{MinValidators: 0, RecoveryScore: 396670510735312},
+		{MinValidators: math.PowerOf2(13), RecoveryScore: 424422339421802},
+		{MinValidators: math.PowerOf2(14), RecoveryScore: 445262211294194},
+		{MinValidators: math.PowerOf2(15), RecoveryScore: 460632171078136},
+		{MinValidators: math.PowerOf2(16), RecoveryScore: 471826519177477},
+		{MinValidators: math.PowerOf2(17), RecoveryScore: 479908445837684},
+		{MinValidators: math.PowerOf2(18), RecoveryScore: 485707547285690},
+		{MinValidators: math.PowerOf2(19), RecoveryScore: 489850697296405},
+		{MinValidators: math.PowerOf2(20), RecoveryScore: 492801774069274},
+		{MinValidators: math.PowerOf2(21), RecoveryScore: 494899265141145},
+		{MinValidators: math.PowerOf2(22), RecoveryScore: 496387815679376},
+	}
+}
+
+// depositPlanFromDeprecated returns the two segment deposit plan described by the deprecated
+// DEPOSIT_PLAN_EARLY_* and DEPOSIT_PLAN_LATER_* values, which give the slope of a segment per epoch.
+func (b *BeaconChainConfig) depositPlanFromDeprecated() ([]DepositPlanSegment, error) {
+	if b.DepositPlanEarlyEnd == 0 || b.DepositPlanLaterEnd <= b.DepositPlanEarlyEnd {
+		return nil, errors.Errorf("DEPOSIT_PLAN_LATER_END %d must be after DEPOSIT_PLAN_EARLY_END %d, which must be positive", b.DepositPlanLaterEnd, b.DepositPlanEarlyEnd)
+	}
+	earlyEpochs, err := math.Mul64(b.EpochsPerYear, b.DepositPlanEarlyEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "DEPOSIT_PLAN_EARLY_END is too late")
+	}
+	earlyIncrease, err := math.Mul64(b.DepositPlanEarlySlope, earlyEpochs)
+	if err != nil {
+		return nil, errors.Wrap(err, "DEPOSIT_PLAN_EARLY_SLOPE is too large")
+	}
+	laterEpochs, err := math.Mul64(b.EpochsPerYear, b.DepositPlanLaterEnd-b.DepositPlanEarlyEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "DEPOSIT_PLAN_LATER_END is too late")
+	}
+	laterIncrease, err := math.Mul64(b.DepositPlanLaterSlope, laterEpochs)
+	if err != nil {
+		return nil, errors.Wrap(err, "DEPOSIT_PLAN_LATER_SLOPE is too large")
+	}
+	return []DepositPlanSegment{
+		{EndYear: b.DepositPlanEarlyEnd, Increase: earlyIncrease, Offset: b.DepositPlanEarlyOffset},
+		{EndYear: b.DepositPlanLaterEnd, Increase: laterIncrease, Offset: b.DepositPlanLaterOffset},
+	}, nil
+}
+
+// setDeprecatedDepositPlan sets the deprecated DEPOSIT_PLAN_EARLY_* and DEPOSIT_PLAN_LATER_* values
+// from the first two segments of a valid deposit plan, for the consumers of the config spec which
+// still read them. The values of a missing segment are zero.
+func (b *BeaconChainConfig) setDeprecatedDepositPlan() {
+	var early, later DepositPlanSegment
+	var earlySlope, laterSlope uint64
+	if len(b.DepositPlan) > 0 {
+		early = b.DepositPlan[0]
+		earlySlope = early.Increase / (b.EpochsPerYear * early.EndYear)
+	}
+	if len(b.DepositPlan) > 1 {
+		later = b.DepositPlan[1]
+		laterSlope = later.Increase / (b.EpochsPerYear * (later.EndYear - early.EndYear))
+	}
+	b.DepositPlanEarlyEnd, b.DepositPlanEarlySlope, b.DepositPlanEarlyOffset = early.EndYear, earlySlope, early.Offset
+	b.DepositPlanLaterEnd, b.DepositPlanLaterSlope, b.DepositPlanLaterOffset = later.EndYear, laterSlope, later.Offset
+}
+
+// ValidateTokenomicsSchedules checks that the deposit plan and the bail out recovery schedule
+// of the config are well formed.
+func (b *BeaconChainConfig) ValidateTokenomicsSchedules() error {
+	if len(b.DepositPlan) == 0 {
+		return errors.New("DEPOSIT_PLAN must have at least one segment")
+	}
+	if b.EpochsPerYear == 0 {
+		return errors.New("EPOCHS_PER_YEAR must be positive")
+	}
+	prevEnd := uint64(0)
+	for i, s := range b.DepositPlan {
+		if s.EndYear <= prevEnd {
+			return errors.Errorf("DEPOSIT_PLAN segment %d ends at year %d, which is not after year %d", i, s.EndYear, prevEnd)
+		}
+		if _, err := math.Mul64(b.EpochsPerYear, s.EndYear); err != nil {
+			return errors.Wrapf(err, "DEPOSIT_PLAN segment %d ends too late", i)
+		}
+		prevEnd = s.EndYear
+	}
+
+	if len(b.BailOutRecoverySchedule) == 0 {
+		return errors.New("BAILOUT_RECOVERY_SCHEDULE must have at least one step")
+	}
+	if b.BailOutRecoverySchedule[0].MinValidators != 0 {
+		return errors.New("BAILOUT_RECOVERY_SCHEDULE must start at zero validators")
+	}
+	for i := 1; i < len(b.BailOutRecoverySchedule); i++ {
+		prev, cur := b.BailOutRecoverySchedule[i-1], b.BailOutRecoverySchedule[i]
+		if cur.MinValidators <= prev.MinValidators {
+			return errors.Errorf("BAILOUT_RECOVERY_SCHEDULE step %d starts at %d validators, which is not above %d", i, cur.MinValidators, prev.MinValidators)
+		}
+	}
+	return nil
+}
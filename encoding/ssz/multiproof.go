@@ -0,0 +1,157 @@
+package ssz
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/minio/sha256-simd"
+	"github.com/pkg/errors"
+)
+
+// GeneralizedIndexDepth returns the depth of the node at the given generalized index,
+// the root being at depth zero.
+func GeneralizedIndexDepth(index uint64) uint64 {
+	if index == 0 {
+		return 0
+	}
+	return uint64(bits.Len64(index) - 1)
+}
+
+// ConcatGeneralizedIndices returns the generalized index of a node given the generalized
+// indices of its ancestors, each one relative to the subtree rooted at the previous one.
+func ConcatGeneralizedIndices(indices ...uint64) (uint64, error) {
+	o := uint64(1)
+	for _, i := range indices {
+		if i == 0 {
+			return 0, errors.New("generalized index zero is invalid")
+		}
+		depth := GeneralizedIndexDepth(i)
+		if GeneralizedIndexDepth(o)+depth > 63 {
+			return 0, errors.New("generalized index overflows 64 bits")
+		}
+		o = o<<depth | (i ^ 1<<depth)
+	}
+	return o, nil
+}
+
+// IsGeneralizedIndexAncestor returns true if the node at the generalized index ancestor is the
+// node at the generalized index index or one of its ancestors.
+func IsGeneralizedIndexAncestor(ancestor, index uint64) bool {
+	if ancestor == 0 || index == 0 {
+		return false
+	}
+	da, di := GeneralizedIndexDepth(ancestor), GeneralizedIndexDepth(index)
+	return da <= di && index>>(di-da) == ancestor
+}
+
+// MultiproofHelperIndices returns the generalized indices of the nodes a multiproof of the
+// nodes at the given generalized indices is made of, in descending order.
+//
+// Spec pseudocode definition:
+//
+//	def get_helper_indices(indices: Sequence[GeneralizedIndex]) -> Sequence[GeneralizedIndex]:
+//	  all_helper_indices: Set[GeneralizedIndex] = set()
+//	  all_path_indices: Set[GeneralizedIndex] = set()
+//	  for index in indices:
+//	      all_helper_indices = all_helper_indices.union(set(get_branch_indices(index)))
+//	      all_path_indices = all_path_indices.union(set(get_path_indices(index)))
+//
+//	  return sorted(all_helper_indices.difference(all_path_indices), reverse=True)
+func MultiproofHelperIndices(indices []uint64) []uint64 {
+	helpers := make(map[uint64]bool)
+	paths := make(map[uint64]bool)
+	for _, index := range indices {
+		for i := index; i > 1; i /= 2 {
+			helpers[i^1] = true
+			paths[i] = true
+		}
+	}
+	helperIndices := make([]uint64, 0, len(helpers))
+	for i := range helpers {
+		if !paths[i] {
+			helperIndices = append(helperIndices, i)
+		}
+	}
+	sort.Slice(helperIndices, func(i, j int) bool {
+		return helperIndices[i] > helperIndices[j]
+	})
+	return helperIndices
+}
+
+// MultiproofRoot computes the root of the Merkle tree the given multiproof is for, where proof
+// holds the nodes at MultiproofHelperIndices(indices).
+//
+// Spec pseudocode definition:
+//
+//	def calculate_multi_merkle_root(leaves: Sequence[Bytes32],
+//	                                proof: Sequence[Bytes32],
+//	                                indices: Sequence[GeneralizedIndex]) -> Root:
+//	  assert len(leaves) == len(indices)
+//	  helper_indices = get_helper_indices(indices)
+//	  assert len(proof) == len(helper_indices)
+//	  objects = {
+//	      **{index: node for index, node in zip(indices, leaves)},
+//	      **{index: node for index, node in zip(helper_indices, proof)}
+//	  }
+//	  keys = sorted(objects.keys(), reverse=True)
+//	  pos = 0
+//	  while pos < len(keys):
+//	      k = keys[pos]
+//	      if k in objects and k ^ 1 in objects and k // 2 not in objects:
+//	          objects[GeneralizedIndex(k // 2)] = hash(
+//	              objects[GeneralizedIndex((k | 1) ^ 1)] +
+//	              objects[GeneralizedIndex(k | 1)]
+//	          )
+//	          keys.append(GeneralizedIndex(k // 2))
+//	      pos += 1
+//	  return objects[GeneralizedIndex(1)]
+func MultiproofRoot(leaves, proof [][32]byte, indices []uint64) ([32]byte, error) {
+	if len(leaves) != len(indices) {
+		return [32]byte{}, errors.Errorf("got %d leaves for %d indices", len(leaves), len(indices))
+	}
+	helperIndices := MultiproofHelperIndices(indices)
+	if len(proof) != len(helperIndices) {
+		return [32]byte{}, errors.Errorf("got %d proof nodes, expected %d", len(proof), len(helperIndices))
+	}
+	objects := make(map[uint64][32]byte, len(indices)+len(helperIndices))
+	for i, index := range indices {
+		objects[index] = leaves[i]
+	}
+	for i, index := range helperIndices {
+		objects[index] = proof[i]
+	}
+	keys := make([]uint64, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] > keys[j]
+	})
+	for pos := 0; pos < len(keys); pos++ {
+		k := keys[pos]
+		_, ok := objects[k]
+		sibling, hasSibling := objects[k^1]
+		_, hasParent := objects[k/2]
+		if !ok || !hasSibling || hasParent {
+			continue
+		}
+		left, right := objects[k], sibling
+		if k%2 == 1 {
+			left, right = sibling, objects[k]
+		}
+		objects[k/2] = sha256.Sum256(append(left[:], right[:]...))
+		keys = append(keys, k/2)
+	}
+	root, ok := objects[1]
+	if !ok {
+		return [32]byte{}, errors.New("multiproof does not lead to the root")
+	}
+	return root, nil
+}
+
+// VerifyMultiproof returns true if the given multiproof of the leaves at the given generalized
+// indices is valid against root.
+func VerifyMultiproof(root [32]byte, leaves, proof [][32]byte, indices []uint64) bool {
+	computed, err := MultiproofRoot(leaves, proof, indices)
+	return err == nil && computed == root
+}
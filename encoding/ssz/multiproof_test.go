@@ -0,0 +1,63 @@
+package ssz_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestConcatGeneralizedIndices(t *testing.T) {
+	index, err := ssz.ConcatGeneralizedIndices(2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), index)
+	// Field 23 of a 32 field container, then field 1 of a 2 field container.
+	index, err = ssz.ConcatGeneralizedIndices(1, 55, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(111), index)
+
+	_, err = ssz.ConcatGeneralizedIndices(2, 0)
+	require.ErrorContains(t, "zero is invalid", err)
+	_, err = ssz.ConcatGeneralizedIndices(1<<40, 1<<30)
+	require.ErrorContains(t, "overflows", err)
+}
+
+func TestIsGeneralizedIndexAncestor(t *testing.T) {
+	assert.Equal(t, true, ssz.IsGeneralizedIndexAncestor(1, 13))
+	assert.Equal(t, true, ssz.IsGeneralizedIndexAncestor(3, 13))
+	assert.Equal(t, true, ssz.IsGeneralizedIndexAncestor(13, 13))
+	assert.Equal(t, false, ssz.IsGeneralizedIndexAncestor(2, 13))
+	assert.Equal(t, false, ssz.IsGeneralizedIndexAncestor(13, 6))
+}
+
+func TestMultiproof(t *testing.T) {
+	// A tree of depth three, leaves at generalized indices 8 to 15.
+	nodes := make([][32]byte, 16)
+	for i := 8; i < 16; i++ {
+		nodes[i] = [32]byte{byte(i)}
+	}
+	for i := 7; i > 0; i-- {
+		nodes[i] = hash.Hash(append(nodes[2*i][:], nodes[2*i+1][:]...))
+	}
+
+	indices := []uint64{9, 14, 5}
+	helperIndices := ssz.MultiproofHelperIndices(indices)
+	assert.DeepEqual(t, []uint64{15, 8, 6}, helperIndices)
+
+	leaves := [][32]byte{nodes[9], nodes[14], nodes[5]}
+	proof := make([][32]byte, len(helperIndices))
+	for i, index := range helperIndices {
+		proof[i] = nodes[index]
+	}
+	root, err := ssz.MultiproofRoot(leaves, proof, indices)
+	require.NoError(t, err)
+	assert.Equal(t, nodes[1], root)
+	assert.Equal(t, true, ssz.VerifyMultiproof(nodes[1], leaves, proof, indices))
+
+	leaves[1] = [32]byte{'x'}
+	assert.Equal(t, false, ssz.VerifyMultiproof(nodes[1], leaves, proof, indices))
+	_, err = ssz.MultiproofRoot(leaves, proof[1:], indices)
+	require.ErrorContains(t, "got 2 proof nodes, expected 3", err)
+}
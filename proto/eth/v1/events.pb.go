@@ -514,6 +514,180 @@ func (x *EventPayloadAttributeV2) GetData() *EventPayloadAttributeV2_BasePayload
 	return nil
 }
 
+type EventBailOut struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValidatorIndex      github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex `protobuf:"varint,1,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty" cast-type:"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives.ValidatorIndex"`
+	Pubkey              []byte                                                                      `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty" ssz-size:"48"`
+	Slot                github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot           `protobuf:"varint,3,opt,name=slot,proto3" json:"slot,omitempty" cast-type:"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives.Slot"`
+	Block               []byte                                                                      `protobuf:"bytes,4,opt,name=block,proto3" json:"block,omitempty" ssz-size:"32"`
+	ExecutionOptimistic bool                                                                        `protobuf:"varint,5,opt,name=execution_optimistic,json=executionOptimistic,proto3" json:"execution_optimistic,omitempty"`
+}
+
+func (x *EventBailOut) Reset() {
+	*x = EventBailOut{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_eth_v1_events_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventBailOut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventBailOut) ProtoMessage() {}
+
+func (x *EventBailOut) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eth_v1_events_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventBailOut.ProtoReflect.Descriptor instead.
+func (*EventBailOut) Descriptor() ([]byte, []int) {
+	return file_proto_eth_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EventBailOut) GetValidatorIndex() github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex {
+	if x != nil {
+		return x.ValidatorIndex
+	}
+	return github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.ValidatorIndex(0)
+}
+
+func (x *EventBailOut) GetPubkey() []byte {
+	if x != nil {
+		return x.Pubkey
+	}
+	return nil
+}
+
+func (x *EventBailOut) GetSlot() github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot {
+	if x != nil {
+		return x.Slot
+	}
+	return github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Slot(0)
+}
+
+func (x *EventBailOut) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *EventBailOut) GetExecutionOptimistic() bool {
+	if x != nil {
+		return x.ExecutionOptimistic
+	}
+	return false
+}
+
+type EventTokenomics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch                  github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Epoch `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty" cast-type:"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives.Epoch"`
+	Block                  []byte                                                             `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty" ssz-size:"32"`
+	RewardAdjustmentFactor uint64                                                             `protobuf:"varint,3,opt,name=reward_adjustment_factor,json=rewardAdjustmentFactor,proto3" json:"reward_adjustment_factor,omitempty"`
+	PreviousEpochReserve   uint64                                                             `protobuf:"varint,4,opt,name=previous_epoch_reserve,json=previousEpochReserve,proto3" json:"previous_epoch_reserve,omitempty"`
+	CurrentEpochReserve    uint64                                                             `protobuf:"varint,5,opt,name=current_epoch_reserve,json=currentEpochReserve,proto3" json:"current_epoch_reserve,omitempty"`
+	EpochIssuance          uint64                                                             `protobuf:"varint,6,opt,name=epoch_issuance,json=epochIssuance,proto3" json:"epoch_issuance,omitempty"`
+	EpochFeedbackBoost     uint64                                                             `protobuf:"varint,7,opt,name=epoch_feedback_boost,json=epochFeedbackBoost,proto3" json:"epoch_feedback_boost,omitempty"`
+}
+
+func (x *EventTokenomics) Reset() {
+	*x = EventTokenomics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_eth_v1_events_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventTokenomics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTokenomics) ProtoMessage() {}
+
+func (x *EventTokenomics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_eth_v1_events_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTokenomics.ProtoReflect.Descriptor instead.
+func (*EventTokenomics) Descriptor() ([]byte, []int) {
+	return file_proto_eth_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EventTokenomics) GetEpoch() github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Epoch {
+	if x != nil {
+		return x.Epoch
+	}
+	return github_com_prysmaticlabs_prysm_v4_consensus_types_primitives.Epoch(0)
+}
+
+func (x *EventTokenomics) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *EventTokenomics) GetRewardAdjustmentFactor() uint64 {
+	if x != nil {
+		return x.RewardAdjustmentFactor
+	}
+	return 0
+}
+
+func (x *EventTokenomics) GetPreviousEpochReserve() uint64 {
+	if x != nil {
+		return x.PreviousEpochReserve
+	}
+	return 0
+}
+
+func (x *EventTokenomics) GetCurrentEpochReserve() uint64 {
+	if x != nil {
+		return x.CurrentEpochReserve
+	}
+	return 0
+}
+
+func (x *EventTokenomics) GetEpochIssuance() uint64 {
+	if x != nil {
+		return x.EpochIssuance
+	}
+	return 0
+}
+
+func (x *EventTokenomics) GetEpochFeedbackBoost() uint64 {
+	if x != nil {
+		return x.EpochFeedbackBoost
+	}
+	return 0
+}
+
 type EventPayloadAttributeV1_BasePayloadAttribute struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -530,7 +704,7 @@ type EventPayloadAttributeV1_BasePayloadAttribute struct {
 func (x *EventPayloadAttributeV1_BasePayloadAttribute) Reset() {
 	*x = EventPayloadAttributeV1_BasePayloadAttribute{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_eth_v1_events_proto_msgTypes[7]
+		mi := &file_proto_eth_v1_events_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -543,7 +717,7 @@ func (x *EventPayloadAttributeV1_BasePayloadAttribute) String() string {
 func (*EventPayloadAttributeV1_BasePayloadAttribute) ProtoMessage() {}
 
 func (x *EventPayloadAttributeV1_BasePayloadAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eth_v1_events_proto_msgTypes[7]
+	mi := &file_proto_eth_v1_events_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +791,7 @@ type EventPayloadAttributeV2_BasePayloadAttribute struct {
 func (x *EventPayloadAttributeV2_BasePayloadAttribute) Reset() {
 	*x = EventPayloadAttributeV2_BasePayloadAttribute{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_eth_v1_events_proto_msgTypes[8]
+		mi := &file_proto_eth_v1_events_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -630,7 +804,7 @@ func (x *EventPayloadAttributeV2_BasePayloadAttribute) String() string {
 func (*EventPayloadAttributeV2_BasePayloadAttribute) ProtoMessage() {}
 
 func (x *EventPayloadAttributeV2_BasePayloadAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_eth_v1_events_proto_msgTypes[8]
+	mi := &file_proto_eth_v1_events_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -862,15 +1036,61 @@ var file_proto_eth_v1_events_proto_rawDesc = []byte{
 	0x6d, 0x2e, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6c,
 	0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x56, 0x32, 0x52,
 	0x11, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
-	0x65, 0x73, 0x42, 0x7e, 0x0a, 0x13, 0x6f, 0x72, 0x67, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65,
-	0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x42, 0x65, 0x61, 0x63, 0x6f,
-	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2e,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d,
-	0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x2f, 0x76,
-	0x34, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x74, 0x68, 0x2f, 0x76, 0x31, 0xaa, 0x02,
-	0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x45, 0x74, 0x68, 0x2e, 0x56, 0x31,
-	0xca, 0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x5c, 0x45, 0x74, 0x68, 0x5c,
-	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x22, 0xd4, 0x02, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x69, 0x6c,
+	0x4f, 0x75, 0x74, 0x12, 0x78, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42, 0x4f, 0x82, 0xb5,
+	0x18, 0x4b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x79,
+	0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d,
+	0x2f, 0x76, 0x34, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2d, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x73, 0x2e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x0e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1e, 0x0a,
+	0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x06, 0x8a,
+	0xb5, 0x18, 0x02, 0x34, 0x38, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x59, 0x0a,
+	0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x45, 0x82, 0xb5, 0x18,
+	0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x79, 0x73,
+	0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d, 0x2f,
+	0x76, 0x34, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2d, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x73, 0x2e, 0x53, 0x6c,
+	0x6f, 0x74, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x06, 0x8a, 0xb5, 0x18, 0x02, 0x33, 0x32, 0x52,
+	0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x31, 0x0a, 0x14, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x74, 0x69, 0x63, 0x22, 0x8a, 0x03, 0x0a, 0x0f, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x63, 0x73, 0x12, 0x5c, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42, 0x46, 0x82, 0xb5,
+	0x18, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x79,
+	0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79, 0x73, 0x6d,
+	0x2f, 0x76, 0x34, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2d, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x73, 0x2e, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1c, 0x0a, 0x05, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x06, 0x8a, 0xb5, 0x18, 0x02,
+	0x33, 0x32, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x38, 0x0a, 0x18, 0x72, 0x65, 0x77,
+	0x61, 0x72, 0x64, 0x5f, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x66,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x72, 0x65, 0x77,
+	0x61, 0x72, 0x64, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x14, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x49, 0x73, 0x73, 0x75,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x66, 0x65,
+	0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x62, 0x6f, 0x6f, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x12, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x46, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63,
+	0x6b, 0x42, 0x6f, 0x6f, 0x73, 0x74, 0x42, 0x7e, 0x0a, 0x13, 0x6f, 0x72, 0x67, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x65, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x50, 0x01, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70,
+	0x72, 0x79, 0x73, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x70, 0x72, 0x79,
+	0x73, 0x6d, 0x2f, 0x76, 0x34, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x74, 0x68, 0x2f,
+	0x76, 0x31, 0xaa, 0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x45, 0x74,
+	0x68, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0f, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x5c,
+	0x45, 0x74, 0x68, 0x5c, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -885,7 +1105,7 @@ func file_proto_eth_v1_events_proto_rawDescGZIP() []byte {
 	return file_proto_eth_v1_events_proto_rawDescData
 }
 
-var file_proto_eth_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_eth_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_proto_eth_v1_events_proto_goTypes = []interface{}{
 	(*StreamEventsRequest)(nil),                          // 0: ethereum.eth.v1.StreamEventsRequest
 	(*EventHead)(nil),                                    // 1: ethereum.eth.v1.EventHead
@@ -894,16 +1114,18 @@ var file_proto_eth_v1_events_proto_goTypes = []interface{}{
 	(*EventFinalizedCheckpoint)(nil),                     // 4: ethereum.eth.v1.EventFinalizedCheckpoint
 	(*EventPayloadAttributeV1)(nil),                      // 5: ethereum.eth.v1.EventPayloadAttributeV1
 	(*EventPayloadAttributeV2)(nil),                      // 6: ethereum.eth.v1.EventPayloadAttributeV2
-	(*EventPayloadAttributeV1_BasePayloadAttribute)(nil), // 7: ethereum.eth.v1.EventPayloadAttributeV1.BasePayloadAttribute
-	(*EventPayloadAttributeV2_BasePayloadAttribute)(nil), // 8: ethereum.eth.v1.EventPayloadAttributeV2.BasePayloadAttribute
-	(*v1.PayloadAttributes)(nil),                         // 9: ethereum.engine.v1.PayloadAttributes
-	(*v1.PayloadAttributesV2)(nil),                       // 10: ethereum.engine.v1.PayloadAttributesV2
+	(*EventBailOut)(nil),                                 // 7: ethereum.eth.v1.EventBailOut
+	(*EventTokenomics)(nil),                              // 8: ethereum.eth.v1.EventTokenomics
+	(*EventPayloadAttributeV1_BasePayloadAttribute)(nil), // 9: ethereum.eth.v1.EventPayloadAttributeV1.BasePayloadAttribute
+	(*EventPayloadAttributeV2_BasePayloadAttribute)(nil), // 10: ethereum.eth.v1.EventPayloadAttributeV2.BasePayloadAttribute
+	(*v1.PayloadAttributes)(nil),                         // 11: ethereum.engine.v1.PayloadAttributes
+	(*v1.PayloadAttributesV2)(nil),                       // 12: ethereum.engine.v1.PayloadAttributesV2
 }
 var file_proto_eth_v1_events_proto_depIdxs = []int32{
-	7,  // 0: ethereum.eth.v1.EventPayloadAttributeV1.data:type_name -> ethereum.eth.v1.EventPayloadAttributeV1.BasePayloadAttribute
-	8,  // 1: ethereum.eth.v1.EventPayloadAttributeV2.data:type_name -> ethereum.eth.v1.EventPayloadAttributeV2.BasePayloadAttribute
-	9,  // 2: ethereum.eth.v1.EventPayloadAttributeV1.BasePayloadAttribute.payload_attributes:type_name -> ethereum.engine.v1.PayloadAttributes
-	10, // 3: ethereum.eth.v1.EventPayloadAttributeV2.BasePayloadAttribute.payload_attributes:type_name -> ethereum.engine.v1.PayloadAttributesV2
+	9,  // 0: ethereum.eth.v1.EventPayloadAttributeV1.data:type_name -> ethereum.eth.v1.EventPayloadAttributeV1.BasePayloadAttribute
+	10, // 1: ethereum.eth.v1.EventPayloadAttributeV2.data:type_name -> ethereum.eth.v1.EventPayloadAttributeV2.BasePayloadAttribute
+	11, // 2: ethereum.eth.v1.EventPayloadAttributeV1.BasePayloadAttribute.payload_attributes:type_name -> ethereum.engine.v1.PayloadAttributes
+	12, // 3: ethereum.eth.v1.EventPayloadAttributeV2.BasePayloadAttribute.payload_attributes:type_name -> ethereum.engine.v1.PayloadAttributesV2
 	4,  // [4:4] is the sub-list for method output_type
 	4,  // [4:4] is the sub-list for method input_type
 	4,  // [4:4] is the sub-list for extension type_name
@@ -1002,7 +1224,7 @@ func file_proto_eth_v1_events_proto_init() {
 			}
 		}
 		file_proto_eth_v1_events_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EventPayloadAttributeV1_BasePayloadAttribute); i {
+			switch v := v.(*EventBailOut); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1014,6 +1236,30 @@ func file_proto_eth_v1_events_proto_init() {
 			}
 		}
 		file_proto_eth_v1_events_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventTokenomics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_eth_v1_events_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventPayloadAttributeV1_BasePayloadAttribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_eth_v1_events_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EventPayloadAttributeV2_BasePayloadAttribute); i {
 			case 0:
 				return &v.state
@@ -1032,7 +1278,7 @@ func file_proto_eth_v1_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_eth_v1_events_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -163,16 +163,23 @@ func depositDataFromKeys(privKeys []bls.SecretKey, pubKeys []bls.PublicKey, numO
 
 // Generates a deposit data item from BLS keys and signs the hash tree root of the data.
 func createDepositData(privKey bls.SecretKey, pubKey bls.PublicKey, withExecCreds bool) (*ethpb.Deposit_Data, error) {
-	depositMessage := &ethpb.DepositMessage{
-		PublicKey:             pubKey.Marshal(),
-		WithdrawalCredentials: withdrawalCredentialsHash(pubKey.Marshal()),
-		Amount:                params.BeaconConfig().MaxEffectiveBalance,
-	}
+	creds := withdrawalCredentialsHash(pubKey.Marshal())
 	if withExecCreds {
 		newCredentials := make([]byte, 12)
 		newCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
 		execAddr := bytesutil.ToBytes20(pubKey.Marshal())
-		depositMessage.WithdrawalCredentials = append(newCredentials, execAddr[:]...)
+		creds = append(newCredentials, execAddr[:]...)
+	}
+	return signedDepositData(privKey, pubKey, creds, params.BeaconConfig().MaxEffectiveBalance)
+}
+
+// signedDepositData creates a deposit data item of the given withdrawal credentials and amount, and
+// signs the hash tree root of the data.
+func signedDepositData(privKey bls.SecretKey, pubKey bls.PublicKey, creds []byte, amount uint64) (*ethpb.Deposit_Data, error) {
+	depositMessage := &ethpb.DepositMessage{
+		PublicKey:             pubKey.Marshal(),
+		WithdrawalCredentials: creds,
+		Amount:                amount,
 	}
 	sr, err := depositMessage.HashTreeRoot()
 	if err != nil {
@@ -0,0 +1,65 @@
+package interop
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// GenesisAllocation describes a group of genesis validators sharing the same deposit amount and
+// withdrawal credentials.
+type GenesisAllocation struct {
+	// Count is the number of validators of the allocation.
+	Count uint64
+	// Amount is the deposit amount of each validator in Gwei, MaxEffectiveBalance if zero.
+	Amount uint64
+	// WithdrawalCredentials of each validator, BLS credentials derived from the validator key if empty.
+	WithdrawalCredentials []byte
+}
+
+// DepositDataFromAllocations returns the signed deposit data of the given allocations and their
+// hash tree roots. Validator keys are deterministically generated, the first allocation getting
+// the first keys, so that the genesis validators may be run from interop keys.
+func DepositDataFromAllocations(allocs []*GenesisAllocation) ([]*ethpb.Deposit_Data, [][]byte, error) {
+	total := uint64(0)
+	for i, a := range allocs {
+		if a.Count == 0 {
+			return nil, nil, errors.Errorf("allocation %d has no validators", i)
+		}
+		if len(a.WithdrawalCredentials) != 0 && len(a.WithdrawalCredentials) != 32 {
+			return nil, nil, errors.Errorf("allocation %d has withdrawal credentials of %d bytes, expected 32", i, len(a.WithdrawalCredentials))
+		}
+		total += a.Count
+	}
+	privKeys, pubKeys, err := DeterministicallyGenerateKeys(0, total)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not deterministically generate keys for %d validators", total)
+	}
+
+	dds := make([]*ethpb.Deposit_Data, 0, total)
+	roots := make([][]byte, 0, total)
+	for _, a := range allocs {
+		amount := a.Amount
+		if amount == 0 {
+			amount = params.BeaconConfig().MaxEffectiveBalance
+		}
+		for j := uint64(0); j < a.Count; j++ {
+			i := len(dds)
+			creds := a.WithdrawalCredentials
+			if len(creds) == 0 {
+				creds = withdrawalCredentialsHash(pubKeys[i].Marshal())
+			}
+			data, err := signedDepositData(privKeys[i], pubKeys[i], creds, amount)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "could not create deposit data for validator %d", i)
+			}
+			h, err := data.HashTreeRoot()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not hash tree root deposit data item")
+			}
+			dds = append(dds, data)
+			roots = append(roots, h[:])
+		}
+	}
+	return dds, roots, nil
+}
@@ -0,0 +1,63 @@
+package interop_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestPreminedGenesis_Allocations(t *testing.T) {
+	creds := make([]byte, 32)
+	creds[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	creds[31] = 0xaa
+	dds, roots, err := interop.DepositDataFromAllocations([]*interop.GenesisAllocation{
+		{Count: 2},
+		{Count: 3, Amount: 2 * params.BeaconConfig().MaxEffectiveBalance, WithdrawalCredentials: creds},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, len(dds))
+	require.Equal(t, 5, len(roots))
+
+	gb := interop.GethTestnetGenesis(0, params.BeaconConfig()).ToBlock()
+	st, err := interop.NewPreminedGenesis(context.Background(), 0, 0, 0, version.Capella, gb,
+		interop.WithDepositData(dds, roots),
+		interop.WithInitialReserve(1000),
+		interop.WithRewardAdjustmentFactor(7),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 5, st.NumValidators())
+	assert.Equal(t, uint64(1000), st.PreviousEpochReserve())
+	assert.Equal(t, uint64(1000), st.CurrentEpochReserve())
+	assert.Equal(t, uint64(7), st.RewardAdjustmentFactor())
+
+	_, pubKeys, err := interop.DeterministicallyGenerateKeys(0, 5)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		val, err := st.ValidatorAtIndex(primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		assert.DeepEqual(t, pubKeys[i].Marshal(), val.PublicKey)
+		balance, err := st.BalanceAtIndex(primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		if i < 2 {
+			assert.Equal(t, params.BeaconConfig().MaxEffectiveBalance, balance)
+			assert.Equal(t, params.BeaconConfig().BLSWithdrawalPrefixByte, val.WithdrawalCredentials[0])
+			continue
+		}
+		assert.Equal(t, 2*params.BeaconConfig().MaxEffectiveBalance, balance)
+		assert.Equal(t, true, bytes.Equal(creds, val.WithdrawalCredentials))
+	}
+}
+
+func TestDepositDataFromAllocations_Invalid(t *testing.T) {
+	_, _, err := interop.DepositDataFromAllocations([]*interop.GenesisAllocation{{Count: 0}})
+	require.ErrorContains(t, "allocation 0 has no validators", err)
+	_, _, err = interop.DepositDataFromAllocations([]*interop.GenesisAllocation{{Count: 1}, {Count: 1, WithdrawalCredentials: make([]byte, 20)}})
+	require.ErrorContains(t, "allocation 1 has withdrawal credentials of 20 bytes, expected 32", err)
+}
@@ -32,6 +32,9 @@ type PremineGenesisConfig struct {
 	Version         int          // as in "github.com/prysmaticlabs/prysm/v4/runtime/version"
 	GB              *types.Block // geth genesis block
 	depositEntries  *depositEntries
+	// Tokenomics of the genesis state, both reserves being set to InitialReserve.
+	InitialReserve         uint64
+	RewardAdjustmentFactor uint64
 }
 
 type depositEntries struct {
@@ -50,6 +53,20 @@ func WithDepositData(dds []*ethpb.Deposit_Data, roots [][]byte) PremineGenesisOp
 	}
 }
 
+// WithInitialReserve sets the previous and current epoch reserves of the genesis state, in Gwei.
+func WithInitialReserve(reserve uint64) PremineGenesisOpt {
+	return func(cfg *PremineGenesisConfig) {
+		cfg.InitialReserve = reserve
+	}
+}
+
+// WithRewardAdjustmentFactor sets the reward adjustment factor of the genesis state.
+func WithRewardAdjustmentFactor(factor uint64) PremineGenesisOpt {
+	return func(cfg *PremineGenesisConfig) {
+		cfg.RewardAdjustmentFactor = factor
+	}
+}
+
 // NewPreminedGenesis creates a genesis BeaconState at the given fork version, suitable for using as an e2e genesis.
 func NewPreminedGenesis(ctx context.Context, t, nvals, pCreds uint64, version int, gb *types.Block, opts ...PremineGenesisOpt) (state.BeaconState, error) {
 	cfg := &PremineGenesisConfig{
@@ -122,10 +139,13 @@ func (s *PremineGenesisConfig) empty() (state.BeaconState, error) {
 	if err = e.SetBalances([]uint64{}); err != nil {
 		return nil, err
 	}
-	if err = e.SetPreviousEpochReserve(0); err != nil {
+	if err = e.SetPreviousEpochReserve(s.InitialReserve); err != nil {
+		return nil, err
+	}
+	if err = e.SetCurrentEpochReserve(s.InitialReserve); err != nil {
 		return nil, err
 	}
-	if err = e.SetCurrentEpochReserve(0); err != nil {
+	if err = e.SetRewardAdjustmentFactor(s.RewardAdjustmentFactor); err != nil {
 		return nil, err
 	}
 	if err = e.SetJustificationBits([]byte{0}); err != nil {
@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// epochProcessingEpoch is the epoch whose last slot the state of every epoch processing case is at.
+const epochProcessingEpoch = primitives.Epoch(5)
+
+// epochState returns a state at the last slot of epochProcessingEpoch with a recent finalized checkpoint.
+func (f *fixture) epochState() *stateBuilder {
+	return f.state().
+		atEpochEnd(epochProcessingEpoch).
+		withFinalized(epochProcessingEpoch-1, epochProcessingEpoch-2)
+}
+
+// inLeak moves the finalized checkpoint back to genesis, far enough for an inactivity leak.
+func (b *stateBuilder) inLeak() *stateBuilder {
+	return b.withFinalized(0, 0)
+}
+
+// epochCase is a named pre-state of an epoch processing handler.
+type epochCase struct {
+	name string
+	pre  *stateBuilder
+}
+
+func epochCases(specs []epochCase, process func(ctx context.Context, st state.BeaconState) (state.BeaconState, error)) ([]*stateCase, error) {
+	cases := make([]*stateCase, 0, len(specs))
+	for _, s := range specs {
+		pre, err := s.pre.build()
+		if err != nil {
+			return nil, errors.Wrap(err, s.name)
+		}
+		cases = append(cases, &stateCase{
+			name:    s.name,
+			pre:     pre,
+			process: process,
+		})
+	}
+	return cases, nil
+}
+
+var inactivityAndBailOutUpdatesHandler = &stateHandler{
+	runner:  "epoch_processing",
+	handler: "inactivity_and_bail_out_updates",
+	cases:   inactivityAndBailOutUpdatesCases,
+}
+
+func inactivityAndBailOutUpdatesCases(_ context.Context, f *fixture) ([]*stateCase, error) {
+	cfg := params.BeaconConfig()
+	recovering := 3 * helpers.BailOutRecoveryScore(numValidators)
+	threshold := cfg.BailOutScoreThreshold
+	// Spread bail out scores around the threshold.
+	aroundThreshold := func(i int) uint64 {
+		switch i % 4 {
+		case 0:
+			return threshold - cfg.BailOutScoreBias
+		case 1:
+			return threshold
+		case 2:
+			return threshold + cfg.BailOutScoreBias
+		default:
+			return recovering
+		}
+	}
+	return epochCases([]epochCase{
+		{
+			name: "full_participation_recovers_scores",
+			pre:  f.epochState().withParticipation(all(fullParticipation), all(fullParticipation)).withScores(constant(20), constant(recovering)),
+		},
+		{
+			name: "no_participation_raises_scores",
+			pre:  f.epochState().withParticipation(all(noParticipation), all(noParticipation)).withScores(constant(20), constant(recovering)),
+		},
+		{
+			name: "mixed_participation",
+			pre:  f.epochState().withParticipation(every(3, timelySourceTarget), every(2, fullParticipation)).withScores(constant(20), constant(recovering)),
+		},
+		{
+			name: "mixed_participation_in_leak",
+			pre:  f.epochState().inLeak().withParticipation(every(3, timelySourceTarget), every(2, fullParticipation)).withScores(constant(20), constant(recovering)),
+		},
+		{
+			name: "scores_around_bail_out_threshold",
+			pre:  f.epochState().withParticipation(every(2, fullParticipation), all(noParticipation)).withScores(constant(0), aroundThreshold),
+		},
+		{
+			name: "waiting_for_exit_keeps_bail_out_score",
+			pre: f.epochState().
+				withParticipation(all(noParticipation), all(noParticipation)).
+				withScores(constant(0), aroundThreshold).
+				withValidators(0, 8, exiting(epochProcessingEpoch+2)),
+		},
+		{
+			name: "slashed_validators",
+			pre: f.epochState().
+				withParticipation(all(fullParticipation), all(fullParticipation)).
+				withScores(constant(20), constant(recovering)).
+				withValidators(0, 8, slashed(epochProcessingEpoch)),
+		},
+		{
+			name: "genesis_epoch_is_noop",
+			pre:  f.state().atEpochEnd(0).withParticipation(all(noParticipation), all(noParticipation)).withScores(constant(20), constant(recovering)),
+		},
+	}, processInactivityAndBailOutUpdates)
+}
+
+func processInactivityAndBailOutUpdates(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+	vp, bp, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	vp, _, err = altair.ProcessEpochParticipation(ctx, st, bp, vp)
+	if err != nil {
+		return nil, err
+	}
+	st, _, err = altair.ProcessInactivityAndBailOutScores(ctx, st, vp)
+	return st, err
+}
+
+var rewardAdjustmentFactorHandler = &stateHandler{
+	runner:  "epoch_processing",
+	handler: "reward_adjustment_factor",
+	cases:   rewardAdjustmentFactorCases,
+}
+
+func rewardAdjustmentFactorCases(_ context.Context, f *fixture) ([]*stateCase, error) {
+	reserve := gwei(1000000)
+	maxFactor := helpers.MaxBoostYield(epochProcessingEpoch)
+	return epochCases([]epochCase{
+		{
+			name: "deposit_below_plan_raises_factor",
+			pre:  f.epochState().withTokenomics(reserve+1, reserve, maxFactor/2),
+		},
+		{
+			name: "deposit_above_plan_lowers_factor",
+			pre:  f.epochState().aboveDepositPlan().withTokenomics(reserve+1, reserve, maxFactor/2),
+		},
+		{
+			name: "factor_capped_at_max_boost_yield",
+			pre:  f.epochState().withTokenomics(reserve+1, reserve, maxFactor),
+		},
+		{
+			name: "factor_floored_at_zero",
+			pre:  f.epochState().aboveDepositPlan().withTokenomics(reserve+1, reserve, 1),
+		},
+		{
+			name: "pending_and_exiting_validators",
+			pre: f.epochState().
+				aboveDepositPlan().
+				withValidators(0, 16, pending(epochProcessingEpoch-1)).
+				withValidators(16, 40, exiting(epochProcessingEpoch+2)).
+				withTokenomics(reserve+1, reserve, maxFactor/2),
+		},
+		{
+			name: "empty_reserve",
+			pre:  f.epochState().withTokenomics(1, 0, maxFactor/2),
+		},
+	}, func(_ context.Context, st state.BeaconState) (state.BeaconState, error) {
+		return st, helpers.ProcessRewardfactorUpdate(st)
+	})
+}
+
+var rewardsAndPenaltiesHandler = &stateHandler{
+	runner:  "epoch_processing",
+	handler: "rewards_and_penalties",
+	cases:   rewardsAndPenaltiesCases,
+}
+
+func rewardsAndPenaltiesCases(_ context.Context, f *fixture) ([]*stateCase, error) {
+	reserve := gwei(1000000)
+	maxFactor := helpers.MaxBoostYield(epochProcessingEpoch)
+	return epochCases([]epochCase{
+		{
+			name: "full_participation_with_reserve",
+			pre:  f.epochState().withParticipation(all(fullParticipation), all(fullParticipation)).withTokenomics(reserve, reserve, maxFactor),
+		},
+		{
+			name: "partial_participation_with_reserve",
+			pre:  f.epochState().withParticipation(every(2, timelySourceTarget), all(fullParticipation)).withTokenomics(reserve, reserve, maxFactor),
+		},
+		{
+			name: "no_participation_with_reserve",
+			pre:  f.epochState().withParticipation(all(noParticipation), all(noParticipation)).withTokenomics(reserve, reserve, maxFactor),
+		},
+		{
+			name: "feedback_boost_capped_by_previous_reserve",
+			pre:  f.epochState().withParticipation(all(fullParticipation), all(fullParticipation)).withTokenomics(1000, reserve, maxFactor),
+		},
+		{
+			name: "current_reserve_smaller_than_usage",
+			pre:  f.epochState().withParticipation(all(fullParticipation), all(fullParticipation)).withTokenomics(reserve, 1000, maxFactor),
+		},
+		{
+			name: "empty_reserve",
+			pre:  f.epochState().withParticipation(all(fullParticipation), all(fullParticipation)).withTokenomics(0, 0, maxFactor),
+		},
+		{
+			name: "inactivity_leak_with_reserve",
+			pre: f.epochState().
+				inLeak().
+				withParticipation(every(2, fullParticipation), all(fullParticipation)).
+				withScores(constant(40), constant(0)).
+				withTokenomics(reserve, reserve, maxFactor),
+		},
+	}, processRewardsAndPenalties)
+}
+
+func processRewardsAndPenalties(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+	vp, bp, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	vp, bp, err = altair.ProcessEpochParticipation(ctx, st, bp, vp)
+	if err != nil {
+		return nil, err
+	}
+	return altair.ProcessRewardsAndPenaltiesPrecompute(st, bp, vp)
+}
+
+var registryUpdatesHandler = &stateHandler{
+	runner:  "epoch_processing",
+	handler: "registry_updates",
+	cases:   registryUpdatesCases,
+}
+
+func registryUpdatesCases(_ context.Context, f *fixture) ([]*stateCase, error) {
+	cfg := params.BeaconConfig()
+	// Queue more validators than the churn limit, with or without deposit bias, can activate.
+	queued := primitives.ValidatorIndex(cfg.MinPerEpochChurnLimit + cfg.ChurnLimitBias + 2)
+	finalized := epochProcessingEpoch - 2
+	ejected := func(v *ethpb.Validator) { v.EffectiveBalance = cfg.EjectionBalance }
+	return epochCases([]epochCase{
+		{
+			name: "activation_queue_below_deposit_plan",
+			pre:  f.epochState().withValidators(0, queued, pending(finalized)),
+		},
+		{
+			name: "activation_queue_above_deposit_plan",
+			pre:  f.epochState().aboveDepositPlan().withValidators(0, queued, pending(finalized)),
+		},
+		{
+			name: "activation_queue_not_finalized",
+			pre:  f.epochState().withValidators(0, queued, pending(finalized+1)),
+		},
+		{
+			name: "ejections_below_deposit_plan",
+			pre:  f.epochState().withValidators(0, queued, ejected),
+		},
+		{
+			name: "ejections_above_deposit_plan",
+			pre:  f.epochState().aboveDepositPlan().withValidators(0, queued, ejected),
+		},
+	}, func(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+		return epoch.ProcessRegistryUpdates(ctx, st)
+	})
+}
@@ -0,0 +1,173 @@
+// Package generator writes conformance test vectors for the Over specific parts of the
+// consensus rules, which the upstream consensus spec tests do not cover. Vectors use the
+// upstream spectest directory layout,
+//
+//	tests/<config>/<fork>/<runner>/<handler>/<suite>/<case>/
+//
+// where state transition cases hold a pre.ssz_snappy state, an optional <input>.ssz_snappy
+// operation and a post.ssz_snappy state, which is absent when the operation must be rejected.
+// Pure function cases hold a single data.yaml file instead.
+package generator
+
+import (
+	"context"
+	"path"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Fork is the fork every vector is generated for.
+	Fork = "capella"
+	// Suite is the suite directory of every handler.
+	Suite = "over_tests"
+
+	preFile  = "pre.ssz_snappy"
+	postFile = "post.ssz_snappy"
+	dataFile = "data.yaml"
+)
+
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+}
+
+// stateCase is a single vector of a state transition function. The process function
+// receives a copy of the pre-state with the case input already bound.
+type stateCase struct {
+	name    string
+	pre     state.BeaconState
+	input   sszMarshaler
+	process func(ctx context.Context, st state.BeaconState) (state.BeaconState, error)
+	// invalid cases must be rejected by process and have no post-state.
+	invalid bool
+}
+
+// stateHandler generates the cases of a state transition handler.
+type stateHandler struct {
+	runner  string
+	handler string
+	// input is the file name, without extension, of the operation of each case.
+	input string
+	cases func(ctx context.Context, f *fixture) ([]*stateCase, error)
+}
+
+// dataCase is a single vector of a pure function, written as data.yaml.
+type dataCase struct {
+	name string
+	data interface{}
+}
+
+// dataHandler generates the cases of a pure function handler.
+type dataHandler struct {
+	runner  string
+	handler string
+	cases   func() ([]*dataCase, error)
+}
+
+var stateHandlers = []*stateHandler{
+	bailOutHandler,
+	attestationHandler,
+	syncAggregateHandler,
+	inactivityAndBailOutUpdatesHandler,
+	rewardAdjustmentFactorHandler,
+	rewardsAndPenaltiesHandler,
+	registryUpdatesHandler,
+}
+
+var dataHandlers = []*dataHandler{
+	churnLimitHandler,
+}
+
+// Generate writes every vector under root for the active beacon config, which is named by config.
+func Generate(ctx context.Context, root, config string) error {
+	f, err := newFixture(ctx)
+	if err != nil {
+		return err
+	}
+	base := path.Join(root, "tests", config, Fork)
+	for _, h := range stateHandlers {
+		cases, err := h.cases(ctx, f)
+		if err != nil {
+			return errors.Wrapf(err, "could not build %s/%s cases", h.runner, h.handler)
+		}
+		dir := path.Join(base, h.runner, h.handler, Suite)
+		for _, c := range cases {
+			if err := writeStateCase(ctx, path.Join(dir, c.name), h.input, c); err != nil {
+				return errors.Wrapf(err, "could not write %s/%s case %s", h.runner, h.handler, c.name)
+			}
+		}
+		log.WithField("handler", path.Join(h.runner, h.handler)).Infof("Wrote %d cases", len(cases))
+	}
+	for _, h := range dataHandlers {
+		cases, err := h.cases()
+		if err != nil {
+			return errors.Wrapf(err, "could not build %s/%s cases", h.runner, h.handler)
+		}
+		dir := path.Join(base, h.runner, h.handler, Suite)
+		for _, c := range cases {
+			if err := writeDataCase(path.Join(dir, c.name), c); err != nil {
+				return errors.Wrapf(err, "could not write %s/%s case %s", h.runner, h.handler, c.name)
+			}
+		}
+		log.WithField("handler", path.Join(h.runner, h.handler)).Infof("Wrote %d cases", len(cases))
+	}
+	return nil
+}
+
+func writeStateCase(ctx context.Context, dir, input string, c *stateCase) error {
+	if err := file.MkdirAll(dir); err != nil {
+		return err
+	}
+	if err := writeSSZSnappy(path.Join(dir, preFile), c.pre); err != nil {
+		return err
+	}
+	if c.input != nil {
+		if err := writeSSZSnappy(path.Join(dir, input+".ssz_snappy"), c.input); err != nil {
+			return err
+		}
+	}
+	// Caches are keyed by roots that synthetic pre-states may share, so never reuse them across cases.
+	helpers.ClearCache()
+	post, err := c.process(ctx, c.pre.Copy())
+	if c.invalid {
+		if err == nil {
+			return errors.New("invalid case was processed without error")
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return writeSSZSnappy(path.Join(dir, postFile), post)
+}
+
+func writeDataCase(dir string, c *dataCase) error {
+	if err := file.MkdirAll(dir); err != nil {
+		return err
+	}
+	enc, err := yaml.Marshal(c.data)
+	if err != nil {
+		return err
+	}
+	return file.WriteFile(path.Join(dir, dataFile), enc)
+}
+
+func writeSSZSnappy(name string, obj sszMarshaler) error {
+	enc, err := obj.MarshalSSZ()
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal %s", path.Base(name))
+	}
+	return file.WriteFile(name, snappy.Encode(nil, enc))
+}
+
+// gwei converts whole OVER to Gwei.
+func gwei(over uint64) uint64 {
+	return over * params.BeaconConfig().GweiPerEth
+}
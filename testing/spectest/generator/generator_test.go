@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/shared/over/epoch_processing"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/shared/over/operations"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/shared/over/tokenomics"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+)
+
+func TestGenerate(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	ctx := context.Background()
+	root := t.TempDir()
+	require.NoError(t, Generate(ctx, root, "mainnet"))
+
+	t.Run("deterministic", func(t *testing.T) {
+		again := t.TempDir()
+		require.NoError(t, Generate(ctx, again, "mainnet"))
+		want, err := file.HashDir(path.Join(root, "tests"))
+		require.NoError(t, err)
+		got, err := file.HashDir(path.Join(again, "tests"))
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+	t.Run("runners", func(t *testing.T) {
+		operations.RunBailOutTest(t, root, "mainnet")
+		operations.RunAttestationTest(t, root, "mainnet")
+		operations.RunSyncAggregateTest(t, root, "mainnet")
+		epoch_processing.RunInactivityAndBailOutUpdatesTests(t, root, "mainnet")
+		epoch_processing.RunRewardAdjustmentFactorTests(t, root, "mainnet")
+		epoch_processing.RunRewardsAndPenaltiesTests(t, root, "mainnet")
+		epoch_processing.RunRegistryUpdatesTests(t, root, "mainnet")
+		tokenomics.RunChurnLimitTests(t, root, "mainnet")
+	})
+
+	// The cases must exercise what their names claim.
+	caseStates := func(t *testing.T, handler, name string) (state.BeaconState, state.BeaconState) {
+		dir := path.Join(root, "tests", "mainnet", Fork, handler, Suite, name)
+		return utils.CapellaState(t, dir, preFile), utils.CapellaState(t, dir, postFile)
+	}
+	t.Run("bail out exit churn bias", func(t *testing.T) {
+		queueEpoch := helpers.ActivationExitEpoch(operationsEpoch)
+		_, post := caseStates(t, "operations/bail_out", "success_exit_queue_full_below_deposit_plan")
+		v, err := post.ValidatorAtIndexReadOnly(3)
+		require.NoError(t, err)
+		assert.Equal(t, queueEpoch+1, v.ExitEpoch())
+
+		_, post = caseStates(t, "operations/bail_out", "success_exit_queue_biased_above_deposit_plan")
+		v, err = post.ValidatorAtIndexReadOnly(3)
+		require.NoError(t, err)
+		assert.Equal(t, queueEpoch, v.ExitEpoch())
+	})
+	t.Run("activation churn bias", func(t *testing.T) {
+		activated := func(st state.BeaconState) uint64 {
+			n := uint64(0)
+			for _, v := range st.Validators() {
+				if v.ActivationEpoch == helpers.ActivationExitEpoch(epochProcessingEpoch) {
+					n++
+				}
+			}
+			return n
+		}
+		cfg := params.BeaconConfig()
+		_, post := caseStates(t, "epoch_processing/registry_updates", "activation_queue_below_deposit_plan")
+		assert.Equal(t, cfg.MinPerEpochChurnLimit+cfg.ChurnLimitBias, activated(post))
+		_, post = caseStates(t, "epoch_processing/registry_updates", "activation_queue_above_deposit_plan")
+		assert.Equal(t, cfg.MinPerEpochChurnLimit, activated(post))
+	})
+	t.Run("reserve usage", func(t *testing.T) {
+		for _, c := range []struct {
+			handler, name string
+		}{
+			{"operations/attestation", "current_epoch_with_reserve"},
+			{"operations/attestation", "previous_epoch_with_reserve"},
+			{"epoch_processing/rewards_and_penalties", "full_participation_with_reserve"},
+			{"epoch_processing/rewards_and_penalties", "partial_participation_with_reserve"},
+		} {
+			pre, post := caseStates(t, c.handler, c.name)
+			assert.Equal(t, true, post.CurrentEpochReserve() < pre.CurrentEpochReserve(), "%s/%s did not use the reserve", c.handler, c.name)
+		}
+		_, post := caseStates(t, "operations/attestation", "reserve_smaller_than_usage")
+		assert.Equal(t, uint64(0), post.CurrentEpochReserve())
+	})
+	t.Run("reward adjustment factor feedback", func(t *testing.T) {
+		pre, post := caseStates(t, "epoch_processing/reward_adjustment_factor", "deposit_below_plan_raises_factor")
+		assert.Equal(t, true, post.RewardAdjustmentFactor() > pre.RewardAdjustmentFactor())
+		assert.Equal(t, pre.CurrentEpochReserve(), post.PreviousEpochReserve())
+		pre, post = caseStates(t, "epoch_processing/reward_adjustment_factor", "deposit_above_plan_lowers_factor")
+		assert.Equal(t, true, post.RewardAdjustmentFactor() < pre.RewardAdjustmentFactor())
+		pre, post = caseStates(t, "epoch_processing/reward_adjustment_factor", "factor_capped_at_max_boost_yield")
+		assert.Equal(t, pre.RewardAdjustmentFactor(), post.RewardAdjustmentFactor())
+		_, post = caseStates(t, "epoch_processing/reward_adjustment_factor", "factor_floored_at_zero")
+		assert.Equal(t, uint64(0), post.RewardAdjustmentFactor())
+	})
+	t.Run("bail out scores", func(t *testing.T) {
+		pre, post := caseStates(t, "epoch_processing/inactivity_and_bail_out_updates", "no_participation_raises_scores")
+		preScores, err := pre.BailOutScores()
+		require.NoError(t, err)
+		postScores, err := post.BailOutScores()
+		require.NoError(t, err)
+		assert.Equal(t, preScores[0]+params.BeaconConfig().BailOutScoreBias, postScores[0])
+
+		pre, post = caseStates(t, "epoch_processing/inactivity_and_bail_out_updates", "full_participation_recovers_scores")
+		preScores, err = pre.BailOutScores()
+		require.NoError(t, err)
+		postScores, err = post.BailOutScores()
+		require.NoError(t, err)
+		assert.Equal(t, preScores[0]-helpers.BailOutRecoveryScore(numValidators), postScores[0])
+	})
+	t.Run("invalid cases have no post-state", func(t *testing.T) {
+		dir := path.Join(root, "tests", "mainnet", Fork, "operations/bail_out", Suite, "invalid_below_threshold")
+		assert.Equal(t, true, utils.FileExists(dir, preFile))
+		assert.Equal(t, false, utils.FileExists(dir, postFile))
+	})
+}
@@ -0,0 +1,386 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	b "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// operationsEpoch is the epoch the state of every operation case is in.
+const operationsEpoch = primitives.Epoch(5)
+
+// operationsState returns a state in the middle of operationsEpoch with a recent finalized checkpoint.
+func (f *fixture) operationsState() *stateBuilder {
+	start, err := slots.EpochStart(operationsEpoch)
+	if err != nil {
+		return &stateBuilder{err: err}
+	}
+	return f.state().
+		atSlot(start+params.BeaconConfig().SlotsPerEpoch/2).
+		withFinalized(operationsEpoch-1, operationsEpoch-2)
+}
+
+var bailOutHandler = &stateHandler{
+	runner:  "operations",
+	handler: "bail_out",
+	input:   "bail_out",
+	cases:   bailOutCases,
+}
+
+func bailOutCases(_ context.Context, f *fixture) ([]*stateCase, error) {
+	cfg := params.BeaconConfig()
+	threshold := cfg.BailOutScoreThreshold
+	bailingOut := primitives.ValidatorIndex(3)
+	scoreOf := func(score uint64) func(i int) uint64 {
+		return func(i int) uint64 {
+			if primitives.ValidatorIndex(i) == bailingOut {
+				return score
+			}
+			return 0
+		}
+	}
+	// Fill the exit queue up to the churn limit without deposit bias.
+	queueEpoch := helpers.ActivationExitEpoch(operationsEpoch)
+	fullQueue := func(sb *stateBuilder) *stateBuilder {
+		return sb.withValidators(10, 10+primitives.ValidatorIndex(cfg.MinPerEpochChurnLimit), exiting(queueEpoch))
+	}
+
+	specs := []struct {
+		name    string
+		pre     *stateBuilder
+		index   primitives.ValidatorIndex
+		invalid bool
+	}{
+		{
+			name: "success_at_threshold",
+			pre:  f.operationsState().withScores(constant(0), scoreOf(threshold)),
+		},
+		{
+			name: "success_above_threshold",
+			pre:  f.operationsState().withScores(constant(0), scoreOf(threshold+2*cfg.BailOutScoreBias)),
+		},
+		{
+			name: "success_exit_queue_full_below_deposit_plan",
+			pre:  fullQueue(f.operationsState().withScores(constant(0), scoreOf(threshold))),
+		},
+		{
+			name: "success_exit_queue_biased_above_deposit_plan",
+			pre:  fullQueue(f.operationsState().aboveDepositPlan().withScores(constant(0), scoreOf(threshold))),
+		},
+		{
+			name:    "invalid_below_threshold",
+			pre:     f.operationsState().withScores(constant(0), scoreOf(threshold-1)),
+			invalid: true,
+		},
+		{
+			name: "invalid_already_exiting",
+			pre: f.operationsState().
+				withScores(constant(0), scoreOf(threshold)).
+				withValidator(bailingOut, exiting(queueEpoch)),
+			invalid: true,
+		},
+		{
+			name: "invalid_not_active",
+			pre: f.operationsState().
+				withScores(constant(0), scoreOf(threshold)).
+				withValidator(bailingOut, pending(operationsEpoch-1)),
+			invalid: true,
+		},
+		{
+			name:    "invalid_unknown_index",
+			pre:     f.operationsState(),
+			index:   numValidators,
+			invalid: true,
+		},
+	}
+	cases := make([]*stateCase, 0, len(specs))
+	for _, s := range specs {
+		pre, err := s.pre.build()
+		if err != nil {
+			return nil, errors.Wrap(err, s.name)
+		}
+		index := bailingOut
+		if s.index != 0 {
+			index = s.index
+		}
+		op := &ethpb.BailOut{ValidatorIndex: index}
+		cases = append(cases, &stateCase{
+			name:  s.name,
+			pre:   pre,
+			input: op,
+			process: func(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+				return b.ProcessBailOuts(ctx, st, []*ethpb.BailOut{op})
+			},
+			invalid: s.invalid,
+		})
+	}
+	return cases, nil
+}
+
+var attestationHandler = &stateHandler{
+	runner:  "operations",
+	handler: "attestation",
+	input:   "attestation",
+	cases:   attestationCases,
+}
+
+func attestationCases(ctx context.Context, f *fixture) ([]*stateCase, error) {
+	cfg := params.BeaconConfig()
+	reserve := gwei(1000000)
+	maxFactor := helpers.MaxBoostYield(operationsEpoch)
+
+	specs := []struct {
+		name string
+		pre  *stateBuilder
+		// slotDelay is how many slots before the state slot the attestation is for.
+		slotDelay primitives.Slot
+		invalid   bool
+	}{
+		{
+			name:      "current_epoch_with_reserve",
+			pre:       f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			slotDelay: 1,
+		},
+		{
+			name:      "previous_epoch_with_reserve",
+			pre:       f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			slotDelay: cfg.SlotsPerEpoch,
+		},
+		{
+			name:      "late_inclusion_with_reserve",
+			pre:       f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			slotDelay: 3,
+		},
+		{
+			name:      "empty_reserve",
+			pre:       f.operationsState().withTokenomics(0, 0, maxFactor),
+			slotDelay: 1,
+		},
+		{
+			name:      "reserve_smaller_than_usage",
+			pre:       f.operationsState().withTokenomics(reserve, 1, maxFactor),
+			slotDelay: 1,
+		},
+		{
+			name:      "zero_reward_adjustment_factor",
+			pre:       f.operationsState().withTokenomics(reserve, reserve, 0),
+			slotDelay: 1,
+		},
+		{
+			name:      "invalid_too_old",
+			pre:       f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			slotDelay: 2*cfg.SlotsPerEpoch + 1,
+			invalid:   true,
+		},
+	}
+	cases := make([]*stateCase, 0, len(specs))
+	for _, s := range specs {
+		pre, err := s.pre.build()
+		if err != nil {
+			return nil, errors.Wrap(err, s.name)
+		}
+		att, err := f.attestation(ctx, pre, pre.Slot()-s.slotDelay)
+		if err != nil {
+			return nil, errors.Wrap(err, s.name)
+		}
+		cases = append(cases, &stateCase{
+			name:    s.name,
+			pre:     pre,
+			input:   att,
+			process: processAttestation(att),
+			invalid: s.invalid,
+		})
+	}
+	return cases, nil
+}
+
+func processAttestation(att *ethpb.Attestation) func(context.Context, state.BeaconState) (state.BeaconState, error) {
+	return func(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+		if err := b.VerifyAttestationSignature(ctx, st, att); err != nil {
+			return nil, err
+		}
+		totalBalance, err := helpers.TotalActiveBalance(st)
+		if err != nil {
+			return nil, err
+		}
+		return altair.ProcessAttestationNoVerifySignature(ctx, st, att, totalBalance)
+	}
+}
+
+// attestation returns an attestation of the whole first committee of the slot, signed by
+// its members, that matches the state's justified checkpoint and block roots.
+func (f *fixture) attestation(ctx context.Context, st state.BeaconState, slot primitives.Slot) (*ethpb.Attestation, error) {
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, slot, 0)
+	if err != nil {
+		return nil, err
+	}
+	targetEpoch := slots.ToEpoch(slot)
+	source := st.CurrentJustifiedCheckpoint()
+	if targetEpoch < slots.ToEpoch(st.Slot()) {
+		source = st.PreviousJustifiedCheckpoint()
+	}
+	headRoot, err := helpers.BlockRootAtSlot(st, slot)
+	if err != nil {
+		return nil, err
+	}
+	targetRoot, err := helpers.BlockRoot(st, targetEpoch)
+	if err != nil {
+		return nil, err
+	}
+	data := &ethpb.AttestationData{
+		Slot:            slot,
+		CommitteeIndex:  0,
+		BeaconBlockRoot: headRoot,
+		Source:          source,
+		Target:          &ethpb.Checkpoint{Epoch: targetEpoch, Root: targetRoot},
+	}
+	domain, err := signing.Domain(st.Fork(), targetEpoch, params.BeaconConfig().DomainBeaconAttester, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	root, err := signing.ComputeSigningRoot(data, domain)
+	if err != nil {
+		return nil, err
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	sigs := make([]bls.Signature, len(committee))
+	for i, idx := range committee {
+		bits.SetBitAt(uint64(i), true)
+		sigs[i] = f.privs[idx].Sign(root[:])
+	}
+	return &ethpb.Attestation{
+		AggregationBits: bits,
+		Data:            data,
+		Signature:       bls.AggregateSignatures(sigs).Marshal(),
+	}, nil
+}
+
+var syncAggregateHandler = &stateHandler{
+	runner:  "operations",
+	handler: "sync_aggregate",
+	input:   "sync_aggregate",
+	cases:   syncAggregateCases,
+}
+
+func syncAggregateCases(_ context.Context, f *fixture) ([]*stateCase, error) {
+	reserve := gwei(1000000)
+	maxFactor := helpers.MaxBoostYield(operationsEpoch)
+	specs := []struct {
+		name          string
+		pre           *stateBuilder
+		participating func(i int) bool
+		badSignature  bool
+		invalid       bool
+	}{
+		{
+			name:          "full_participation_with_reserve",
+			pre:           f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			participating: func(int) bool { return true },
+		},
+		{
+			name:          "half_participation_with_reserve",
+			pre:           f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			participating: func(i int) bool { return i%2 == 0 },
+		},
+		{
+			name:          "full_participation_empty_reserve",
+			pre:           f.operationsState().withTokenomics(0, 0, maxFactor),
+			participating: func(int) bool { return true },
+		},
+		{
+			name:          "no_participation",
+			pre:           f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			participating: func(int) bool { return false },
+		},
+		{
+			name:          "invalid_signature",
+			pre:           f.operationsState().withTokenomics(reserve, reserve, maxFactor),
+			participating: func(int) bool { return true },
+			badSignature:  true,
+			invalid:       true,
+		},
+	}
+	cases := make([]*stateCase, 0, len(specs))
+	for _, s := range specs {
+		pre, err := s.pre.build()
+		if err != nil {
+			return nil, errors.Wrap(err, s.name)
+		}
+		agg, err := f.syncAggregate(pre, s.participating, s.badSignature)
+		if err != nil {
+			return nil, errors.Wrap(err, s.name)
+		}
+		cases = append(cases, &stateCase{
+			name:  s.name,
+			pre:   pre,
+			input: agg,
+			process: func(ctx context.Context, st state.BeaconState) (state.BeaconState, error) {
+				st, _, err := altair.ProcessSyncAggregate(ctx, st, agg)
+				return st, err
+			},
+			invalid: s.invalid,
+		})
+	}
+	return cases, nil
+}
+
+// syncAggregate returns a sync aggregate of the current sync committee members for which
+// participating returns true, signing the block root of the previous slot. With badSignature
+// the members sign the wrong root.
+func (f *fixture) syncAggregate(st state.BeaconState, participating func(i int) bool, badSignature bool) (*ethpb.SyncAggregate, error) {
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, err
+	}
+	prevSlot := slots.PrevSlot(st.Slot())
+	domain, err := signing.Domain(st.Fork(), slots.ToEpoch(prevSlot), params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
+	if err != nil {
+		return nil, err
+	}
+	blockRoot, err := helpers.BlockRootAtSlot(st, prevSlot)
+	if err != nil {
+		return nil, err
+	}
+	if badSignature {
+		blockRoot = bytesutil.PadTo([]byte("bad signature"), 32)
+	}
+	msg := primitives.SSZBytes(blockRoot)
+	root, err := signing.ComputeSigningRoot(&msg, domain)
+	if err != nil {
+		return nil, err
+	}
+	bits := bitfield.NewBitvector512()
+	var sigs []bls.Signature
+	for i, pubkey := range committee.Pubkeys {
+		if !participating(i) {
+			continue
+		}
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+		if !ok {
+			return nil, errors.Errorf("sync committee member %#x is not in the registry", pubkey)
+		}
+		bits.SetBitAt(uint64(i), true)
+		sigs = append(sigs, f.privs[idx].Sign(root[:]))
+	}
+	sig := common.InfiniteSignature[:]
+	if len(sigs) > 0 {
+		sig = bls.AggregateSignatures(sigs).Marshal()
+	}
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: sig,
+	}, nil
+}
@@ -0,0 +1,238 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// numValidators is the registry size of every generated pre-state.
+const numValidators = 64
+
+// Participation flag combinations used to fill epoch participation.
+const (
+	flagSource         = 1 << 0
+	flagTarget         = 1 << 1
+	flagHead           = 1 << 2
+	fullParticipation  = flagSource | flagTarget | flagHead
+	noParticipation    = 0
+	timelySourceTarget = flagSource | flagTarget
+)
+
+// fixture holds the deterministic genesis every case derives its pre-state from.
+type fixture struct {
+	genesis state.BeaconState
+	privs   []bls.SecretKey
+}
+
+func newFixture(ctx context.Context) (*fixture, error) {
+	gb := interop.GethTestnetGenesis(0, params.BeaconConfig()).ToBlock()
+	st, err := interop.NewPreminedGenesis(ctx, 0, numValidators, 0, version.Capella, gb)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate genesis state")
+	}
+	// Premined genesis leaves the participation lists empty.
+	if err := st.SetPreviousParticipationBits(make([]byte, numValidators)); err != nil {
+		return nil, err
+	}
+	if err := st.SetCurrentParticipationBits(make([]byte, numValidators)); err != nil {
+		return nil, err
+	}
+	privs, _, err := interop.DeterministicallyGenerateKeys(0, numValidators)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate validator keys")
+	}
+	return &fixture{genesis: st, privs: privs}, nil
+}
+
+// stateBuilder applies a chain of modifications to a copy of the genesis state.
+type stateBuilder struct {
+	st  state.BeaconState
+	err error
+}
+
+func (f *fixture) state() *stateBuilder {
+	return &stateBuilder{st: f.genesis.Copy()}
+}
+
+func (b *stateBuilder) apply(fn func(st state.BeaconState) error) *stateBuilder {
+	if b.err == nil {
+		b.err = fn(b.st)
+	}
+	return b
+}
+
+// build returns the modified state, or the first error any modification returned.
+func (b *stateBuilder) build() (state.BeaconState, error) {
+	return b.st, b.err
+}
+
+// atSlot moves the state to the given slot without processing anything.
+func (b *stateBuilder) atSlot(slot primitives.Slot) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		return st.SetSlot(slot)
+	})
+}
+
+// atEpochEnd moves the state to the last slot of the given epoch, where epoch processing runs.
+func (b *stateBuilder) atEpochEnd(epoch primitives.Epoch) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		end, err := slots.EpochEnd(epoch)
+		if err != nil {
+			return err
+		}
+		return st.SetSlot(end)
+	})
+}
+
+// withFinalized sets the justified and finalized checkpoints to the given epochs.
+func (b *stateBuilder) withFinalized(justified, finalized primitives.Epoch) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		root := make([]byte, 32)
+		if err := st.SetPreviousJustifiedCheckpoint(&ethpb.Checkpoint{Epoch: justified, Root: root}); err != nil {
+			return err
+		}
+		if err := st.SetCurrentJustifiedCheckpoint(&ethpb.Checkpoint{Epoch: justified, Root: root}); err != nil {
+			return err
+		}
+		return st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: finalized, Root: root})
+	})
+}
+
+// withTokenomics sets the reserves and the reward adjustment factor.
+func (b *stateBuilder) withTokenomics(previousReserve, currentReserve, rewardAdjustmentFactor uint64) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		if err := st.SetPreviousEpochReserve(previousReserve); err != nil {
+			return err
+		}
+		if err := st.SetCurrentEpochReserve(currentReserve); err != nil {
+			return err
+		}
+		return st.SetRewardAdjustmentFactor(rewardAdjustmentFactor)
+	})
+}
+
+// withParticipation fills the previous and current epoch participation with the flags returned for each index.
+func (b *stateBuilder) withParticipation(previous, current func(i int) byte) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		prev := make([]byte, st.NumValidators())
+		cur := make([]byte, st.NumValidators())
+		for i := range prev {
+			prev[i] = previous(i)
+			cur[i] = current(i)
+		}
+		if err := st.SetPreviousParticipationBits(prev); err != nil {
+			return err
+		}
+		return st.SetCurrentParticipationBits(cur)
+	})
+}
+
+// withScores sets the inactivity and bail out scores returned for each index.
+func (b *stateBuilder) withScores(inactivity, bailOut func(i int) uint64) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		is := make([]uint64, st.NumValidators())
+		bs := make([]uint64, st.NumValidators())
+		for i := range is {
+			is[i] = inactivity(i)
+			bs[i] = bailOut(i)
+		}
+		if err := st.SetInactivityScores(is); err != nil {
+			return err
+		}
+		return st.SetBailOutScores(bs)
+	})
+}
+
+// withValidator modifies the validator at the given index.
+func (b *stateBuilder) withValidator(idx primitives.ValidatorIndex, fn func(v *ethpb.Validator)) *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		v, err := st.ValidatorAtIndex(idx)
+		if err != nil {
+			return err
+		}
+		fn(v)
+		return st.UpdateValidatorAtIndex(idx, v)
+	})
+}
+
+// withValidators modifies the validators in [from, to).
+func (b *stateBuilder) withValidators(from, to primitives.ValidatorIndex, fn func(v *ethpb.Validator)) *stateBuilder {
+	for idx := from; idx < to; idx++ {
+		b = b.withValidator(idx, fn)
+	}
+	return b
+}
+
+// aboveDepositPlan raises every effective balance so that the total deposit exceeds the
+// target deposit plan of the first years, which flips the direction of the churn limit bias
+// and of the reward adjustment factor feedback.
+func (b *stateBuilder) aboveDepositPlan() *stateBuilder {
+	return b.apply(func(st state.BeaconState) error {
+		balance := params.BeaconConfig().DepositPlanFinal / numValidators * 2
+		bals := st.Balances()
+		for i := range bals {
+			bals[i] = balance
+		}
+		if err := st.SetBalances(bals); err != nil {
+			return err
+		}
+		// The validators are shared with the genesis state, so modify copies.
+		return st.ApplyToEveryValidator(func(_ int, v *ethpb.Validator) (bool, *ethpb.Validator, error) {
+			v = ethpb.CopyValidator(v)
+			v.EffectiveBalance = balance
+			return true, v, nil
+		})
+	})
+}
+
+// pending turns the validator into one that deposited but has not been activated yet.
+func pending(eligibility primitives.Epoch) func(v *ethpb.Validator) {
+	return func(v *ethpb.Validator) {
+		v.ActivationEligibilityEpoch = eligibility
+		v.ActivationEpoch = params.BeaconConfig().FarFutureEpoch
+	}
+}
+
+// exiting turns the validator into one that initiated an exit at the given epoch.
+func exiting(exitEpoch primitives.Epoch) func(v *ethpb.Validator) {
+	return func(v *ethpb.Validator) {
+		v.ExitEpoch = exitEpoch
+		v.WithdrawableEpoch = exitEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
+	}
+}
+
+// slashed turns the validator into one slashed at the given epoch.
+func slashed(epoch primitives.Epoch) func(v *ethpb.Validator) {
+	return func(v *ethpb.Validator) {
+		v.Slashed = true
+		v.ExitEpoch = epoch + 1
+		v.WithdrawableEpoch = epoch + params.BeaconConfig().EpochsPerSlashingsVector
+	}
+}
+
+func all(flags byte) func(int) byte {
+	return func(int) byte { return flags }
+}
+
+// every returns flags for every n-th index and no participation otherwise.
+func every(n int, flags byte) func(int) byte {
+	return func(i int) byte {
+		if i%n == 0 {
+			return flags
+		}
+		return noParticipation
+	}
+}
+
+func constant(v uint64) func(int) uint64 {
+	return func(int) uint64 { return v }
+}
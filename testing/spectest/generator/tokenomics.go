@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// churnLimitData is the data.yaml content of a tokenomics/churn_limit case.
+type churnLimitData struct {
+	ActiveValidatorCount   uint64 `yaml:"active_validator_count"`
+	ActiveValidatorDeposit uint64 `yaml:"active_validator_deposit"`
+	Epoch                  uint64 `yaml:"epoch"`
+	IsExit                 bool   `yaml:"is_exit"`
+	ChurnLimit             uint64 `yaml:"churn_limit"`
+}
+
+var churnLimitHandler = &dataHandler{
+	runner:  "tokenomics",
+	handler: "churn_limit",
+	cases:   churnLimitCases,
+}
+
+func churnLimitCases() ([]*dataCase, error) {
+	cfg := params.BeaconConfig()
+	early := primitives.Epoch(cfg.EpochsPerYear)
+	// A year into the second deposit plan segment, and a year after the last one.
+	later := primitives.Epoch(cfg.EpochsPerYear * (cfg.DepositPlan[0].EndYear + 1))
+	final := primitives.Epoch(cfg.EpochsPerYear * (cfg.DepositPlan[len(cfg.DepositPlan)-1].EndYear + 1))
+	// Enough validators for the churn limit to exceed its minimum.
+	manyValidators := cfg.ChurnLimitQuotient * (cfg.MinPerEpochChurnLimit + 3)
+
+	specs := []struct {
+		name   string
+		count  uint64
+		epoch  primitives.Epoch
+		offset int64
+	}{
+		{"genesis_below_plan", numValidators, 0, -1},
+		{"genesis_at_plan", numValidators, 0, 0},
+		{"genesis_above_plan", numValidators, 0, 1},
+		{"early_plan_below", numValidators, early, -1},
+		{"early_plan_above", numValidators, early, 1},
+		{"later_plan_below", numValidators, later, -1},
+		{"later_plan_above", numValidators, later, 1},
+		{"final_plan_below", numValidators, final, -1},
+		{"final_plan_above", numValidators, final, 1},
+		{"many_validators_below_plan", manyValidators, early, -1},
+		{"many_validators_above_plan", manyValidators, early, 1},
+	}
+	cases := make([]*dataCase, 0, 2*len(specs))
+	for _, s := range specs {
+		deposit := helpers.TargetDepositPlan(s.epoch)
+		switch {
+		case s.offset < 0:
+			deposit -= uint64(-s.offset) * cfg.EffectiveBalanceIncrement
+		case s.offset > 0:
+			deposit += uint64(s.offset) * cfg.EffectiveBalanceIncrement
+		}
+		for _, isExit := range []bool{false, true} {
+			limit, err := helpers.ValidatorChurnLimit(s.count, deposit, s.epoch, isExit)
+			if err != nil {
+				return nil, err
+			}
+			name := s.name + "_activation"
+			if isExit {
+				name = s.name + "_exit"
+			}
+			cases = append(cases, &dataCase{
+				name: name,
+				data: &churnLimitData{
+					ActiveValidatorCount:   s.count,
+					ActiveValidatorDeposit: deposit,
+					Epoch:                  uint64(s.epoch),
+					IsExit:                 isExit,
+					ChurnLimit:             limit,
+				},
+			})
+		}
+	}
+	return cases, nil
+}
@@ -0,0 +1,12 @@
+package over
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/shared/over/tokenomics"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+)
+
+func TestMainnet_Capella_Over_Tokenomics_ChurnLimit(t *testing.T) {
+	tokenomics.RunChurnLimitTests(t, utils.VectorsRoot(t), "mainnet")
+}
@@ -0,0 +1,12 @@
+package over
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/shared/over/epoch_processing"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+)
+
+func TestMainnet_Capella_Over_EpochProcessing_InactivityAndBailOutUpdates(t *testing.T) {
+	epoch_processing.RunInactivityAndBailOutUpdatesTests(t, utils.VectorsRoot(t), "mainnet")
+}
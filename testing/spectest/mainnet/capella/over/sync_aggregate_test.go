@@ -0,0 +1,12 @@
+package over
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/shared/over/operations"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+)
+
+func TestMainnet_Capella_Over_Operations_SyncAggregate(t *testing.T) {
+	operations.RunSyncAggregateTest(t, utils.VectorsRoot(t), "mainnet")
+}
@@ -0,0 +1,81 @@
+// Package epoch_processing runs the Over specific epoch processing sub-step vectors.
+package epoch_processing
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+)
+
+const (
+	fork  = "capella"
+	suite = "over_tests"
+)
+
+type epochOperation func(ctx context.Context, s state.BeaconState) (state.BeaconState, error)
+
+// RunInactivityAndBailOutUpdatesTests executes "epoch_processing/inactivity_and_bail_out_updates" tests.
+func RunInactivityAndBailOutUpdatesTests(t *testing.T, root, config string) {
+	runEpochOperationTest(t, root, config, "inactivity_and_bail_out_updates", func(ctx context.Context, s state.BeaconState) (state.BeaconState, error) {
+		vp, bp, err := altair.InitializePrecomputeValidators(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		vp, _, err = altair.ProcessEpochParticipation(ctx, s, bp, vp)
+		if err != nil {
+			return nil, err
+		}
+		s, _, err = altair.ProcessInactivityAndBailOutScores(ctx, s, vp)
+		return s, err
+	})
+}
+
+// RunRewardAdjustmentFactorTests executes "epoch_processing/reward_adjustment_factor" tests.
+func RunRewardAdjustmentFactorTests(t *testing.T, root, config string) {
+	runEpochOperationTest(t, root, config, "reward_adjustment_factor", func(_ context.Context, s state.BeaconState) (state.BeaconState, error) {
+		return s, helpers.ProcessRewardfactorUpdate(s)
+	})
+}
+
+// RunRewardsAndPenaltiesTests executes "epoch_processing/rewards_and_penalties" tests.
+func RunRewardsAndPenaltiesTests(t *testing.T, root, config string) {
+	runEpochOperationTest(t, root, config, "rewards_and_penalties", func(ctx context.Context, s state.BeaconState) (state.BeaconState, error) {
+		vp, bp, err := altair.InitializePrecomputeValidators(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		vp, bp, err = altair.ProcessEpochParticipation(ctx, s, bp, vp)
+		if err != nil {
+			return nil, err
+		}
+		return altair.ProcessRewardsAndPenaltiesPrecompute(s, bp, vp)
+	})
+}
+
+// RunRegistryUpdatesTests executes "epoch_processing/registry_updates" tests.
+func RunRegistryUpdatesTests(t *testing.T, root, config string) {
+	runEpochOperationTest(t, root, config, "registry_updates", epoch.ProcessRegistryUpdates)
+}
+
+// runEpochOperationTest applies the sub-step of every case to its pre-state and expects the post-state.
+func runEpochOperationTest(t *testing.T, root, config, handler string, fn epochOperation) {
+	testFolders, testsFolderPath := utils.TestFolders(t, root, config, fork, path.Join("epoch_processing", handler, suite))
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			helpers.ClearCache()
+			folderPath := path.Join(testsFolderPath, folder.Name())
+			pre := utils.CapellaState(t, folderPath, "pre.ssz_snappy")
+
+			post, err := fn(context.Background(), pre)
+			require.NoError(t, err)
+			utils.RequireCapellaStateEqual(t, folderPath, "post.ssz_snappy", post)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package operations runs the Over specific block operation vectors.
+package operations
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	b "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+)
+
+const (
+	fork  = "capella"
+	suite = "over_tests"
+)
+
+type operation func(ctx context.Context, s state.BeaconState, input []byte) (state.BeaconState, error)
+
+// RunBailOutTest executes "operations/bail_out" tests.
+func RunBailOutTest(t *testing.T, root, config string) {
+	runOperationTest(t, root, config, "bail_out", "bail_out", func(ctx context.Context, s state.BeaconState, input []byte) (state.BeaconState, error) {
+		op := &ethpb.BailOut{}
+		if err := op.UnmarshalSSZ(input); err != nil {
+			return nil, err
+		}
+		return b.ProcessBailOuts(ctx, s, []*ethpb.BailOut{op})
+	})
+}
+
+// RunAttestationTest executes "operations/attestation" tests.
+func RunAttestationTest(t *testing.T, root, config string) {
+	runOperationTest(t, root, config, "attestation", "attestation", func(ctx context.Context, s state.BeaconState, input []byte) (state.BeaconState, error) {
+		att := &ethpb.Attestation{}
+		if err := att.UnmarshalSSZ(input); err != nil {
+			return nil, err
+		}
+		if err := b.VerifyAttestationSignature(ctx, s, att); err != nil {
+			return nil, err
+		}
+		totalBalance, err := helpers.TotalActiveBalance(s)
+		if err != nil {
+			return nil, err
+		}
+		return altair.ProcessAttestationNoVerifySignature(ctx, s, att, totalBalance)
+	})
+}
+
+// RunSyncAggregateTest executes "operations/sync_aggregate" tests.
+func RunSyncAggregateTest(t *testing.T, root, config string) {
+	runOperationTest(t, root, config, "sync_aggregate", "sync_aggregate", func(ctx context.Context, s state.BeaconState, input []byte) (state.BeaconState, error) {
+		agg := &ethpb.SyncAggregate{}
+		if err := agg.UnmarshalSSZ(input); err != nil {
+			return nil, err
+		}
+		s, _, err := altair.ProcessSyncAggregate(ctx, s, agg)
+		return s, err
+	})
+}
+
+// runOperationTest applies the operation of every case to its pre-state, expecting the post-state,
+// or an error when the case has no post-state.
+func runOperationTest(t *testing.T, root, config, handler, inputName string, fn operation) {
+	testFolders, testsFolderPath := utils.TestFolders(t, root, config, fork, path.Join("operations", handler, suite))
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			helpers.ClearCache()
+			folderPath := path.Join(testsFolderPath, folder.Name())
+			pre := utils.CapellaState(t, folderPath, "pre.ssz_snappy")
+			input := utils.SSZSnappyFile(t, folderPath, inputName+".ssz_snappy")
+
+			post, err := fn(context.Background(), pre, input)
+			if !utils.FileExists(folderPath, "post.ssz_snappy") {
+				require.NotNil(t, err, "Did not fail when expected")
+				return
+			}
+			require.NoError(t, err)
+			utils.RequireCapellaStateEqual(t, folderPath, "post.ssz_snappy", post)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package tokenomics runs the Over specific tokenomics function vectors.
+package tokenomics
+
+import (
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// ChurnLimitTest is the data.yaml content of a churn limit case.
+type ChurnLimitTest struct {
+	ActiveValidatorCount   uint64 `yaml:"active_validator_count"`
+	ActiveValidatorDeposit uint64 `yaml:"active_validator_deposit"`
+	Epoch                  uint64 `yaml:"epoch"`
+	IsExit                 bool   `yaml:"is_exit"`
+	ChurnLimit             uint64 `yaml:"churn_limit"`
+}
+
+// RunChurnLimitTests executes "tokenomics/churn_limit" tests.
+func RunChurnLimitTests(t *testing.T, root, config string) {
+	testFolders, testsFolderPath := utils.TestFolders(t, root, config, "capella", "tokenomics/churn_limit/over_tests")
+	for _, folder := range testFolders {
+		t.Run(folder.Name(), func(t *testing.T) {
+			enc, err := file.ReadFileAsBytes(path.Join(testsFolderPath, folder.Name(), "data.yaml"))
+			require.NoError(t, err)
+			test := &ChurnLimitTest{}
+			require.NoError(t, yaml.Unmarshal(enc, test))
+
+			limit, err := helpers.ValidatorChurnLimit(test.ActiveValidatorCount, test.ActiveValidatorDeposit, primitives.Epoch(test.Epoch), test.IsExit)
+			require.NoError(t, err)
+			require.Equal(t, test.ChurnLimit, limit)
+		})
+	}
+}
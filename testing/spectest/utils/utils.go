@@ -0,0 +1,76 @@
+// Package utils provides the helpers shared by the spectest runners to locate and decode
+// test vectors laid out like the upstream consensus spec tests.
+package utils
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/d4l3k/messagediff.v1"
+)
+
+// VectorsDirEnv is the environment variable holding the directory that contains the tests/ tree of vectors.
+const VectorsDirEnv = "OVER_SPEC_TESTS_DIR"
+
+// VectorsRoot returns the vectors directory from VectorsDirEnv, skipping the test when it is not set.
+func VectorsRoot(t testing.TB) string {
+	root := os.Getenv(VectorsDirEnv)
+	if root == "" {
+		t.Skipf("%s is not set, generate vectors with tools/over-spectest-gen to run this test", VectorsDirEnv)
+	}
+	return root
+}
+
+// TestFolders returns the case directories of a handler suite and the path they are in.
+func TestFolders(t testing.TB, root, config, forkOrPhase, folderPath string) ([]os.DirEntry, string) {
+	testsFolderPath := path.Join(root, "tests", config, forkOrPhase, folderPath)
+	testFolders, err := os.ReadDir(testsFolderPath)
+	require.NoError(t, err)
+	if len(testFolders) == 0 {
+		t.Fatalf("No test folders found at %s", testsFolderPath)
+	}
+	return testFolders, testsFolderPath
+}
+
+// FileExists reports whether the case directory holds the named file.
+func FileExists(dir, name string) bool {
+	return file.FileExists(path.Join(dir, name))
+}
+
+// SSZSnappyFile returns the decompressed contents of the named ssz_snappy file.
+func SSZSnappyFile(t testing.TB, dir, name string) []byte {
+	enc, err := file.ReadFileAsBytes(path.Join(dir, name))
+	require.NoError(t, err)
+	ssz, err := snappy.Decode(nil /* dst */, enc)
+	require.NoError(t, err, "Failed to decompress %s", name)
+	return ssz
+}
+
+// CapellaState decodes the named ssz_snappy Capella state file.
+func CapellaState(t testing.TB, dir, name string) state.BeaconState {
+	st := &ethpb.BeaconStateCapella{}
+	require.NoError(t, st.UnmarshalSSZ(SSZSnappyFile(t, dir, name)), "Failed to unmarshal %s", name)
+	s, err := state_native.InitializeFromProtoCapella(st)
+	require.NoError(t, err)
+	return s
+}
+
+// RequireCapellaStateEqual fails the test with a diff when the state differs from the named ssz_snappy state file.
+func RequireCapellaStateEqual(t testing.TB, dir, name string, st state.BeaconState) {
+	want := &ethpb.BeaconStateCapella{}
+	require.NoError(t, want.UnmarshalSSZ(SSZSnappyFile(t, dir, name)), "Failed to unmarshal %s", name)
+	got, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateCapella)
+	require.Equal(t, true, ok, "State is not a Capella state")
+	if !proto.Equal(want, got) {
+		diff, _ := messagediff.PrettyDiff(want, got)
+		t.Fatalf("Post state does not match expected: %s", diff)
+	}
+}
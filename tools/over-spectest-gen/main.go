@@ -0,0 +1,43 @@
+// This tool writes the conformance test vectors of the Over specific consensus functions,
+// in the upstream consensus spec tests layout, so that other Over clients can test against
+// the same source of truth. See testing/spectest/generator for the generated handlers.
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/spectest/generator"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	outputDir       = flag.String("output-dir", "", "Directory to write the tests/ tree of vectors to")
+	configName      = flag.String("config", params.MainnetName, "Name of the config to generate vectors for, used as the config directory name")
+	chainConfigFile = flag.String("chain-config-file", "", "Optional YAML chain config to generate vectors for, overrides the config values of --config")
+)
+
+func main() {
+	flag.Parse()
+	if *outputDir == "" {
+		log.Fatal("Please specify --output-dir to write the vectors to")
+	}
+
+	cfg, err := params.ByName(*configName)
+	if err != nil {
+		log.WithError(err).Fatal("Could not find config")
+	}
+	if *chainConfigFile != "" {
+		cfg, err = params.UnmarshalConfigFile(*chainConfigFile, cfg.Copy())
+		if err != nil {
+			log.WithError(err).Fatal("Could not load chain config file")
+		}
+	}
+	params.OverrideBeaconConfig(cfg)
+
+	if err := generator.Generate(context.Background(), *outputDir, *configName); err != nil {
+		log.WithError(err).Fatal("Could not generate vectors")
+	}
+	log.Infof("Wrote vectors to %s", *outputDir)
+}
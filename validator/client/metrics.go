@@ -3,9 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -212,6 +215,39 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorBailOutScoreGaugeVec used to track validator bailout scores.
+	ValidatorBailOutScoreGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "bail_out_score",
+			Help:      "Validator bailout score. The validator can be forcibly exited once it reaches the bailout score threshold",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
+	// ValidatorBailOutScorePercentGaugeVec used to track validator bailout scores as a percentage of the threshold.
+	ValidatorBailOutScorePercentGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "bail_out_score_threshold_percent",
+			Help:      "Validator bailout score as a percentage of the bailout score threshold",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
+	// ValidatorBailOutsVec used to count the bail outs of validators included in blocks.
+	ValidatorBailOutsVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "bail_outs",
+			Help:      "Count the bail outs of the validator included in blocks, each forcing the validator to exit",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
@@ -263,6 +299,13 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot primiti
 	}
 	v.prevBalanceLock.Unlock()
 
+	if slots.ToEpoch(slot) >= params.BeaconConfig().AltairForkEpoch {
+		scores := v.bailOutScoresByKey(resp)
+		for _, pubKey := range resp.PublicKeys {
+			v.logBailOutScore(pubKey, scores)
+		}
+	}
+
 	v.UpdateLogAggregateStats(resp, slot)
 	return nil
 }
@@ -370,6 +413,81 @@ func (v *validator) logForEachValidator(index int, pubKey []byte, resp *ethpb.Va
 	v.prevBalance[pubKeyBytes] = balBeforeEpoch
 }
 
+// bailOutScoresByKey maps the bailout scores of the response to the public keys they belong to. Unlike the
+// other per validator fields, the scores also cover inactive validators reported as missing, in validator
+// index order, so the active and missing validators with a known index are walked in that same order.
+// Missing validators that are not part of the validator summary have the highest indices and come last.
+func (v *validator) bailOutScoresByKey(resp *ethpb.ValidatorPerformanceResponse) map[[fieldparams.BLSPubkeyLength]byte]string {
+	type indexedKey struct {
+		index  primitives.ValidatorIndex
+		pubKey [fieldparams.BLSPubkeyLength]byte
+	}
+	keys := make([]indexedKey, 0, len(resp.PublicKeys)+len(resp.MissingValidators))
+	for _, pk := range append(append([][]byte{}, resp.PublicKeys...), resp.MissingValidators...) {
+		pubKey := bytesutil.ToBytes48(pk)
+		if index, ok := v.pubkeyToValidatorIndex[pubKey]; ok {
+			keys = append(keys, indexedKey{index: index, pubKey: pubKey})
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].index < keys[j].index
+	})
+	scores := make(map[[fieldparams.BLSPubkeyLength]byte]string, len(resp.BailOutScores))
+	for i := 0; i < len(keys) && i < len(resp.BailOutScores); i++ {
+		scores[keys[i].pubKey] = resp.BailOutScores[i]
+	}
+	return scores
+}
+
+// logBailOutScore exports the bailout score of a validator and warns when the score gets close to
+// the bailout score threshold, with increasing severity as higher warning thresholds are crossed.
+func (v *validator) logBailOutScore(pubKey []byte, scores map[[fieldparams.BLSPubkeyLength]byte]string) {
+	truncatedKey := fmt.Sprintf("%#x", bytesutil.Trunc(pubKey))
+	rawScore, ok := scores[bytesutil.ToBytes48(pubKey)]
+	if !ok {
+		log.WithField("pubKey", truncatedKey).Warn("Missing bailout score")
+		return
+	}
+	score, err := strconv.ParseUint(rawScore, 10, 64)
+	if err != nil {
+		log.WithError(err).WithField("pubKey", truncatedKey).Warn("Could not parse bailout score")
+		return
+	}
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	percent := float64(score) / float64(threshold) * 100
+	if v.emitAccountMetrics {
+		fmtKey := fmt.Sprintf("%#x", pubKey)
+		ValidatorBailOutScoreGaugeVec.WithLabelValues(fmtKey).Set(float64(score))
+		ValidatorBailOutScorePercentGaugeVec.WithLabelValues(fmtKey).Set(percent)
+	}
+
+	fields := logrus.Fields{
+		"pubKey":             truncatedKey,
+		"bailOutScore":       score,
+		"percentOfThreshold": fmt.Sprintf("%.2f%%", percent),
+	}
+	if score >= threshold {
+		log.WithFields(fields).Error("Validator reached the bailout score threshold and can be forcibly exited at any time")
+		return
+	}
+	crossed := -1
+	for i, warnPercent := range v.bailOutWarnThresholds {
+		if percent >= float64(warnPercent) {
+			crossed = i
+		}
+	}
+	if crossed < 0 {
+		return
+	}
+	fields["warnThreshold"] = fmt.Sprintf("%d%%", v.bailOutWarnThresholds[crossed])
+	fields["missedTargetVotesBeforeBailOut"] = helpers.MissedTargetVotesBeforeBailOut(score)
+	if crossed == len(v.bailOutWarnThresholds)-1 {
+		log.WithFields(fields).Error("Validator bailout score is close to the bailout score threshold")
+	} else {
+		log.WithFields(fields).Warn("Validator bailout score is rising towards the bailout score threshold")
+	}
+}
+
 // UpdateLogAggregateStats updates and logs the voteStats struct of a validator using the RPC response obtained from LogValidatorGainsAndLosses.
 func (v *validator) UpdateLogAggregateStats(resp *ethpb.ValidatorPerformanceResponse, slot primitives.Slot) {
 	summary := &v.voteStats
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"strconv"
 	"testing"
 
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -170,3 +171,87 @@ func TestUpdateLogAltairAggregateStats(t *testing.T) {
 		"correctlyVotedHeadPct=\"86%\" correctlyVotedSourcePct=\"100%\" "+
 		"correctlyVotedTargetPct=\"71%\" numberOfEpochs=3 pctChangeCombinedBalance=\"0.20555%\"")
 }
+
+func TestLogBailOutScore(t *testing.T) {
+	threshold := params.BeaconConfig().BailOutScoreThreshold
+	pubKey := bytesutil.FromBytes48(bytesutil.ToBytes48([]byte("000000000000000000000000000000000000000012345678")))
+	v := &validator{
+		bailOutWarnThresholds: []uint64{50, 75, 90},
+	}
+
+	tests := []struct {
+		name      string
+		score     string
+		logged    string
+		notLogged string
+	}{
+		{
+			name:      "below warn thresholds",
+			score:     strconv.FormatUint(threshold/10, 10),
+			notLogged: "Validator bailout score",
+		},
+		{
+			name:      "first warn threshold",
+			score:     strconv.FormatUint(threshold/100*60, 10),
+			logged:    "level=warning msg=\"Validator bailout score is rising towards the bailout score threshold\"",
+			notLogged: "level=error",
+		},
+		{
+			name:   "last warn threshold",
+			score:  strconv.FormatUint(threshold/100*95, 10),
+			logged: "level=error msg=\"Validator bailout score is close to the bailout score threshold\"",
+		},
+		{
+			name:   "reached threshold",
+			score:  strconv.FormatUint(threshold, 10),
+			logged: "Validator reached the bailout score threshold and can be forcibly exited at any time",
+		},
+		{
+			name:   "missing score",
+			logged: "Missing bailout score",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := logTest.NewGlobal()
+			scores := make(map[[fieldparams.BLSPubkeyLength]byte]string)
+			if tt.score != "" {
+				scores[bytesutil.ToBytes48(pubKey)] = tt.score
+			}
+			v.logBailOutScore(pubKey, scores)
+			if tt.logged != "" {
+				require.LogsContain(t, hook, tt.logged)
+			}
+			if tt.notLogged != "" {
+				require.LogsDoNotContain(t, hook, tt.notLogged)
+			}
+		})
+	}
+}
+
+func TestBailOutScoresByKey(t *testing.T) {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 4)
+	for i := range pubKeys {
+		pubKeys[i] = bytesutil.ToBytes48([]byte{byte(i + 1)})
+	}
+	v := &validator{
+		pubkeyToValidatorIndex: map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex{
+			pubKeys[0]: 3,
+			pubKeys[1]: 1,
+			pubKeys[2]: 2,
+			pubKeys[3]: 10,
+		},
+	}
+	// Validator 2 is inactive and validator 10 is not in the validator summary yet. The scores
+	// are ordered by validator index and include the inactive validator.
+	resp := &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:        [][]byte{pubKeys[1][:], pubKeys[0][:]},
+		MissingValidators: [][]byte{pubKeys[3][:], pubKeys[2][:]},
+		BailOutScores:     []string{"100", "200", "300"},
+	}
+	scores := v.bailOutScoresByKey(resp)
+	require.Equal(t, 3, len(scores))
+	require.Equal(t, "100", scores[pubKeys[1]])
+	require.Equal(t, "200", scores[pubKeys[2]])
+	require.Equal(t, "300", scores[pubKeys[0]])
+}
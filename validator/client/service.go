@@ -59,6 +59,7 @@ type ValidatorService struct {
 	useWeb                bool
 	emitAccountMetrics    bool
 	logValidatorBalances  bool
+	bailOutWarnThresholds []uint64
 	interopKeysConfig     *local.InteropKeymanagerConfig
 	conn                  validatorHelpers.NodeConnection
 	grpcRetryDelay        time.Duration
@@ -85,6 +86,7 @@ type Config struct {
 	UseWeb                     bool
 	LogValidatorBalances       bool
 	EmitAccountMetrics         bool
+	BailOutScoreWarnThresholds []uint64
 	InteropKeysConfig          *local.InteropKeymanagerConfig
 	Wallet                     *wallet.Wallet
 	WalletInitializedFeed      *event.Feed
@@ -117,6 +119,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		dataDir:               cfg.DataDir,
 		graffiti:              []byte(cfg.GraffitiFlag),
 		logValidatorBalances:  cfg.LogValidatorBalances,
+		bailOutWarnThresholds: cfg.BailOutScoreWarnThresholds,
 		emitAccountMetrics:    cfg.EmitAccountMetrics,
 		maxCallRecvMsgSize:    cfg.GrpcMaxCallRecvMsgSizeFlag,
 		grpcRetries:           cfg.GrpcRetriesFlag,
@@ -203,6 +206,7 @@ func (v *ValidatorService) Start() {
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
+		bailOutWarnThresholds:          v.bailOutWarnThresholds,
 		startBalances:                  make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
 		prevBalance:                    make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
 		pubkeyToValidatorIndex:         make(map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex),
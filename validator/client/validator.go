@@ -32,6 +32,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	accountsiface "github.com/prysmaticlabs/prysm/v4/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
@@ -75,6 +76,7 @@ type validator struct {
 	highestValidSlotLock                 sync.Mutex
 	prevBalanceLock                      sync.RWMutex
 	slashableKeysLock                    sync.RWMutex
+	dutyPubKeysLock                      sync.RWMutex
 	eipImportBlacklistedPublicKeys       map[[fieldparams.BLSPubkeyLength]byte]bool
 	walletInitializedFeed                *event.Feed
 	attLogs                              map[[32]byte]*attSubmitted
@@ -82,8 +84,10 @@ type validator struct {
 	duties                               *ethpb.DutiesResponse
 	prevBalance                          map[[fieldparams.BLSPubkeyLength]byte]uint64
 	pubkeyToValidatorIndex               map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex
+	dutyPubKeys                          map[primitives.ValidatorIndex][fieldparams.BLSPubkeyLength]byte
 	signedValidatorRegistrations         map[[fieldparams.BLSPubkeyLength]byte]*ethpb.SignedValidatorRegistrationV1
 	graffitiOrderedIndex                 uint64
+	bailOutWarnThresholds                []uint64
 	aggregatedSlotCommitteeIDCache       *lru.Cache
 	domainDataCache                      *ristretto.Cache
 	highestValidSlot                     primitives.Slot
@@ -376,10 +380,60 @@ func (v *validator) ReceiveBlocks(ctx context.Context, connectionErrorChannel ch
 			v.highestValidSlot = blk.Block().Slot()
 		}
 		v.highestValidSlotLock.Unlock()
+		v.logBailOuts(blk)
 		v.blockFeed.Send(blk)
 	}
 }
 
+// logBailOuts logs an error and counts the bail out of every validator of this client
+// included in the given block, as a bail out forces the validator to exit. The validators
+// of this client are looked up in the duties of the current epoch.
+func (v *validator) logBailOuts(blk interfaces.ReadOnlySignedBeaconBlock) {
+	if blk.Version() == version.Phase0 {
+		return
+	}
+	bailOuts, err := blk.Block().Body().BailOuts()
+	if err != nil {
+		log.WithError(err).Error("Could not get bail outs from block")
+		return
+	}
+	if len(bailOuts) == 0 {
+		return
+	}
+	v.dutyPubKeysLock.RLock()
+	defer v.dutyPubKeysLock.RUnlock()
+	for _, b := range bailOuts {
+		pubKey, ok := v.dutyPubKeys[b.ValidatorIndex]
+		if !ok {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"pubKey":         fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+			"validatorIndex": b.ValidatorIndex,
+			"slot":           blk.Block().Slot(),
+		}).Error("Validator was bailed out and is being forcibly exited")
+		if v.emitAccountMetrics {
+			ValidatorBailOutsVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Inc()
+		}
+	}
+}
+
+// updateDutyPubKeys caches the public keys of the validators with duties by validator index, for
+// the block stream to recognize the validators of this client without querying the beacon node.
+func (v *validator) updateDutyPubKeys(duties []*ethpb.DutiesResponse_Duty) {
+	pubKeys := make(map[primitives.ValidatorIndex][fieldparams.BLSPubkeyLength]byte, len(duties))
+	for _, d := range duties {
+		// Validators that are not in the beacon state yet have no index.
+		if d.Status == ethpb.ValidatorStatus_UNKNOWN_STATUS || d.Status == ethpb.ValidatorStatus_DEPOSITED {
+			continue
+		}
+		pubKeys[d.ValidatorIndex] = bytesutil.ToBytes48(d.PublicKey)
+	}
+	v.dutyPubKeysLock.Lock()
+	v.dutyPubKeys = pubKeys
+	v.dutyPubKeysLock.Unlock()
+}
+
 func (v *validator) checkAndLogValidatorStatus(statuses []*validatorStatus, activeValCount uint64) bool {
 	activationsPerEpoch := uint64(math.Max(float64(params.BeaconConfig().MinPerEpochChurnLimit), float64(activeValCount/params.BeaconConfig().ChurnLimitQuotient)))
 
@@ -627,6 +681,7 @@ func (v *validator) UpdateDuties(ctx context.Context, slot primitives.Slot) erro
 
 	v.duties = resp
 	v.logDuties(slot, v.duties.CurrentEpochDuties)
+	v.updateDutyPubKeys(resp.CurrentEpochDuties)
 
 	// Non-blocking call for beacon node to start subscriptions for aggregators.
 	// Make sure to copy metadata into a new context
@@ -949,6 +949,46 @@ func TestService_ReceiveBlocks_SetHighest(t *testing.T) {
 	require.Equal(t, slot, v.highestValidSlot)
 }
 
+func TestService_ReceiveBlocks_LogsBailOuts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := validatormock.NewMockValidatorClient(ctrl)
+	kp := randKeypair(t)
+
+	v := validator{
+		validatorClient:    client,
+		blockFeed:          new(event.Feed),
+		emitAccountMetrics: true,
+	}
+	v.updateDutyPubKeys([]*ethpb.DutiesResponse_Duty{
+		{PublicKey: kp.pub[:], ValidatorIndex: 2, Status: ethpb.ValidatorStatus_ACTIVE},
+	})
+	stream := mock2.NewMockBeaconNodeValidatorAltair_StreamBlocksClient(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	client.EXPECT().StreamBlocksAltair(
+		gomock.Any(),
+		&ethpb.StreamBlocksRequest{VerifiedOnly: true},
+	).Return(stream, nil)
+	stream.EXPECT().Context().Return(ctx).AnyTimes()
+	blk := util.NewBeaconBlockAltair()
+	blk.Block.Slot = 100
+	blk.Block.Body.BailOuts = []*ethpb.BailOut{{ValidatorIndex: 1}, {ValidatorIndex: 2}}
+	stream.EXPECT().Recv().Return(
+		&ethpb.StreamBlocksResponse{
+			Block: &ethpb.StreamBlocksResponse_AltairBlock{AltairBlock: blk},
+		},
+		nil,
+	).Do(func() {
+		cancel()
+	})
+	hook := logTest.NewGlobal()
+	connectionErrorChannel := make(chan error)
+	v.ReceiveBlocks(ctx, connectionErrorChannel)
+	require.LogsContain(t, hook, "Validator was bailed out and is being forcibly exited")
+	require.LogsContain(t, hook, "validatorIndex=2")
+	require.LogsDoNotContain(t, hook, "validatorIndex=1")
+}
+
 type doppelGangerRequestMatcher struct {
 	req *ethpb.DoppelGangerRequest
 }
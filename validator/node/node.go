@@ -14,6 +14,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -370,6 +371,10 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	dataDir := c.cliCtx.String(cmd.DataDirFlag.Name)
 	logValidatorBalances := !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name)
 	emitAccountMetrics := !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name)
+	bailOutScoreWarnThresholds, err := bailOutScoreWarnThresholds(c.cliCtx)
+	if err != nil {
+		return err
+	}
 	cert := c.cliCtx.String(flags.CertFlag.Name)
 	graffiti := c.cliCtx.String(flags.GraffitiFlag.Name)
 	maxCallRecvMsgSize := c.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
@@ -384,7 +389,6 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	}
 
 	gStruct := &g.Graffiti{}
-	var err error
 	if c.cliCtx.IsSet(flags.GraffitiFileFlag.Name) {
 		n := c.cliCtx.String(flags.GraffitiFileFlag.Name)
 		gStruct, err = g.ParseGraffitiFile(n)
@@ -408,6 +412,7 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		DataDir:                    dataDir,
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
+		BailOutScoreWarnThresholds: bailOutScoreWarnThresholds,
 		CertFlag:                   cert,
 		GraffitiFlag:               g.ParseHexGraffiti(graffiti),
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
@@ -701,6 +706,23 @@ func reviewGasLimit(gasLimit validator.Uint64) validator.Uint64 {
 	return gasLimit
 }
 
+// bailOutScoreWarnThresholds parses the percentages of the bailout score threshold at which
+// warnings are logged, returning them in ascending order.
+func bailOutScoreWarnThresholds(cliCtx *cli.Context) ([]uint64, error) {
+	raw := cliCtx.IntSlice(flags.BailOutScoreWarnThresholdsFlag.Name)
+	thresholds := make([]uint64, 0, len(raw))
+	for _, pct := range raw {
+		if pct <= 0 || pct > 100 {
+			return nil, fmt.Errorf("--%s: %d is not a percentage between 1 and 100", flags.BailOutScoreWarnThresholdsFlag.Name, pct)
+		}
+		thresholds = append(thresholds, uint64(pct))
+	}
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i] < thresholds[j]
+	})
+	return thresholds, nil
+}
+
 func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 	var vs *client.ValidatorService
 	if err := c.services.FetchService(&vs); err != nil {
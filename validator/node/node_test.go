@@ -75,6 +75,36 @@ func TestClearDB(t *testing.T) {
 	require.LogsContain(t, hook, "Removing database")
 }
 
+func TestBailOutScoreWarnThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds []int
+		want       []uint64
+		wantErr    string
+	}{
+		{name: "sorted", thresholds: []int{90, 50, 75}, want: []uint64{50, 75, 90}},
+		{name: "full threshold", thresholds: []int{100}, want: []uint64{100}},
+		{name: "zero", thresholds: []int{0, 50}, wantErr: "0 is not a percentage between 1 and 100"},
+		{name: "above threshold", thresholds: []int{50, 150}, wantErr: "150 is not a percentage between 1 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.App{}
+			set := flag.NewFlagSet("test", 0)
+			set.Var(cli.NewIntSlice(tt.thresholds...), flags.BailOutScoreWarnThresholdsFlag.Name, "")
+			cliCtx := cli.NewContext(&app, set, nil)
+
+			got, err := bailOutScoreWarnThresholds(cliCtx)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.DeepEqual(t, tt.want, got)
+		})
+	}
+}
+
 // TestWeb3SignerConfig tests the web3 signer config returns the correct values.
 func TestWeb3SignerConfig(t *testing.T) {
 	pubkey1decoded, err := hexutil.Decode("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")